@@ -0,0 +1,261 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/idp"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/internal/webui"
+)
+
+// redacted replaces the values of fields tagged with redact:"true" when the config is printed.
+const redacted = "REDACTED"
+
+type Config struct {
+	Project         string `toml:"project" env:"PROJECT" env-default:"miam"`
+	Source          string `toml:"source" env:"SOURCE" env-default:"https://github.com/megakuul/miam"`
+	Backend         string `toml:"backend" env:"BACKEND"`
+	SecretsProvider string `toml:"secrets_provider" env:"SECRETS_PROVIDER"`
+	LogLevel        string `toml:"log_level" env:"LOG_LEVEL" env-default:"info"` // debug, info, warn or error
+
+	// the api is served with tls if a certificate and key are configured.
+	Addr        string   `toml:"addr" env:"ADDR" env-default:":8080"`
+	TLSCertFile string   `toml:"tls_cert_file" env:"TLS_CERT_FILE"`
+	TLSKeyFile  string   `toml:"tls_key_file" env:"TLS_KEY_FILE"`
+	CORSOrigins []string `toml:"cors_origins" env:"CORS_ORIGINS" env-separator:","`
+	// requests are rejected once their body exceeds the max request size or takes longer than the read timeout.
+	MaxRequestSize int           `toml:"max_request_size" env:"MAX_REQUEST_SIZE" env-default:"1048576"`
+	ReadTimeout    time.Duration `toml:"read_timeout" env:"READ_TIMEOUT" env-default:"30s"`
+	// every caller may call each procedure with the rate limit (requests per second) and burst,
+	// procedures that deploy clusters or the operator with the stricter deploy rate limit. A negative rate disables the limit.
+	RateLimit            float64 `toml:"rate_limit" env:"RATE_LIMIT" env-default:"10"`
+	RateLimitBurst       int     `toml:"rate_limit_burst" env:"RATE_LIMIT_BURST" env-default:"20"`
+	DeployRateLimit      float64 `toml:"deploy_rate_limit" env:"DEPLOY_RATE_LIMIT" env-default:"0.1"`
+	DeployRateLimitBurst int     `toml:"deploy_rate_limit_burst" env:"DEPLOY_RATE_LIMIT_BURST" env-default:"3"`
+	// unauthenticated callers are limited by the client address that the proxy in front of the operator appends to
+	// this header (e.g. X-Forwarded-For). Only set it behind a proxy that sets the header, callers can forge it otherwise.
+	TrustedProxyHeader string `toml:"trusted_proxy_header" env:"TRUSTED_PROXY_HEADER"`
+	// responses of deployments requested with an idempotency key are replayed to retries within the ttl.
+	IdempotencyTTL time.Duration `toml:"idempotency_ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	// the grpc reflection service lets tools like grpcurl or buf curl explore the api unless it is disabled.
+	DisableReflection bool `toml:"disable_reflection" env:"DISABLE_REFLECTION"`
+
+	// spans are exported via otlp/http to the endpoint (e.g. http://localhost:4318), tracing is disabled without endpoint.
+	// Requests without sampled parent are sampled with the ratio.
+	OTLPEndpoint     string  `toml:"otlp_endpoint" env:"OTLP_ENDPOINT"`
+	TraceSampleRatio float64 `toml:"trace_sample_ratio" env:"TRACE_SAMPLE_RATIO" env-default:"1"`
+
+	// on shutdown, in-flight requests are drained and running deployments are interrupted within the timeout.
+	// Interrupted deployments are resumed on the next start.
+	ShutdownTimeout time.Duration `toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"30s"`
+
+	// the aws region and the store endpoint default to the aws sdk configuration,
+	// the tables default to the tables provisioned for the project.
+	Region        string `toml:"region" env:"REGION"`
+	StoreEndpoint string `toml:"store_endpoint" env:"STORE_ENDPOINT"`
+	ClusterTable  string `toml:"cluster_table" env:"CLUSTER_TABLE"`
+	OperatorTable string `toml:"operator_table" env:"OPERATOR_TABLE"`
+	AuditTable    string `toml:"audit_table" env:"AUDIT_TABLE"`
+	IAMTable      string `toml:"iam_table" env:"IAM_TABLE"`
+
+	Workers        int           `toml:"workers" env:"WORKERS" env-default:"4"`
+	QueueSize      int           `toml:"queue_size" env:"QUEUE_SIZE" env-default:"64"`
+	RetentionCount int           `toml:"retention_count" env:"RETENTION_COUNT" env-default:"20"`
+	RetentionAge   time.Duration `toml:"retention_age" env:"RETENTION_AGE" env-default:"720h"`
+	PruneInterval  time.Duration `toml:"prune_interval" env:"PRUNE_INTERVAL" env-default:"1h"`
+	HistoryTTL     time.Duration `toml:"history_ttl" env:"HISTORY_TTL" env-default:"720h"`
+
+	// the operator refuses to start if neither an oidc issuer, idp, iam nor api key authentication is configured,
+	// unless it is explicitly started with --insecure-no-auth.
+	// Policies and bindings refer to identities of the idp (or the oidc issuer if no idp is configured) by their plain
+	// subject or email, identities of the oidc issuer are referred to as "<issuer>#<subject>" if an idp is configured.
+	OIDCIssuer   string `toml:"oidc_issuer" env:"OIDC_ISSUER"`
+	OIDCAudience string `toml:"oidc_audience" env:"OIDC_AUDIENCE"`
+	OIDCJWKSURL  string `toml:"oidc_jwks_url" env:"OIDC_JWKS_URL"`
+	IAMAuth      bool   `toml:"iam_auth" env:"IAM_AUTH"`
+	APIKeyAuth   bool   `toml:"api_key_auth" env:"API_KEY_AUTH"`
+
+	// policies grant roles to authenticated callers in addition to the role bindings managed through the iam api.
+	// Callers without policy or binding are denied.
+	Policies         []auth.Policy `toml:"policies"`
+	DirectoryRefresh time.Duration `toml:"directory_refresh" env:"DIRECTORY_REFRESH" env-default:"30s"`
+
+	// the operator acts as oidc provider for miam identities if an idp issuer (its public url) is configured.
+	// Tokens are signed with the kms keys (the first key signs) or with rotated keys persisted in the key directory.
+	IDPIssuer        string        `toml:"idp_issuer" env:"IDP_ISSUER"`
+	IDPTokenLifetime time.Duration `toml:"idp_token_lifetime" env:"IDP_TOKEN_LIFETIME" env-default:"1h"`
+	IDPKMSKeys       []string      `toml:"idp_kms_keys" env:"IDP_KMS_KEYS" env-separator:","`
+	IDPKeyDir        string        `toml:"idp_key_dir" env:"IDP_KEY_DIR"`
+	IDPKeyRotation   time.Duration `toml:"idp_key_rotation" env:"IDP_KEY_ROTATION" env-default:"720h"`
+	IDPClients       []idp.Client  `toml:"idp_clients"`
+
+	// users sign in at the idp through the upstream providers, their upstream groups are mapped to roles.
+	IDPUpstreams       []idp.Upstream `toml:"idp_upstreams"`
+	IDPSessionLifetime time.Duration  `toml:"idp_session_lifetime" env:"IDP_SESSION_LIFETIME" env-default:"12h"`
+
+	// the web ui is served at / if the operator was built with it. The ui calls the api at its own origin
+	// unless an api url is configured and signs in users at the oidc issuer (defaults to the idp issuer).
+	UIAPIURL       string   `toml:"ui_api_url" env:"UI_API_URL"`
+	UIOIDCIssuer   string   `toml:"ui_oidc_issuer" env:"UI_OIDC_ISSUER"`
+	UIOIDCClientID string   `toml:"ui_oidc_client_id" env:"UI_OIDC_CLIENT_ID"`
+	UIOIDCScopes   []string `toml:"ui_oidc_scopes" env:"UI_OIDC_SCOPES" env-separator:"," env-default:"openid,profile,email"`
+}
+
+// Tables returns the configured store tables, unset tables default to the tables of the project.
+func (c *Config) Tables() store.Tables {
+	tables := store.DefaultTables(c.Project)
+	for _, table := range []struct{ name, override *string }{
+		{&tables.Cluster, &c.ClusterTable},
+		{&tables.Operator, &c.OperatorTable},
+		{&tables.Audit, &c.AuditTable},
+		{&tables.IAM, &c.IAMTable},
+	} {
+		if *table.override != "" {
+			*table.name = *table.override
+		}
+	}
+	return tables
+}
+
+// Validate checks the config and reports all invalid settings at once.
+func (c *Config) Validate() error {
+	errs := []error{}
+	check := func(valid bool, format string, args ...any) {
+		if !valid {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	check(c.Project != "", "project must not be empty")
+	_, _, err := net.SplitHostPort(c.Addr)
+	check(err == nil, "addr '%s' must be a host and port (e.g. ':8080')", c.Addr)
+	check((c.TLSCertFile == "") == (c.TLSKeyFile == ""), "tls_cert_file and tls_key_file must be set together")
+	for _, origin := range c.CORSOrigins {
+		check(origin == "*" || absoluteURL(origin), "cors origin '%s' must be '*' or an absolute url", origin)
+	}
+	check(c.MaxRequestSize > 0, "max_request_size must be positive")
+	check(c.ReadTimeout > 0, "read_timeout must be positive")
+	check(c.RateLimit <= 0 || c.RateLimitBurst > 0, "rate_limit_burst must be positive")
+	check(c.DeployRateLimit <= 0 || c.DeployRateLimitBurst > 0, "deploy_rate_limit_burst must be positive")
+	check(c.IdempotencyTTL > 0, "idempotency_ttl must be positive")
+	check(c.StoreEndpoint == "" || absoluteURL(c.StoreEndpoint), "store_endpoint must be an absolute url")
+	_, err = c.Level()
+	check(err == nil, "log_level '%s' must be debug, info, warn or error", c.LogLevel)
+	check(c.OTLPEndpoint == "" || absoluteURL(c.OTLPEndpoint), "otlp_endpoint must be an absolute url")
+	check(c.TraceSampleRatio >= 0 && c.TraceSampleRatio <= 1, "trace_sample_ratio must be between 0 and 1")
+	check(c.ShutdownTimeout > 0, "shutdown_timeout must be positive")
+
+	check(c.Workers > 0, "workers must be positive")
+	check(c.QueueSize > 0, "queue_size must be positive")
+	check(c.RetentionCount >= 0, "retention_count must not be negative")
+	check(c.RetentionAge >= 0, "retention_age must not be negative")
+	check(c.PruneInterval >= 0, "prune_interval must not be negative")
+	check(c.HistoryTTL >= 0, "history_ttl must not be negative")
+	check(c.DirectoryRefresh > 0, "directory_refresh must be positive")
+
+	check(c.OIDCIssuer == "" || c.OIDCAudience != "", "oidc_audience must be set if oidc_issuer is configured")
+	check(c.OIDCIssuer == "" || absoluteURL(c.OIDCIssuer), "oidc_issuer must be an absolute url")
+	check(c.OIDCJWKSURL == "" || absoluteURL(c.OIDCJWKSURL), "oidc_jwks_url must be an absolute url")
+
+	check(c.IDPIssuer == "" || absoluteURL(c.IDPIssuer), "idp_issuer must be an absolute url")
+	check(c.IDPIssuer != "" || len(c.IDPClients) < 1, "idp_clients require an idp_issuer")
+	check(c.IDPIssuer != "" || len(c.IDPUpstreams) < 1, "idp_upstreams require an idp_issuer")
+	// keys held in memory are lost on restart and differ between replicas.
+	check(c.IDPIssuer == "" || len(c.IDPKMSKeys) > 0 || c.IDPKeyDir != "", "idp_issuer requires idp_kms_keys or an idp_key_dir")
+	check(c.IDPTokenLifetime > 0, "idp_token_lifetime must be positive")
+	check(c.IDPSessionLifetime > 0, "idp_session_lifetime must be positive")
+	check(c.IDPKeyRotation > 0, "idp_key_rotation must be positive")
+
+	check(c.UIAPIURL == "" || absoluteURL(c.UIAPIURL), "ui_api_url must be an absolute url")
+	check(c.UIOIDCIssuer == "" || absoluteURL(c.UIOIDCIssuer), "ui_oidc_issuer must be an absolute url")
+	return errors.Join(errs...)
+}
+
+// AuthConfigured reports whether any authentication is configured.
+func (c *Config) AuthConfigured() bool {
+	return c.OIDCIssuer != "" || c.IDPIssuer != "" || c.IAMAuth || c.APIKeyAuth
+}
+
+// TrustedIssuer returns the issuer whose identities are referred to by their plain names, the idp owns the names
+// of the miam users if it is configured.
+func (c *Config) TrustedIssuer() string {
+	if c.IDPIssuer != "" {
+		return c.IDPIssuer
+	}
+	return c.OIDCIssuer
+}
+
+// UIConfig returns the runtime config of the web ui.
+func (c *Config) UIConfig() webui.Config {
+	issuer := c.UIOIDCIssuer
+	if issuer == "" {
+		issuer = c.IDPIssuer
+	}
+	return webui.Config{
+		APIURL: c.UIAPIURL,
+		OIDC: webui.OIDCConfig{
+			Issuer:   issuer,
+			ClientID: c.UIOIDCClientID,
+			Scopes:   c.UIOIDCScopes,
+		},
+	}
+}
+
+// Level returns the parsed log level.
+func (c *Config) Level() (slog.Level, error) {
+	level := slog.LevelInfo
+	err := level.UnmarshalText([]byte(c.LogLevel))
+	return level, err
+}
+
+func absoluteURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && parsed.Scheme != "" && parsed.Host != ""
+}
+
+// Print writes the effective config as toml with all secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	printed := *c
+	tables := c.Tables()
+	printed.ClusterTable, printed.OperatorTable = tables.Cluster, tables.Operator
+	printed.AuditTable, printed.IAMTable = tables.Audit, tables.IAM
+	redact(reflect.ValueOf(&printed).Elem())
+	return toml.NewEncoder(w).Encode(printed)
+}
+
+// redact replaces non-empty string fields tagged with redact:"true" in place.
+// Slices are copied before their elements are redacted, so the original config is not modified.
+func redact(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.IsNil() || !value.CanSet() {
+			return
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		reflect.Copy(copied, value)
+		value.Set(copied)
+		for i := range value.Len() {
+			redact(value.Index(i))
+		}
+	case reflect.Struct:
+		for i := range value.NumField() {
+			field := value.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if value.Type().Field(i).Tag.Get("redact") == "true" && field.Kind() == reflect.String && field.String() != "" {
+				field.SetString(redacted)
+				continue
+			}
+			redact(field)
+		}
+	}
+}
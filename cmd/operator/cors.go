@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/megakuul/miam/internal/interceptor"
+	"github.com/rs/cors"
+)
+
+// corsMaxAge is the time browsers may cache a preflight response.
+const corsMaxAge = 2 * time.Hour
+
+var (
+	// corsAllowedHeaders are the request headers of the connect, grpc-web and grpc protocols and the api.
+	corsAllowedHeaders = []string{
+		"Authorization",
+		"Content-Type",
+		"Connect-Protocol-Version",
+		"Connect-Timeout-Ms",
+		"Connect-Accept-Encoding",
+		"Connect-Content-Encoding",
+		"Grpc-Timeout",
+		"Grpc-Accept-Encoding",
+		"Grpc-Encoding",
+		"X-Grpc-Web",
+		"X-User-Agent",
+		interceptor.RequestIDHeader,
+		interceptor.IdempotencyKeyHeader,
+	}
+	// corsExposedHeaders are the response headers browser clients need to read the result of a call.
+	// grpc-web returns the status in the body for most errors, but trailers-only responses carry it in headers.
+	corsExposedHeaders = []string{
+		"Content-Encoding",
+		"Connect-Content-Encoding",
+		"Grpc-Encoding",
+		"Grpc-Status",
+		"Grpc-Message",
+		"Grpc-Status-Details-Bin",
+		"Retry-After",
+		interceptor.RequestIDHeader,
+		interceptor.IdempotentReplayedHeader,
+	}
+)
+
+// withCORS answers browser preflight requests and allows the origins to call the api.
+// Credentials (cookies) are not allowed, browser clients authenticate with bearer tokens.
+// Without origins the handler is returned unchanged.
+func withCORS(handler http.Handler, origins []string) http.Handler {
+	if len(origins) < 1 {
+		return handler
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: origins,
+		// unary connect calls use post, or get if they are side-effect free.
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: corsAllowedHeaders,
+		ExposedHeaders: corsExposedHeaders,
+		MaxAge:         int(corsMaxAge.Seconds()),
+	}).Handler(handler)
+}
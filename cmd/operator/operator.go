@@ -3,48 +3,54 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/deploy"
+	"github.com/megakuul/miam/internal/health"
+	"github.com/megakuul/miam/internal/idp"
+	"github.com/megakuul/miam/internal/logging"
+	"github.com/megakuul/miam/internal/metrics"
+	"github.com/megakuul/miam/internal/reconciler"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/internal/tracing"
 	"github.com/spf13/pflag"
 )
 
 type Flags struct {
-	Config string
+	Config         string
+	PrintConfig    bool
+	InsecureNoAuth bool
 }
 
 func ReadFlags() *Flags {
 	flags := &Flags{}
 	pflag.StringVarP(&flags.Config, "config", "c", "config.toml", "Specify a custom config file")
+	pflag.BoolVar(&flags.PrintConfig, "print-config", false, "Print the effective config with redacted secrets and exit")
+	pflag.BoolVar(&flags.InsecureNoAuth, "insecure-no-auth", false, "Serve the api without authentication and authorization if no authentication is configured")
 	pflag.Parse()
 	return flags
 }
 
-type Config struct {
-	Project string `toml:"project" env:"PROJECT" env-default:"miam"`
-	Source  string `toml:"source" env:"SOURCE" env-default:"https://github.com/megakuul/miam"`
-}
-
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		select {
-		case <-sigs:
-			cancel()
-			os.Exit(1) // TODO remove
-			return
-		case <-ctx.Done():
-			return
-		}
-	}()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	// a second signal terminates the operator immediately.
+	context.AfterFunc(ctx, stop)
 
+	// replaced once the configured log level is known.
+	slog.SetDefault(logging.New(os.Stderr, slog.LevelInfo))
 	if err := run(ctx); err != nil {
-		os.Stderr.WriteString("ERROR: " + err.Error())
+		slog.Error("operator failed", "error", err)
 		os.Exit(1)
 	}
 }
@@ -60,8 +66,135 @@ func run(ctx context.Context) error {
 	if err := cleanenv.ReadEnv(config); err != nil {
 		return fmt.Errorf("cannot acquire env config: %v", err)
 	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid config:\n%v", err)
+	}
+	if flags.PrintConfig {
+		return config.Print(os.Stdout)
+	}
+	if level, err := config.Level(); err == nil {
+		slog.SetDefault(logging.New(os.Stderr, level))
+	}
+	if !config.AuthConfigured() {
+		if !flags.InsecureNoAuth {
+			return fmt.Errorf("no authentication is configured, configure oidc_issuer, iam_auth, idp_issuer or api_key_auth " +
+				"or serve the api to every caller with --insecure-no-auth")
+		}
+		slog.WarnContext(ctx, "serving the api without authentication and authorization, every caller has full access")
+	} else if flags.InsecureNoAuth {
+		return fmt.Errorf("--insecure-no-auth must not be set if authentication is configured")
+	}
+
+	verifiers := []auth.Verifier{}
+	if config.OIDCIssuer != "" {
+		verifier, err := auth.NewOIDCVerifier(ctx, config.OIDCIssuer, config.OIDCAudience, config.OIDCJWKSURL)
+		if err != nil {
+			return fmt.Errorf("cannot initialize oidc verifier: %v", err)
+		}
+		verifiers = append(verifiers, verifier)
+	}
+	if config.IAMAuth {
+		verifiers = append(verifiers, auth.NewIAMVerifier(config.Project))
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, config.OTLPEndpoint, config.TraceSampleRatio)
+	if err != nil {
+		return fmt.Errorf("cannot initialize tracing: %v", err)
+	}
+	defer func() {
+		// flushes the spans of the shutdown itself, so it runs with a fresh timeout.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.ShutdownTimeout)
+		defer cancel()
+		shutdownTracing(ctx)
+	}()
+
+	awsOptions := []func(*awsconfig.LoadOptions) error{}
+	if config.Region != "" {
+		awsOptions = append(awsOptions, awsconfig.WithRegion(config.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, awsOptions...)
+	if err != nil {
+		return fmt.Errorf("cannot load aws config: %v", err)
+	}
+	metrics := metrics.New()
+	dynamoClient := dynamodb.NewFromConfig(awsConfig, metrics.StoreOption(), tracing.StoreOption(), func(o *dynamodb.Options) {
+		if config.StoreEndpoint != "" {
+			o.BaseEndpoint = &config.StoreEndpoint
+		}
+	})
+	store := store.New(dynamoClient, config.Project, config.Tables())
+	runner := deploy.NewRunner(config.Backend, config.SecretsProvider, s3.NewFromConfig(awsConfig))
+	reconciler := reconciler.New(store, runner, config.Project, config.Workers, config.QueueSize, reconciler.Retention{
+		Count:    config.RetentionCount,
+		Age:      config.RetentionAge,
+		Interval: config.PruneInterval,
+		History:  config.HistoryTTL,
+	}, metrics)
+	// the reconciler outlives the server, so that requests drained on shutdown can still enqueue jobs.
+	reconcilerCtx, stopReconciler := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopReconciler()
+	reconcilerDone := make(chan struct{})
+	go func() {
+		defer close(reconcilerDone)
+		reconciler.Run(reconcilerCtx)
+	}()
 
-	startServer()
+	// groups of users are looked up by their membership items, which older releases did not write.
+	if err := store.IndexMemberships(ctx); err != nil {
+		slog.WarnContext(ctx, "failed to index group memberships", "error", err)
+	}
+	policies := append(config.Policies, idp.UpstreamPolicies(config.IDPUpstreams)...)
+	authorizer := auth.NewAuthorizer(policies, store.Directory, config.DirectoryRefresh, config.TrustedIssuer())
+	var provider *idp.Provider
+	if config.IDPIssuer != "" {
+		var keys idp.KeyStore
+		if len(config.IDPKMSKeys) > 0 {
+			keys, err = idp.NewKMSKeyStore(ctx, kms.NewFromConfig(awsConfig), config.IDPKMSKeys)
+		} else {
+			// replaced keys are published until all tokens and sessions they signed expired.
+			keys, err = idp.NewLocalKeyStore(config.IDPKeyDir, config.IDPKeyRotation, max(config.IDPTokenLifetime, config.IDPSessionLifetime))
+		}
+		if err != nil {
+			return fmt.Errorf("cannot initialize idp keys: %v", err)
+		}
+		provider, err = idp.New(config.IDPIssuer, config.IDPTokenLifetime, config.IDPClients, keys, store, authorizer, verifiers)
+		if err != nil {
+			return fmt.Errorf("cannot initialize idp: %v", err)
+		}
+		if err := provider.EnableLogin(ctx, config.IDPUpstreams, store, config.IDPSessionLifetime); err != nil {
+			return fmt.Errorf("cannot initialize idp login: %v", err)
+		}
+		verifiers = append(verifiers, provider)
+	}
+	if config.APIKeyAuth {
+		// added after the provider, so that scoped keys cannot be exchanged for unscoped idp tokens.
+		verifiers = append(verifiers, auth.NewAPIKeyVerifier(store.LookupAPIKey, store.LookupServiceAccount))
+	}
 
-	return nil
+	checks := []health.Check{
+		{Name: "store", Probe: store.Ping},
+		{Name: "aws_credentials", Probe: func(ctx context.Context) error {
+			if awsConfig.Credentials == nil {
+				return fmt.Errorf("no aws credentials configured")
+			}
+			_, err := awsConfig.Credentials.Retrieve(ctx)
+			return err
+		}},
+		{Name: "pulumi", Probe: func(ctx context.Context) error {
+			_, err := runner.CLIVersion()
+			return err
+		}},
+	}
+
+	serverErr := startServer(ctx, config, store, reconciler, authorizer, provider, verifiers, checks, metrics)
+	stopReconciler()
+	select {
+	case <-reconcilerDone:
+	case <-time.After(config.ShutdownTimeout):
+		return fmt.Errorf("reconciler did not stop within %s", config.ShutdownTimeout)
+	}
+	if serverErr == nil {
+		slog.Info("operator stopped")
+	}
+	return serverErr
 }
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"connectrpc.com/otelconnect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/health"
+	"github.com/megakuul/miam/internal/idp"
+	"github.com/megakuul/miam/internal/interceptor"
+	"github.com/megakuul/miam/internal/metrics"
+	"github.com/megakuul/miam/internal/reconciler"
+	"github.com/megakuul/miam/internal/scim"
+	"github.com/megakuul/miam/internal/service"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/internal/webui"
+	"github.com/megakuul/miam/pkg/api/iam/v1/authorize/authorizeconnect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/group/groupconnect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/role/roleconnect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/serviceaccount/serviceaccountconnect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/user/userconnect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit/auditconnect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator/operatorconnect"
+	operatorui "github.com/megakuul/miam/web/operator"
+)
+
+const (
+	// readHeaderTimeout bounds the time clients may take to send the request headers.
+	readHeaderTimeout = 10 * time.Second
+	// maxHeaderBytes limits the size of the request headers (including bearer tokens).
+	maxHeaderBytes = 64 << 10
+)
+
+// startServer serves the operator api until the context is cancelled, then drains in-flight requests.
+// Requests are authenticated with the verifiers and authorized with the authorizer,
+// if there are no verifiers (only if started with --insecure-no-auth) the api is served without authentication and authorization.
+// The unauthenticated health and metrics endpoints are served next to the api, the scim endpoints only with verifiers,
+// the oidc provider endpoints only if a provider is specified and the web ui only if it was embedded.
+// All services are served with the connect, grpc and grpc-web protocols.
+func startServer(ctx context.Context, config *Config, store *store.Store, reconciler *reconciler.Reconciler,
+	authorizer *auth.Authorizer, provider *idp.Provider, verifiers []auth.Verifier, checks []health.Check, metrics *metrics.Metrics) error {
+	// request metrics are collected by the metrics interceptor, otelconnect only traces.
+	tracer, err := otelconnect.NewInterceptor(otelconnect.WithoutMetrics())
+	if err != nil {
+		return fmt.Errorf("cannot initialize tracing interceptor: %v", err)
+	}
+	chain := []connect.Interceptor{tracer, interceptor.NewRequestIDInterceptor(), interceptor.NewMetricsInterceptor(metrics)}
+	if len(verifiers) > 0 {
+		chain = append(chain, interceptor.NewAuthInterceptor(authorizer, verifiers...))
+	}
+	deployLimit := interceptor.RateLimit{Rate: config.DeployRateLimit, Burst: config.DeployRateLimitBurst}
+	limiter := interceptor.NewRateLimiter(
+		interceptor.RateLimit{Rate: config.RateLimit, Burst: config.RateLimitBurst},
+		map[string]interceptor.RateLimit{
+			clusterconnect.ClusterServiceUpdateProcedure:       deployLimit,
+			clusterconnect.ClusterServiceDestroyProcedure:      deployLimit,
+			operatorconnect.MaintenanceServiceUpdateProcedure:  deployLimit,
+			operatorconnect.MaintenanceServiceDestroyProcedure: deployLimit,
+		},
+		config.TrustedProxyHeader,
+	)
+	chain = append(chain, interceptor.NewRateLimitInterceptor(limiter))
+	chain = append(chain, interceptor.NewAuditInterceptor(store.PutAudit))
+	if len(verifiers) > 0 {
+		chain = append(chain, interceptor.NewAuthzInterceptor(authorizer, store.ClusterTags))
+	}
+	// requests are validated after authorization, so unauthorized callers cannot probe the request rules.
+	chain = append(chain, interceptor.NewValidateInterceptor())
+	chain = append(chain, interceptor.NewIdempotencyInterceptor(store, config.IdempotencyTTL))
+	options := []connect.HandlerOption{
+		connect.WithInterceptors(chain...),
+		connect.WithReadMaxBytes(config.MaxRequestSize),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(clusterconnect.NewClusterServiceHandler(
+		service.NewClusterService(store, reconciler), options...,
+	))
+	mux.Handle(operatorconnect.NewMaintenanceServiceHandler(
+		service.NewMaintenanceService(store, reconciler, config.Source), options...,
+	))
+	mux.Handle(auditconnect.NewAuditServiceHandler(
+		service.NewAuditService(store), options...,
+	))
+	mux.Handle(userconnect.NewUserServiceHandler(
+		service.NewUserService(store), options...,
+	))
+	mux.Handle(groupconnect.NewGroupServiceHandler(
+		service.NewGroupService(store), options...,
+	))
+	mux.Handle(roleconnect.NewRoleServiceHandler(
+		service.NewRoleService(store), options...,
+	))
+	mux.Handle(authorizeconnect.NewAuthorizeServiceHandler(
+		service.NewAuthorizeService(authorizer, store), options...,
+	))
+	mux.Handle(serviceaccountconnect.NewServiceAccountServiceHandler(
+		service.NewServiceAccountService(store), options...,
+	))
+	if len(verifiers) > 0 {
+		scimServer, err := scim.New(store, authorizer, verifiers, limiter, store.PutAudit)
+		if err != nil {
+			return fmt.Errorf("cannot initialize scim endpoints: %v", err)
+		}
+		scimServer.Register(mux)
+	} else {
+		slog.WarnContext(ctx, "scim endpoints are disabled, they are not served without authentication")
+	}
+	services := []string{
+		clusterconnect.ClusterServiceName,
+		operatorconnect.MaintenanceServiceName,
+		auditconnect.AuditServiceName,
+		userconnect.UserServiceName,
+		groupconnect.GroupServiceName,
+		roleconnect.RoleServiceName,
+		authorizeconnect.AuthorizeServiceName,
+		serviceaccountconnect.ServiceAccountServiceName,
+	}
+	if !config.DisableReflection {
+		// the reflection service only describes the api, calls are still authenticated.
+		reflector := grpcreflect.NewStaticReflector(services...)
+		mux.Handle(grpcreflect.NewHandlerV1(reflector))
+		mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+	}
+	healthServer := health.New(checks, services, func(ctx context.Context) (string, error) {
+		return activeOperatorRevision(ctx, store)
+	})
+	healthServer.Register(mux)
+	metrics.Register(mux)
+	if provider != nil {
+		provider.Register(mux, limiter)
+	}
+	if files := operatorui.Files(); files != nil {
+		ui, err := webui.New(files, config.UIConfig())
+		if err != nil {
+			return fmt.Errorf("cannot initialize web ui: %v", err)
+		}
+		ui.Register(mux)
+	}
+
+	protocols := &http.Protocols{}
+	protocols.SetHTTP1(true)
+	if config.TLSCertFile != "" {
+		protocols.SetHTTP2(true)
+	} else {
+		protocols.SetUnencryptedHTTP2(true)
+	}
+	server := &http.Server{
+		Addr:      config.Addr,
+		Handler:   withCORS(mux, config.CORSOrigins),
+		Protocols: protocols,
+		ErrorLog:  slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       config.ReadTimeout,
+		MaxHeaderBytes:    maxHeaderBytes,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		if config.TLSCertFile != "" {
+			serveErr <- server.ListenAndServeTLS(config.TLSCertFile, config.TLSKeyFile)
+		} else {
+			serveErr <- server.ListenAndServe()
+		}
+	}()
+	slog.Info("serving operator api", "addr", config.Addr, "tls", config.TLSCertFile != "")
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server failed: %v", err)
+	case <-ctx.Done():
+	}
+	slog.Info("draining operator api", "timeout", config.ShutdownTimeout)
+	healthServer.Drain()
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		server.Close()
+		return fmt.Errorf("failed to drain server: %v", err)
+	}
+	return nil
+}
+
+// activeOperatorRevision returns the newest active revision of the operator, or an empty string if none is active.
+func activeOperatorRevision(ctx context.Context, store *store.Store) (string, error) {
+	revisions, err := store.GetOperator(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, revision := range revisions {
+		if revision.GetState() == operator.State_ACTIVE {
+			return revision.GetRevision(), nil
+		}
+	}
+	return "", nil
+}
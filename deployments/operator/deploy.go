@@ -2,36 +2,122 @@ package operator
 
 import (
 	"github.com/pulumi/pulumi-aws/sdk/v7/go/aws/apigatewayv2"
-	"github.com/pulumi/pulumi-aws/sdk/v7/go/aws/s3"
+	"github.com/pulumi/pulumi-aws/sdk/v7/go/aws/dynamodb"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
 func Deploy(ctx *pulumi.Context) error {
 	api, err := apigatewayv2.NewApi(ctx, "gateway", &apigatewayv2.ApiArgs{
-		Name: pulumi.StringPtr(ctx.Project()),
+		Name:         pulumi.StringPtr(ctx.Project()),
 		ProtocolType: pulumi.String("HTTP"),
 	})
-	if err!=nil {
+	if err != nil {
 		return err
 	}
 	integration, err := apigatewayv2.NewIntegration(ctx, "lambda", &apigatewayv2.IntegrationArgs{
-		ApiId: api.ID(),
-		ConnectionType: pulumi.String("INTERNET"),
-		IntegrationType: pulumi.String("AWS_PROXY"),
-		IntegrationMethod: pulumi.String("AWS_PROXY"), 
-		IntegrationUri: pulumi.String("arn:TODO"),
+		ApiId:                api.ID(),
+		ConnectionType:       pulumi.String("INTERNET"),
+		IntegrationType:      pulumi.String("AWS_PROXY"),
+		IntegrationMethod:    pulumi.String("AWS_PROXY"),
+		IntegrationUri:       pulumi.String("arn:TODO"),
 		PayloadFormatVersion: pulumi.String("2.0"),
 	})
-	if err!=nil {
+	if err != nil {
 		return err
 	}
 	_, err = apigatewayv2.NewRoute(ctx, "route", &apigatewayv2.RouteArgs{
-		ApiId: api.ID(),
+		ApiId:         api.ID(),
 		OperationName: pulumi.String("deploy"),
-		RouteKey: pulumi.String("/deploy"),
-		Target: pulumi.Sprintf("integrations/%s", integration.ID()),
+		RouteKey:      pulumi.String("/deploy"),
+		Target:        pulumi.Sprintf("integrations/%s", integration.ID()),
 	})
-	if err!=nil {
+	if err != nil {
+		return err
+	}
+	// the operator serves the web ui and its api on all other routes.
+	_, err = apigatewayv2.NewRoute(ctx, "default", &apigatewayv2.RouteArgs{
+		ApiId:    api.ID(),
+		RouteKey: pulumi.String("$default"),
+		Target:   pulumi.Sprintf("integrations/%s", integration.ID()),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = dynamodb.NewTable(ctx, "cluster", &dynamodb.TableArgs{
+		Name:        pulumi.Sprintf("%s-cluster", ctx.Project()),
+		BillingMode: pulumi.String("PAY_PER_REQUEST"),
+		HashKey:     pulumi.String("name"),
+		RangeKey:    pulumi.String("revision"),
+		Attributes: dynamodb.TableAttributeArray{
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("name"), Type: pulumi.String("S")},
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("revision"), Type: pulumi.String("S")},
+		},
+		GlobalSecondaryIndexes: dynamodb.TableGlobalSecondaryIndexArray{
+			&dynamodb.TableGlobalSecondaryIndexArgs{
+				Name:           pulumi.String("revision_index"),
+				HashKey:        pulumi.String("revision"),
+				RangeKey:       pulumi.String("name"),
+				ProjectionType: pulumi.String("ALL"),
+			},
+		},
+		Ttl: &dynamodb.TableTtlArgs{
+			AttributeName: pulumi.String("expires_at"),
+			Enabled:       pulumi.Bool(true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = dynamodb.NewTable(ctx, "operator", &dynamodb.TableArgs{
+		Name:        pulumi.Sprintf("%s-operator", ctx.Project()),
+		BillingMode: pulumi.String("PAY_PER_REQUEST"),
+		HashKey:     pulumi.String("project"),
+		RangeKey:    pulumi.String("revision"),
+		Attributes: dynamodb.TableAttributeArray{
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("project"), Type: pulumi.String("S")},
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("revision"), Type: pulumi.String("S")},
+		},
+		Ttl: &dynamodb.TableTtlArgs{
+			AttributeName: pulumi.String("expires_at"),
+			Enabled:       pulumi.Bool(true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = dynamodb.NewTable(ctx, "audit", &dynamodb.TableArgs{
+		Name:        pulumi.Sprintf("%s-audit", ctx.Project()),
+		BillingMode: pulumi.String("PAY_PER_REQUEST"),
+		HashKey:     pulumi.String("project"),
+		RangeKey:    pulumi.String("id"),
+		Attributes: dynamodb.TableAttributeArray{
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("project"), Type: pulumi.String("S")},
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("id"), Type: pulumi.String("S")},
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("actor"), Type: pulumi.String("S")},
+		},
+		GlobalSecondaryIndexes: dynamodb.TableGlobalSecondaryIndexArray{
+			&dynamodb.TableGlobalSecondaryIndexArgs{
+				Name:           pulumi.String("actor_index"),
+				HashKey:        pulumi.String("actor"),
+				RangeKey:       pulumi.String("id"),
+				ProjectionType: pulumi.String("ALL"),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = dynamodb.NewTable(ctx, "iam", &dynamodb.TableArgs{
+		Name:        pulumi.Sprintf("%s-iam", ctx.Project()),
+		BillingMode: pulumi.String("PAY_PER_REQUEST"),
+		HashKey:     pulumi.String("kind"),
+		RangeKey:    pulumi.String("name"),
+		Attributes: dynamodb.TableAttributeArray{
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("kind"), Type: pulumi.String("S")},
+			&dynamodb.TableAttributeArgs{Name: pulumi.String("name"), Type: pulumi.String("S")},
+		},
+	})
+	if err != nil {
 		return err
 	}
 	return nil
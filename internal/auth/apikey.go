@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/megakuul/miam/pkg/policy"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// APIKeyPrefix identifies miam api keys ("miam_<id>_<secret>"), e.g. for secret scanners.
+	APIKeyPrefix = "miam_"
+	// APIKeyIssuer is the issuer of identities authenticated with api keys.
+	APIKeyIssuer = "miam-apikey"
+	// ServiceAccountPrefix prefixes the subject of service account identities.
+	ServiceAccountPrefix = "serviceaccount:"
+)
+
+// APIKey is the stored part of an api key.
+type APIKey struct {
+	ServiceAccount    string
+	ServiceAccountUID string   // uid of the service account instance the key was issued to
+	SecretHash        string   // see HashAPIKeySecret
+	Procedures        []string // action patterns the key is restricted to, empty allows all
+	Clusters          []string // cluster name patterns the key is restricted to, empty allows all
+	ExpiresAt         time.Time
+}
+
+// ServiceAccount is the state of a service account relevant to the verification of its api keys.
+type ServiceAccount struct {
+	UID      string // distinguishes the account from deleted accounts with the same name
+	Disabled bool
+}
+
+// APIKeySource loads the api key with the id, returns ErrUnknownAPIKey if it does not exist.
+type APIKeySource func(ctx context.Context, id string) (*APIKey, error)
+
+// ServiceAccountSource loads the service account with the name, returns ErrUnknownServiceAccount if it does not exist.
+type ServiceAccountSource func(ctx context.Context, name string) (*ServiceAccount, error)
+
+var (
+	// ErrUnknownAPIKey is returned by api key sources for keys that do not exist (e.g. revoked keys).
+	ErrUnknownAPIKey = errors.New("unknown api key")
+	// ErrUnknownServiceAccount is returned by service account sources for accounts that do not exist.
+	ErrUnknownServiceAccount = errors.New("unknown service account")
+)
+
+// NewAPIKey generates the id and secret of a new api key and returns the full key presented by clients.
+func NewAPIKey() (id, secret, key string) {
+	id, secret = strings.ToLower(ulid.Make().String()), strings.ToLower(rand.Text())
+	return id, secret, APIKeyPrefix + id + "_" + secret
+}
+
+// HashAPIKeySecret returns the hash of the secret that is stored instead of the secret.
+// Secrets are random, so an unsalted hash is sufficient.
+func HashAPIKeySecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// APIKeyVerifier resolves the service account of api keys.
+type APIKeyVerifier struct {
+	keys     APIKeySource
+	accounts ServiceAccountSource
+}
+
+// NewAPIKeyVerifier creates a verifier accepting the keys provided by keys whose service account exists in accounts.
+func NewAPIKeyVerifier(keys APIKeySource, accounts ServiceAccountSource) *APIKeyVerifier {
+	return &APIKeyVerifier{keys: keys, accounts: accounts}
+}
+
+// Verify checks the secret and expiry of the key and that its service account still exists and is enabled.
+// The identity is restricted to the scope of the key.
+func (v *APIKeyVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	encoded, ok := strings.CutPrefix(token, APIKeyPrefix)
+	if !ok {
+		return nil, ErrUnsupportedToken
+	}
+	id, secret, ok := strings.Cut(encoded, "_")
+	if !ok {
+		return nil, fmt.Errorf("malformed api key")
+	}
+	key, err := v.keys(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrUnknownAPIKey) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to load api key: %v", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(HashAPIKeySecret(secret)), []byte(key.SecretHash)) != 1 {
+		return nil, fmt.Errorf("invalid api key")
+	}
+	if !key.ExpiresAt.IsZero() && time.Now().After(key.ExpiresAt) {
+		return nil, fmt.Errorf("api key expired")
+	}
+	account, err := v.accounts(ctx, key.ServiceAccount)
+	if err != nil {
+		if errors.Is(err, ErrUnknownServiceAccount) {
+			return nil, fmt.Errorf("service account of the api key was deleted")
+		}
+		return nil, fmt.Errorf("failed to load service account: %v", err)
+	}
+	// keys of a deleted account must not be revived by a new account with the same name.
+	if account.UID != key.ServiceAccountUID {
+		return nil, fmt.Errorf("service account of the api key was deleted")
+	}
+	if account.Disabled {
+		return nil, ErrDisabled
+	}
+	identity := &Identity{
+		Issuer:  APIKeyIssuer,
+		Subject: ServiceAccountPrefix + key.ServiceAccount,
+	}
+	if len(key.Procedures) > 0 || len(key.Clusters) > 0 {
+		identity.Scope = apiKeyScope(key)
+	}
+	return identity, nil
+}
+
+// apiKeyScope converts the restrictions of the key into a policy.
+func apiKeyScope(key *APIKey) *policy.Document {
+	statement := policy.Statement{
+		Effect:    policy.Allow,
+		Actions:   key.Procedures,
+		Resources: []string{"*"},
+	}
+	if len(statement.Actions) < 1 {
+		statement.Actions = []string{"*"}
+	}
+	if len(key.Clusters) > 0 {
+		statement.Resources = []string{}
+		for _, pattern := range key.Clusters {
+			statement.Resources = append(statement.Resources, "cluster:"+pattern)
+		}
+	}
+	return &policy.Document{Statements: []policy.Statement{statement}}
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyVerifierVerify(t *testing.T) {
+	id, secret, token := NewAPIKey()
+	now := time.Now()
+	valid := APIKey{
+		ServiceAccount:    "ci",
+		ServiceAccountUID: "uid-1",
+		SecretHash:        HashAPIKeySecret(secret),
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		key       func(APIKey) APIKey
+		account   *ServiceAccount // nil if the account does not exist
+		wantErr   error           // checked with errors.Is if set
+		wantFail  bool
+		wantSkip  bool
+		wantScope bool
+	}{
+		{
+			name:    "valid key",
+			account: &ServiceAccount{UID: "uid-1"},
+		},
+		{
+			name:      "scoped key",
+			key:       func(k APIKey) APIKey { k.Clusters = []string{"prod-*"}; return k },
+			account:   &ServiceAccount{UID: "uid-1"},
+			wantScope: true,
+		},
+		{
+			name:     "other token is not handled",
+			token:    "eyJhbGciOiJSUzI1NiJ9.e30.sig",
+			account:  &ServiceAccount{UID: "uid-1"},
+			wantSkip: true,
+		},
+		{
+			name:     "malformed key",
+			token:    APIKeyPrefix + id,
+			account:  &ServiceAccount{UID: "uid-1"},
+			wantFail: true,
+		},
+		{
+			name:     "wrong secret",
+			token:    APIKeyPrefix + id + "_wrong",
+			account:  &ServiceAccount{UID: "uid-1"},
+			wantFail: true,
+		},
+		{
+			name:     "expired key",
+			key:      func(k APIKey) APIKey { k.ExpiresAt = now.Add(-time.Minute); return k },
+			account:  &ServiceAccount{UID: "uid-1"},
+			wantFail: true,
+		},
+		{
+			name:     "deleted service account",
+			wantFail: true,
+		},
+		{
+			name:     "recreated service account",
+			account:  &ServiceAccount{UID: "uid-2"},
+			wantFail: true,
+		},
+		{
+			name:    "disabled service account",
+			account: &ServiceAccount{UID: "uid-1", Disabled: true},
+			wantErr: ErrDisabled,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key := valid
+			if test.key != nil {
+				key = test.key(key)
+			}
+			verifier := NewAPIKeyVerifier(
+				func(ctx context.Context, keyID string) (*APIKey, error) {
+					if keyID != id {
+						return nil, ErrUnknownAPIKey
+					}
+					return &key, nil
+				},
+				func(ctx context.Context, name string) (*ServiceAccount, error) {
+					if test.account == nil || name != key.ServiceAccount {
+						return nil, ErrUnknownServiceAccount
+					}
+					return test.account, nil
+				},
+			)
+			presented := token
+			if test.token != "" {
+				presented = test.token
+			}
+			identity, err := verifier.Verify(context.Background(), presented)
+			switch {
+			case test.wantSkip:
+				if !errors.Is(err, ErrUnsupportedToken) {
+					t.Fatalf("err = %v, want %v", err, ErrUnsupportedToken)
+				}
+			case test.wantErr != nil:
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("err = %v, want %v", err, test.wantErr)
+				}
+			case test.wantFail:
+				if err == nil {
+					t.Fatal("key was accepted")
+				}
+			case err != nil:
+				t.Fatalf("key was rejected: %v", err)
+			case identity.Subject != ServiceAccountPrefix+"ci" || identity.Issuer != APIKeyIssuer:
+				t.Fatalf("identity = %s/%s, want %s/%s", identity.Issuer, identity.Subject, APIKeyIssuer, ServiceAccountPrefix+"ci")
+			case (identity.Scope != nil) != test.wantScope:
+				t.Fatalf("scope = %v, want scoped %v", identity.Scope, test.wantScope)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/megakuul/miam/pkg/awsauth"
+)
+
+// IAMIssuer is the issuer of identities resolved from aws iam principals.
+const IAMIssuer = "aws-iam"
+
+const (
+	// iamTokenLifetime is the maximum age of the signature of a presigned sts url.
+	// Clients sign a fresh url for every request, so the lifetime only covers the transmission and clock skew.
+	iamTokenLifetime = 5 * time.Minute
+	// iamClockSkew is the maximum time the signature of a presigned sts url may be dated in the future.
+	iamClockSkew = 30 * time.Second
+	// iamCacheTTL bounds the time a verified token is accepted without calling sts again.
+	iamCacheTTL = time.Minute
+)
+
+// stsHostPattern matches the global and regional sts endpoints. Tokens pointing to other hosts are rejected
+// to prevent the operator from being used to forward requests to arbitrary urls.
+var stsHostPattern = regexp.MustCompile(`^sts(\.[a-z0-9-]+)?\.amazonaws\.com(\.cn)?$`)
+
+type iamCacheEntry struct {
+	identity *Identity
+	expires  time.Time
+}
+
+// IAMVerifier resolves the iam principal of tokens created by awsauth.Token.
+type IAMVerifier struct {
+	client  *http.Client
+	project string
+
+	lock      sync.Mutex
+	cache     map[[sha256.Size]byte]iamCacheEntry
+	lastSweep time.Time
+}
+
+// NewIAMVerifier creates a verifier accepting tokens that were issued for the project.
+func NewIAMVerifier(project string) *IAMVerifier {
+	return &IAMVerifier{
+		client:    &http.Client{Timeout: 10 * time.Second},
+		project:   project,
+		cache:     map[[sha256.Size]byte]iamCacheEntry{},
+		lastSweep: time.Now(),
+	}
+}
+
+// Verify calls the presigned sts url of the token and returns the identity of the signing principal.
+// The subject is the principal arn; assumed roles additionally carry their role arn as group.
+// Verified tokens are cached by their hash for a short time, so that retries and bursts do not call sts each time.
+func (v *IAMVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	encoded, ok := strings.CutPrefix(token, awsauth.TokenPrefix)
+	if !ok {
+		return nil, ErrUnsupportedToken
+	}
+	hash := sha256.Sum256([]byte(token))
+	if identity, ok := v.cached(hash); ok {
+		return identity, nil
+	}
+	rawURL, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token: %v", err)
+	}
+	stsURL, signed, err := v.checkURL(string(rawURL))
+	if err != nil {
+		return nil, err
+	}
+	identity, err := v.callerIdentity(ctx, stsURL)
+	if err != nil {
+		return nil, err
+	}
+	// the token is not cached beyond its own lifetime.
+	expires := time.Now().Add(iamCacheTTL)
+	if tokenExpiry := signed.Add(iamTokenLifetime); tokenExpiry.Before(expires) {
+		expires = tokenExpiry
+	}
+	v.store(hash, identity, expires)
+	return identity, nil
+}
+
+// callerIdentity calls the presigned sts url and returns the identity of the signing principal.
+func (v *IAMVerifier) callerIdentity(ctx context.Context, stsURL *url.URL) (*Identity, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, stsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(awsauth.ProjectHeader, v.project)
+	req.Header.Set("Accept", "application/json")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call sts: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sts rejected token: %s", resp.Status)
+	}
+	body := struct {
+		GetCallerIdentityResponse struct {
+			GetCallerIdentityResult struct {
+				Arn string
+			}
+		}
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode sts response: %v", err)
+	}
+	arn := body.GetCallerIdentityResponse.GetCallerIdentityResult.Arn
+	if arn == "" {
+		return nil, fmt.Errorf("sts returned no principal")
+	}
+	identity := &Identity{Issuer: IAMIssuer, Subject: arn, Groups: []string{}}
+	if role := roleARN(arn); role != "" {
+		identity.Groups = append(identity.Groups, role)
+	}
+	return identity, nil
+}
+
+// cached returns the identity of a verified token unless its cache entry expired.
+func (v *IAMVerifier) cached(hash [sha256.Size]byte) (*Identity, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	entry, ok := v.cache[hash]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	// callers must not modify the cached identity.
+	identity := *entry.identity
+	identity.Groups = slices.Clone(entry.identity.Groups)
+	return &identity, true
+}
+
+// store caches the identity of a verified token until the expiry, expired entries are swept on the way.
+func (v *IAMVerifier) store(hash [sha256.Size]byte, identity *Identity, expires time.Time) {
+	now := time.Now()
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if now.Sub(v.lastSweep) > iamCacheTTL {
+		for key, entry := range v.cache {
+			if now.After(entry.expires) {
+				delete(v.cache, key)
+			}
+		}
+		v.lastSweep = now
+	}
+	cached := *identity
+	cached.Groups = slices.Clone(identity.Groups)
+	v.cache[hash] = iamCacheEntry{identity: &cached, expires: expires}
+}
+
+// checkURL ensures that the url is a recent GetCallerIdentity call to sts signed for this project.
+// Returns the parsed url and the time it was signed.
+func (v *IAMVerifier) checkURL(rawURL string) (*url.URL, time.Time, error) {
+	stsURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse token url: %v", err)
+	}
+	if stsURL.Scheme != "https" || !stsHostPattern.MatchString(stsURL.Hostname()) || stsURL.Port() != "" {
+		return nil, time.Time{}, fmt.Errorf("token url does not point to sts")
+	}
+	query := stsURL.Query()
+	if query.Get("Action") != "GetCallerIdentity" {
+		return nil, time.Time{}, fmt.Errorf("token url is not a GetCallerIdentity call")
+	}
+	signedHeaders := strings.Split(query.Get("X-Amz-SignedHeaders"), ";")
+	if !slices.Contains(signedHeaders, strings.ToLower(awsauth.ProjectHeader)) {
+		return nil, time.Time{}, fmt.Errorf("token is not bound to a project")
+	}
+	signed, err := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("token has no valid signature date")
+	}
+	if age := time.Since(signed); age > iamTokenLifetime {
+		return nil, time.Time{}, fmt.Errorf("token is expired")
+	} else if age < -iamClockSkew {
+		return nil, time.Time{}, fmt.Errorf("token is signed in the future")
+	}
+	return stsURL, signed, nil
+}
+
+// roleARN returns the iam role arn of an assumed role session arn
+// (arn:aws:sts::<account>:assumed-role/<role>/<session>), or an empty string for other principals.
+// The role path is not part of the session arn and therefore omitted.
+func roleARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[2] != "sts" {
+		return ""
+	}
+	resource := strings.Split(parts[5], "/")
+	if len(resource) != 3 || resource[0] != "assumed-role" {
+		return ""
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parts[1], parts[4], resource[1])
+}
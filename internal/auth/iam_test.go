@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/megakuul/miam/pkg/awsauth"
+)
+
+// stsURL builds a presigned sts url with the query parameters relevant to checkURL.
+func stsURL(host, action, signedHeaders string, signed time.Time) string {
+	query := url.Values{}
+	query.Set("Action", action)
+	query.Set("Version", "2011-06-15")
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	query.Set("X-Amz-Date", signed.UTC().Format("20060102T150405Z"))
+	query.Set("X-Amz-Signature", "signature")
+	return "https://" + host + "/?" + query.Encode()
+}
+
+func TestIAMVerifierCheckURL(t *testing.T) {
+	verifier := NewIAMVerifier("miam")
+	now := time.Now()
+	headers := "host;x-miam-project"
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "global endpoint", url: stsURL("sts.amazonaws.com", "GetCallerIdentity", headers, now)},
+		{name: "regional endpoint", url: stsURL("sts.eu-central-1.amazonaws.com", "GetCallerIdentity", headers, now)},
+		{name: "china endpoint", url: stsURL("sts.cn-north-1.amazonaws.com.cn", "GetCallerIdentity", headers, now)},
+		{name: "other host", url: stsURL("sts.amazonaws.com.example.com", "GetCallerIdentity", headers, now), wantErr: true},
+		{name: "other service", url: stsURL("s3.amazonaws.com", "GetCallerIdentity", headers, now), wantErr: true},
+		{name: "explicit port", url: stsURL("sts.amazonaws.com:8443", "GetCallerIdentity", headers, now), wantErr: true},
+		{name: "plain http", url: strings.Replace(stsURL("sts.amazonaws.com", "GetCallerIdentity", headers, now), "https", "http", 1), wantErr: true},
+		{name: "other action", url: stsURL("sts.amazonaws.com", "AssumeRole", headers, now), wantErr: true},
+		{name: "project header not signed", url: stsURL("sts.amazonaws.com", "GetCallerIdentity", "host", now), wantErr: true},
+		{name: "recent signature", url: stsURL("sts.amazonaws.com", "GetCallerIdentity", headers, now.Add(-4*time.Minute))},
+		{name: "expired signature", url: stsURL("sts.amazonaws.com", "GetCallerIdentity", headers, now.Add(-6*time.Minute)), wantErr: true},
+		{name: "signature within clock skew", url: stsURL("sts.amazonaws.com", "GetCallerIdentity", headers, now.Add(20*time.Second))},
+		{name: "signature in the future", url: stsURL("sts.amazonaws.com", "GetCallerIdentity", headers, now.Add(2*time.Minute)), wantErr: true},
+		{name: "missing signature date", url: "https://sts.amazonaws.com/?Action=GetCallerIdentity&X-Amz-SignedHeaders=host%3Bx-miam-project", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := verifier.checkURL(test.url)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("err = %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoleARN(t *testing.T) {
+	tests := []struct {
+		arn  string
+		want string
+	}{
+		{arn: "arn:aws:sts::123456789012:assumed-role/deployer/session", want: "arn:aws:iam::123456789012:role/deployer"},
+		{arn: "arn:aws-cn:sts::123456789012:assumed-role/deployer/session", want: "arn:aws-cn:iam::123456789012:role/deployer"},
+		{arn: "arn:aws:iam::123456789012:user/alice", want: ""},
+		{arn: "arn:aws:sts::123456789012:federated-user/alice", want: ""},
+		{arn: "arn:aws:sts::123456789012:assumed-role/deployer", want: ""},
+		{arn: "not an arn", want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.arn, func(t *testing.T) {
+			if got := roleARN(test.arn); got != test.want {
+				t.Fatalf("roleARN = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// roundTripperFunc answers the requests of a http client without network.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestIAMVerifierCachesTokens(t *testing.T) {
+	calls := atomic.Int32{}
+	verifier := NewIAMVerifier("miam")
+	verifier.client = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		if req.Header.Get(awsauth.ProjectHeader) != "miam" {
+			t.Errorf("project header = %q, want miam", req.Header.Get(awsauth.ProjectHeader))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body: io.NopCloser(strings.NewReader(`{"GetCallerIdentityResponse":{"GetCallerIdentityResult":` +
+				`{"Arn":"arn:aws:sts::123456789012:assumed-role/deployer/session"}}}`)),
+		}, nil
+	})}
+	token := func(signed time.Time) string {
+		return awsauth.TokenPrefix + base64.RawURLEncoding.EncodeToString(
+			[]byte(stsURL("sts.amazonaws.com", "GetCallerIdentity", "host;x-miam-project", signed)))
+	}
+	first, second := token(time.Now()), token(time.Now().Add(-time.Second))
+
+	for _, presented := range []string{first, first, second} {
+		identity, err := verifier.Verify(context.Background(), presented)
+		if err != nil {
+			t.Fatalf("token was rejected: %v", err)
+		}
+		if identity.Subject != "arn:aws:sts::123456789012:assumed-role/deployer/session" || len(identity.Groups) != 1 {
+			t.Fatalf("identity = %+v", identity)
+		}
+		// modifications of the returned identity must not leak into the cache.
+		identity.Groups[0] = "modified"
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("sts was called %d times, want 2", got)
+	}
+	identity, err := verifier.Verify(context.Background(), first)
+	if err != nil || identity.Groups[0] != "arn:aws:iam::123456789012:role/deployer" {
+		t.Fatalf("cached identity = %+v (%v)", identity, err)
+	}
+}
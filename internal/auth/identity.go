@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/megakuul/miam/pkg/policy"
+)
+
+// ErrUnsupportedToken is returned by verifiers that do not handle the type of the presented token.
+var ErrUnsupportedToken = errors.New("unsupported token type")
+
+// Verifier resolves the identity of the caller from a bearer token.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Identity, error)
+}
+
+// Authenticate resolves the identity of the token with the first verifier supporting it.
+// Returns ErrUnsupportedToken if no verifier supports the token.
+func Authenticate(ctx context.Context, verifiers []Verifier, token string) (*Identity, error) {
+	for _, verifier := range verifiers {
+		identity, err := verifier.Verify(ctx, token)
+		if errors.Is(err, ErrUnsupportedToken) {
+			continue
+		}
+		return identity, err
+	}
+	return nil, ErrUnsupportedToken
+}
+
+// Identity describes the authenticated caller of a request.
+type Identity struct {
+	Issuer  string
+	Subject string
+	Email   string
+	Groups  []string
+	// Scope restricts the identity to the requests it allows (e.g. the scope of an api key), nil if unrestricted.
+	Scope *policy.Document
+}
+
+// Name returns the human readable name of the identity (email if available, subject otherwise).
+func (i *Identity) Name() string {
+	if i.Email != "" {
+		return i.Email
+	}
+	return i.Subject
+}
+
+type (
+	identityKey  struct{}
+	principalKey struct{}
+)
+
+// WithIdentity returns a context carrying the identity of the caller.
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// NameFrom returns the name of the caller stored in the context, or fallback if the request is not authenticated.
+func NameFrom(ctx context.Context, fallback string) string {
+	if identity, ok := IdentityFrom(ctx); ok {
+		return identity.Name()
+	}
+	return fallback
+}
+
+// WithPrincipal returns a context carrying the principal of the caller (see Authorizer.Principal).
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the principal of the caller stored in the context, or fallback if the request is not authenticated.
+// Unlike the name, the principal of identities of untrusted issuers is qualified with their issuer.
+func PrincipalFrom(ctx context.Context, fallback string) string {
+	if principal, ok := ctx.Value(principalKey{}).(string); ok {
+		return principal
+	}
+	return fallback
+}
+
+// IdentityFrom returns the identity of the caller stored in the context.
+func IdentityFrom(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
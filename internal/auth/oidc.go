@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// OIDCVerifier validates bearer jwts issued by an oidc issuer.
+type OIDCVerifier struct {
+	issuer   string
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCVerifier creates a verifier accepting tokens of the issuer that are addressed to the audience.
+// The signing keys are fetched from jwksURL, or from the issuer discovery document if jwksURL is empty.
+// Keys are cached and refetched once a token is signed by an unknown key.
+// The context is used to fetch the keys and must live as long as the verifier.
+func NewOIDCVerifier(ctx context.Context, issuer, audience, jwksURL string) (*OIDCVerifier, error) {
+	config := &oidc.Config{ClientID: audience}
+	if jwksURL != "" {
+		return &OIDCVerifier{
+			issuer:   issuer,
+			verifier: oidc.NewVerifier(issuer, oidc.NewRemoteKeySet(ctx, jwksURL), config),
+		}, nil
+	}
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oidc issuer: %v", err)
+	}
+	return &OIDCVerifier{issuer: issuer, verifier: provider.Verifier(config)}, nil
+}
+
+// Verify checks the signature, issuer, audience and expiry of the token and returns the identity it carries.
+// The email is only used if the email_verified claim is true, the identity is named by the subject otherwise.
+// Tokens naming their subject in a reserved form (see Reserved) are rejected.
+func (v *OIDCVerifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	if TokenIssuer(token) != v.issuer {
+		return nil, ErrUnsupportedToken
+	}
+	idToken, err := v.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	claims := struct {
+		Email         string   `json:"email"`
+		EmailVerified any      `json:"email_verified"`
+		Groups        []string `json:"groups"`
+	}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %v", err)
+	}
+	identity := &Identity{
+		Issuer:  idToken.Issuer,
+		Subject: idToken.Subject,
+		Groups:  claims.Groups,
+	}
+	// an unverified email can be chosen freely by the user of some issuers, it must not name the identity.
+	// some issuers (e.g. cognito) encode the claim as string.
+	if claims.EmailVerified == true || claims.EmailVerified == "true" {
+		identity.Email = claims.Email
+	}
+	if Reserved(identity.Subject) || Reserved(identity.Name()) {
+		return nil, fmt.Errorf("token subject '%s' uses a reserved form", identity.Name())
+	}
+	return identity, nil
+}
+
+// TokenIssuer returns the unverified issuer claim of a jwt or an empty string if the token is no jwt.
+// It must only be used to select the verifier responsible for the token.
+func TokenIssuer(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	claims := struct {
+		Issuer string `json:"iss"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Issuer
+}
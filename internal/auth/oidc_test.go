@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	testIssuer   = "https://issuer.test"
+	testAudience = "miam"
+)
+
+// newTestIssuer serves the public key of the returned signer as jwks.
+func newTestIssuer(t *testing.T) (jose.Signer, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(server.Close)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, server.URL
+}
+
+func TestOIDCVerifierVerify(t *testing.T) {
+	signer, jwksURL := newTestIssuer(t)
+	verifier, err := NewOIDCVerifier(context.Background(), testIssuer, testAudience, jwksURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	valid := jwt.Claims{
+		Issuer:   testIssuer,
+		Subject:  "sub-1",
+		Audience: jwt.Audience{testAudience},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+
+	tests := []struct {
+		name     string
+		claims   func(jwt.Claims) jwt.Claims
+		custom   map[string]any
+		wantName string
+		wantErr  bool
+		wantSkip bool
+	}{
+		{
+			name:     "verified email names the identity",
+			custom:   map[string]any{"email": "alice@example.com", "email_verified": true},
+			wantName: "alice@example.com",
+		},
+		{
+			name:     "verified email encoded as string",
+			custom:   map[string]any{"email": "alice@example.com", "email_verified": "true"},
+			wantName: "alice@example.com",
+		},
+		{
+			name:     "unverified email falls back to the subject",
+			custom:   map[string]any{"email": "alice@example.com", "email_verified": false},
+			wantName: "sub-1",
+		},
+		{
+			name:     "email without verification claim falls back to the subject",
+			custom:   map[string]any{"email": "alice@example.com"},
+			wantName: "sub-1",
+		},
+		{
+			name:    "wrong audience",
+			claims:  func(c jwt.Claims) jwt.Claims { c.Audience = jwt.Audience{"other"}; return c },
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			claims: func(c jwt.Claims) jwt.Claims {
+				c.IssuedAt, c.Expiry = jwt.NewNumericDate(now.Add(-2*time.Hour)), jwt.NewNumericDate(now.Add(-time.Hour))
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name:    "reserved subject form",
+			claims:  func(c jwt.Claims) jwt.Claims { c.Subject = "serviceaccount:ci"; return c },
+			wantErr: true,
+		},
+		{
+			name:    "reserved email form",
+			custom:  map[string]any{"email": "arn:aws:iam::1:role/x", "email_verified": true},
+			wantErr: true,
+		},
+		{
+			name:     "other issuer is not handled",
+			claims:   func(c jwt.Claims) jwt.Claims { c.Issuer = "https://other.test"; return c },
+			wantSkip: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := valid
+			if test.claims != nil {
+				claims = test.claims(claims)
+			}
+			token, err := jwt.Signed(signer).Claims(claims).Claims(test.custom).Serialize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			identity, err := verifier.Verify(context.Background(), token)
+			switch {
+			case test.wantSkip:
+				if !errors.Is(err, ErrUnsupportedToken) {
+					t.Fatalf("err = %v, want %v", err, ErrUnsupportedToken)
+				}
+			case test.wantErr:
+				if err == nil {
+					t.Fatal("token was accepted")
+				}
+			case err != nil:
+				t.Fatalf("token was rejected: %v", err)
+			case identity.Name() != test.wantName:
+				t.Fatalf("name = %q, want %q", identity.Name(), test.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/megakuul/miam/pkg/policy"
+)
+
+const (
+	// RoleViewer may read clusters and the operator.
+	RoleViewer = "viewer"
+	// RoleOperator may additionally deploy, destroy, cancel and prune clusters.
+	RoleOperator = "operator"
+	// RoleAdmin may call every procedure, including operator maintenance and identity management.
+	RoleAdmin = "admin"
+)
+
+var viewerActions = []string{
+	"cluster:List",
+	"cluster:Get",
+	"cluster:Describe",
+	"operator:Get",
+	"operator:Describe",
+	// checks the caller only, checking other principals requires the admin only "authorize:CheckOther".
+	"authorize:Check",
+}
+
+// ActionCheckOther allows to check the permissions of principals other than the caller with AuthorizeService.Check.
+const ActionCheckOther = "authorize:CheckOther"
+
+// BuiltinRoles maps the builtin role names to their policies.
+var BuiltinRoles = map[string]*policy.Document{
+	RoleViewer: {Statements: []policy.Statement{{
+		Effect:    policy.Allow,
+		Actions:   viewerActions,
+		Resources: []string{"*"},
+	}}},
+	RoleOperator: {Statements: []policy.Statement{{
+		Effect: policy.Allow,
+		Actions: append(slices.Clone(viewerActions),
+			"cluster:Update",
+			"cluster:Destroy",
+			"cluster:Cancel",
+			"cluster:Prune",
+		),
+		Resources: []string{"*"},
+	}}},
+	RoleAdmin: {Statements: []policy.Statement{{
+		Effect:    policy.Allow,
+		Actions:   []string{"*"},
+		Resources: []string{"*"},
+	}}},
+}
+
+// ErrDisabled is returned for identities of disabled users and service accounts.
+var ErrDisabled = errors.New("user is disabled")
+
+const (
+	// GroupPrefix prefixes policy subjects referring to a group.
+	GroupPrefix = "group:"
+	// IAMPrefix starts the subject of iam identities (their principal arn).
+	IAMPrefix = "arn:"
+	// ClientSubjectPrefix prefixes the subject of idp tokens issued to clients with the client credentials flow.
+	ClientSubjectPrefix = "client:"
+	// issuerSeparator separates the issuer from the subject of identities of untrusted issuers.
+	issuerSeparator = "#"
+)
+
+// reservedPrefixes are the subject forms owned by a single verifier (or policy groups),
+// user names and the subjects of other issuers must not use them.
+var reservedPrefixes = []string{ServiceAccountPrefix, IAMPrefix, ClientSubjectPrefix, GroupPrefix}
+
+// Reserved reports whether the name uses a subject form owned by a specific verifier.
+func Reserved(name string) bool {
+	return slices.ContainsFunc(reservedPrefixes, func(prefix string) bool { return strings.HasPrefix(name, prefix) })
+}
+
+// Policy grants a role to a set of subjects.
+// Subjects match the principal of the identity (see Authorizer.Principal) built from its subject or name,
+// or one of its groups prefixed with "group:". Identities of untrusted issuers are matched as "<issuer>#<subject>".
+// The role is restricted to clusters matching one of the cluster name patterns (path.Match syntax)
+// or carrying one of the tags. Policies without clusters and tags are unscoped and apply to everything.
+type Policy struct {
+	Subjects []string `toml:"subjects"`
+	Role     string   `toml:"role"`
+	Clusters []string `toml:"clusters"`
+	Tags     []string `toml:"tags"`
+}
+
+func (p *Policy) scoped() bool {
+	return len(p.Clusters) > 0 || len(p.Tags) > 0
+}
+
+func (p *Policy) matches(principals, groups []string) bool {
+	for _, subject := range p.Subjects {
+		if group, ok := strings.CutPrefix(subject, GroupPrefix); ok {
+			if slices.Contains(groups, group) {
+				return true
+			}
+		} else if slices.Contains(principals, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) covers(resource *Resource) bool {
+	if !p.scoped() {
+		return true
+	}
+	if resource == nil {
+		return false
+	}
+	for _, pattern := range p.Clusters {
+		if ok, _ := path.Match(pattern, resource.Cluster); ok {
+			return true
+		}
+	}
+	for _, tag := range p.Tags {
+		if slices.Contains(resource.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resource is the cluster a request operates on. A nil resource refers to global operations
+// (e.g. operator maintenance), which are only granted by unscoped policies.
+type Resource struct {
+	Cluster string
+	Tags    []string
+}
+
+// Directory holds the identities, roles and bindings managed through the iam api.
+// Users and service accounts are principals of trusted issuers (see Authorizer.Principal).
+type Directory struct {
+	Policies []Policy                    // role bindings
+	Roles    map[string]*policy.Document // custom role name -> policy
+	Groups   map[string][]string         // user name -> group names
+	Disabled map[string]bool             // user name or service account subject -> disabled
+}
+
+// DirectorySource loads the current directory (e.g. from the store).
+type DirectorySource func(ctx context.Context) (*Directory, error)
+
+// Authorizer decides whether an identity may call a procedure based on the configured policies
+// and the bindings of the directory. The directory is cached for the refresh interval.
+type Authorizer struct {
+	policies []Policy
+	source   DirectorySource
+	refresh  time.Duration
+	issuers  []string
+
+	lock      sync.Mutex
+	directory *Directory
+	loaded    time.Time
+}
+
+// NewAuthorizer creates an authorizer evaluating the specified policies and the directory loaded from source.
+// The source may be nil if only static policies are used. The identities of the trusted issuers are referred to by
+// their plain subject or name, the identities of all other issuers are qualified with their issuer.
+func NewAuthorizer(policies []Policy, source DirectorySource, refresh time.Duration, issuers ...string) *Authorizer {
+	return &Authorizer{
+		policies: policies,
+		source:   source,
+		refresh:  refresh,
+		issuers:  issuers,
+	}
+}
+
+// Principal returns the name policies and the directory refer to the identity by.
+// Api key and iam identities and the identities of trusted issuers are referred to by their name,
+// the identities of other issuers by "<issuer>#<name>", so that they cannot impersonate each other.
+func (a *Authorizer) Principal(identity *Identity) string {
+	return a.qualify(identity, identity.Name())
+}
+
+func (a *Authorizer) qualify(identity *Identity, name string) string {
+	// api key and iam identities always carry their reserved subject form,
+	// identities without issuer are built by the operator from a principal (see IdentityOf).
+	switch {
+	case identity.Issuer == "", identity.Issuer == APIKeyIssuer, identity.Issuer == IAMIssuer:
+	case slices.Contains(a.issuers, identity.Issuer):
+	default:
+		return identity.Issuer + issuerSeparator + name
+	}
+	return name
+}
+
+// IdentityOf returns the identity referred to by the principal, e.g. to check the permissions of another caller.
+func (a *Authorizer) IdentityOf(principal string) *Identity {
+	if issuer, subject, ok := strings.Cut(principal, issuerSeparator); ok {
+		return &Identity{Issuer: issuer, Subject: subject}
+	}
+	return &Identity{Subject: principal}
+}
+
+// principals returns the forms policies may refer to the identity by.
+func (a *Authorizer) principals(identity *Identity) []string {
+	return []string{a.qualify(identity, identity.Subject), a.Principal(identity)}
+}
+
+func (a *Authorizer) load(ctx context.Context) (*Directory, error) {
+	if a.source == nil {
+		return &Directory{}, nil
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.directory == nil || time.Since(a.loaded) > a.refresh {
+		directory, err := a.source(ctx)
+		if err != nil {
+			return nil, err
+		}
+		a.directory, a.loaded = directory, time.Now()
+	}
+	return a.directory, nil
+}
+
+// Allowed reports whether the identity may call the procedure on the resource.
+func (a *Authorizer) Allowed(ctx context.Context, identity *Identity, procedure string, resource *Resource) (bool, error) {
+	decision, err := a.Check(ctx, identity, policy.ActionOf(procedure), resource)
+	if err != nil {
+		return false, err
+	}
+	return decision.Allowed, nil
+}
+
+// AllowedAny reports whether the identity may call the procedure on any resource.
+func (a *Authorizer) AllowedAny(ctx context.Context, identity *Identity, procedure string) (bool, error) {
+	directory, err := a.load(ctx)
+	if err != nil {
+		return false, err
+	}
+	if directory.Disabled[a.Principal(identity)] {
+		return false, nil
+	}
+	if identity.Scope != nil && !policy.MayAllow([]*policy.Document{identity.Scope}, policy.ActionOf(procedure)) {
+		return false, nil
+	}
+	documents := a.documents(directory, identity, func(p *Policy) bool { return true })
+	return policy.MayAllow(documents, policy.ActionOf(procedure)), nil
+}
+
+// Check evaluates the action on the resource against the policies of all roles bound to the identity.
+// Scoped identities are additionally restricted to the requests allowed by their scope.
+// Global resources (nil) are named after the service of the action (e.g. "operator").
+func (a *Authorizer) Check(ctx context.Context, identity *Identity, action string, resource *Resource) (policy.Decision, error) {
+	directory, err := a.load(ctx)
+	if err != nil {
+		return policy.Decision{}, err
+	}
+	if directory.Disabled[a.Principal(identity)] {
+		return policy.Decision{Allowed: false, Reason: ErrDisabled.Error()}, nil
+	}
+	groups := a.groups(directory, identity)
+	request := policy.Request{
+		Action: action,
+		Context: map[string][]string{
+			policy.KeyIdentitySubject: {identity.Subject},
+			policy.KeyIdentityName:    {a.Principal(identity)},
+			policy.KeyIdentityIssuer:  {identity.Issuer},
+			policy.KeyIdentityGroup:   groups,
+		},
+	}
+	if resource == nil {
+		request.Resource, _, _ = strings.Cut(action, ":")
+	} else {
+		request.Resource = "cluster:" + resource.Cluster
+		request.Context[policy.KeyClusterTag] = resource.Tags
+	}
+	if identity.Scope != nil {
+		if decision := policy.Evaluate([]*policy.Document{identity.Scope}, request); !decision.Allowed {
+			return policy.Decision{Allowed: false, Reason: "outside of the identity scope"}, nil
+		}
+	}
+	documents := a.documents(directory, identity, func(p *Policy) bool { return p.covers(resource) })
+	return policy.Evaluate(documents, request), nil
+}
+
+// ScopedRole is a role bound to an identity for the clusters matching one of the patterns or carrying one of the tags.
+type ScopedRole struct {
+	Role     string   `json:"role"`
+	Clusters []string `json:"clusters,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Grants returns the groups of the identity, the names of the roles bound to it for all clusters
+// and the roles bound to it for a subset of the clusters. Fails with ErrDisabled if the user is disabled.
+func (a *Authorizer) Grants(ctx context.Context, identity *Identity) ([]string, []string, []ScopedRole, error) {
+	directory, err := a.load(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if directory.Disabled[a.Principal(identity)] {
+		return nil, nil, nil, ErrDisabled
+	}
+	principals, groups := a.principals(identity), a.groups(directory, identity)
+	roles, scoped := []string{}, []ScopedRole{}
+	for _, p := range slices.Concat(a.policies, directory.Policies) {
+		if !p.matches(principals, groups) {
+			continue
+		}
+		if !p.scoped() {
+			if !slices.Contains(roles, p.Role) {
+				roles = append(roles, p.Role)
+			}
+			continue
+		}
+		scoped = append(scoped, ScopedRole{Role: p.Role, Clusters: p.Clusters, Tags: p.Tags})
+	}
+	// scoped bindings of roles that are also bound unscoped grant nothing in addition.
+	scoped = slices.DeleteFunc(scoped, func(s ScopedRole) bool { return slices.Contains(roles, s.Role) })
+	return groups, roles, scoped, nil
+}
+
+// groups returns the groups of the identity, groups claimed by untrusted issuers are qualified like principals.
+func (a *Authorizer) groups(directory *Directory, identity *Identity) []string {
+	groups := []string{}
+	for _, group := range identity.Groups {
+		groups = append(groups, a.qualify(identity, group))
+	}
+	return append(groups, directory.Groups[a.Principal(identity)]...)
+}
+
+// documents returns the policies of the roles bound to the identity by policies passing the filter.
+func (a *Authorizer) documents(directory *Directory, identity *Identity, filter func(*Policy) bool) []*policy.Document {
+	principals, groups := a.principals(identity), a.groups(directory, identity)
+	documents := []*policy.Document{}
+	for _, p := range slices.Concat(a.policies, directory.Policies) {
+		if !p.matches(principals, groups) || !filter(&p) {
+			continue
+		}
+		if document, ok := BuiltinRoles[p.Role]; ok {
+			documents = append(documents, document)
+		} else if document, ok := directory.Roles[p.Role]; ok {
+			documents = append(documents, document)
+		}
+	}
+	return documents
+}
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/megakuul/miam/pkg/policy"
+)
+
+const (
+	testIDP      = "https://miam.test"
+	testExternal = "https://external.test"
+)
+
+func testDirectory(ctx context.Context) (*Directory, error) {
+	return &Directory{
+		Policies: []Policy{
+			{Subjects: []string{"alice@example.com"}, Role: RoleAdmin},
+			{Subjects: []string{testExternal + "#bob@example.com"}, Role: RoleOperator},
+			{Subjects: []string{"group:deployers"}, Role: RoleOperator, Clusters: []string{"dev-*"}},
+			{Subjects: []string{"group:" + testExternal + "#viewers"}, Role: RoleViewer},
+			{Subjects: []string{"serviceaccount:ci"}, Role: RoleOperator},
+			{Subjects: []string{"arn:aws:iam::123456789012:role/deployer"}, Role: RoleViewer},
+		},
+		Groups: map[string][]string{
+			"carol@example.com": {"deployers"},
+		},
+		Disabled: map[string]bool{
+			"dave@example.com": true,
+		},
+	}, nil
+}
+
+func TestAuthorizerPrincipal(t *testing.T) {
+	authorizer := NewAuthorizer(nil, nil, time.Minute, testIDP)
+	tests := []struct {
+		name     string
+		identity *Identity
+		want     string
+	}{
+		{"trusted issuer", &Identity{Issuer: testIDP, Subject: "alice@example.com"}, "alice@example.com"},
+		{"trusted issuer with email", &Identity{Issuer: testIDP, Subject: "123", Email: "alice@example.com"}, "alice@example.com"},
+		{"untrusted issuer", &Identity{Issuer: testExternal, Subject: "123", Email: "alice@example.com"}, testExternal + "#alice@example.com"},
+		{"api key", &Identity{Issuer: APIKeyIssuer, Subject: "serviceaccount:ci"}, "serviceaccount:ci"},
+		{"iam", &Identity{Issuer: IAMIssuer, Subject: "arn:aws:iam::1:role/x"}, "arn:aws:iam::1:role/x"},
+		{"built from principal", authorizer.IdentityOf("alice@example.com"), "alice@example.com"},
+		{"built from qualified principal", authorizer.IdentityOf(testExternal + "#bob"), testExternal + "#bob"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := authorizer.Principal(test.identity); got != test.want {
+				t.Fatalf("principal = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizerCheck(t *testing.T) {
+	authorizer := NewAuthorizer(nil, testDirectory, time.Minute, testIDP)
+	dev := &Resource{Cluster: "dev-1"}
+	prod := &Resource{Cluster: "prod-1"}
+	tests := []struct {
+		name     string
+		identity *Identity
+		action   string
+		resource *Resource
+		allowed  bool
+	}{
+		{"bound user", &Identity{Issuer: testIDP, Subject: "alice@example.com"}, "operator:Update", nil, true},
+		{"same name of untrusted issuer", &Identity{Issuer: testExternal, Subject: "alice@example.com"}, "cluster:Get", prod, false},
+		{"verified email of untrusted issuer", &Identity{Issuer: testExternal, Subject: "1", Email: "alice@example.com"}, "cluster:Get", prod, false},
+		{"qualified binding", &Identity{Issuer: testExternal, Subject: "2", Email: "bob@example.com"}, "cluster:Update", prod, true},
+		{"qualified binding of trusted name", &Identity{Issuer: testIDP, Subject: "bob@example.com"}, "cluster:Get", prod, false},
+		{"directory group in scope", &Identity{Issuer: testIDP, Subject: "carol@example.com"}, "cluster:Update", dev, true},
+		{"directory group out of scope", &Identity{Issuer: testIDP, Subject: "carol@example.com"}, "cluster:Update", prod, false},
+		{"directory group not granted to untrusted issuer", &Identity{Issuer: testExternal, Subject: "carol@example.com"}, "cluster:Update", dev, false},
+		{"token group of trusted issuer", &Identity{Issuer: testIDP, Subject: "x", Groups: []string{"deployers"}}, "cluster:Update", dev, true},
+		{"token group of untrusted issuer", &Identity{Issuer: testExternal, Subject: "x", Groups: []string{"deployers"}}, "cluster:Update", dev, false},
+		{"qualified token group", &Identity{Issuer: testExternal, Subject: "x", Groups: []string{"viewers"}}, "cluster:Get", prod, true},
+		{"disabled user", &Identity{Issuer: testIDP, Subject: "dave@example.com", Groups: []string{"deployers"}}, "cluster:Get", dev, false},
+		{"service account", &Identity{Issuer: APIKeyIssuer, Subject: "serviceaccount:ci"}, "cluster:Update", prod, true},
+		{"iam principal", &Identity{Issuer: IAMIssuer, Subject: "arn:aws:iam::123456789012:role/deployer"}, "cluster:Get", prod, true},
+		{"untrusted issuer claiming an iam principal", &Identity{Issuer: testExternal, Subject: "arn:aws:iam::123456789012:role/deployer"}, "cluster:Get", prod, false},
+		{
+			name:     "scope restricts bound role",
+			identity: &Identity{Issuer: APIKeyIssuer, Subject: "serviceaccount:ci", Scope: &policy.Document{Statements: []policy.Statement{{Effect: policy.Allow, Actions: []string{"cluster:Get"}, Resources: []string{"*"}}}}},
+			action:   "cluster:Update",
+			resource: prod,
+			allowed:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision, err := authorizer.Check(context.Background(), test.identity, test.action, test.resource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decision.Allowed != test.allowed {
+				t.Fatalf("allowed = %v, want %v (%s)", decision.Allowed, test.allowed, decision.Reason)
+			}
+		})
+	}
+}
+
+func TestReserved(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"alice@example.com", false},
+		{"serviceaccount:ci", true},
+		{"arn:aws:iam::1:role/x", true},
+		{"client:web", true},
+		{"group:admins", true},
+		{"user:arn", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Reserved(test.name); got != test.want {
+				t.Fatalf("reserved = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizerGrants(t *testing.T) {
+	authorizer := NewAuthorizer([]Policy{
+		{Subjects: []string{"erin@example.com"}, Role: RoleOperator, Tags: []string{"team-a"}},
+		{Subjects: []string{"erin@example.com"}, Role: RoleViewer},
+		{Subjects: []string{"erin@example.com"}, Role: RoleViewer, Clusters: []string{"prod-*"}},
+	}, testDirectory, time.Minute, testIDP)
+	tests := []struct {
+		name     string
+		identity *Identity
+		roles    []string
+		scoped   []ScopedRole
+		wantErr  bool
+	}{
+		{
+			name:     "unscoped role",
+			identity: &Identity{Issuer: testIDP, Subject: "alice@example.com"},
+			roles:    []string{RoleAdmin},
+			scoped:   []ScopedRole{},
+		},
+		{
+			name:     "scoped role is not listed as role",
+			identity: &Identity{Issuer: testIDP, Subject: "carol@example.com"},
+			roles:    []string{},
+			scoped:   []ScopedRole{{Role: RoleOperator, Clusters: []string{"dev-*"}}},
+		},
+		{
+			name:     "scoped role covered by unscoped binding is omitted",
+			identity: &Identity{Issuer: testIDP, Subject: "erin@example.com"},
+			roles:    []string{RoleViewer},
+			scoped:   []ScopedRole{{Role: RoleOperator, Tags: []string{"team-a"}}},
+		},
+		{
+			name:     "disabled user",
+			identity: &Identity{Issuer: testIDP, Subject: "dave@example.com"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, roles, scoped, err := authorizer.Grants(context.Background(), test.identity)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("grants of disabled user were returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(roles, test.roles) {
+				t.Fatalf("roles = %v, want %v", roles, test.roles)
+			}
+			if !reflect.DeepEqual(scoped, test.scoped) {
+				t.Fatalf("scoped roles = %+v, want %+v", scoped, test.scoped)
+			}
+		})
+	}
+}
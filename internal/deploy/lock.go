@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// unlock removes the stack lock that an interrupted operation leaves behind on a diy backend.
+// Locks are located at .pulumi/locks/organization/<project>/<stack>/ (or .pulumi/locks/<stack>/ on legacy backends).
+// Other backends release the lock themselves once the update is cancelled with cancel.
+func (r *Runner) unlock(ctx context.Context, project, stackName string, cancel func(context.Context) error) error {
+	backend, err := url.Parse(r.backend)
+	if err != nil {
+		return fmt.Errorf("failed to parse backend url: %v", err)
+	}
+	lockDirs := []string{
+		path.Join(".pulumi", "locks", "organization", project, stackName),
+		path.Join(".pulumi", "locks", stackName),
+	}
+	switch backend.Scheme {
+	case "s3":
+		return r.unlockS3(ctx, backend, lockDirs)
+	case "file":
+		for _, dir := range lockDirs {
+			if err := os.RemoveAll(filepath.Join(backend.Path, dir)); err != nil {
+				return fmt.Errorf("failed to remove lock: %v", err)
+			}
+		}
+		return nil
+	default:
+		// pulumi cloud releases the lock of cancelled updates itself.
+		return cancel(ctx)
+	}
+}
+
+func (r *Runner) unlockS3(ctx context.Context, backend *url.URL, lockDirs []string) error {
+	if r.s3Client == nil {
+		return fmt.Errorf("no s3 client available to unlock backend %s", backend)
+	}
+	for _, dir := range lockDirs {
+		prefix := strings.TrimPrefix(path.Join(backend.Path, dir), "/") + "/"
+		paginator := s3.NewListObjectsV2Paginator(r.s3Client, &s3.ListObjectsV2Input{
+			Bucket: aws.String(backend.Host),
+			Prefix: aws.String(prefix),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list locks: %v", err)
+			}
+			objects := []s3types.ObjectIdentifier{}
+			for _, object := range page.Contents {
+				// only direct lock files are removed, nested directories belong to other stacks.
+				if !strings.Contains(strings.TrimPrefix(*object.Key, prefix), "/") {
+					objects = append(objects, s3types.ObjectIdentifier{Key: object.Key})
+				}
+			}
+			if len(objects) < 1 {
+				continue
+			}
+			_, err = r.s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String(backend.Host),
+				Delete: &s3types.Delete{Objects: objects},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to remove locks: %v", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/megakuul/miam/internal/tracing"
+	"github.com/pulumi/pulumi/sdk/v3/go/auto"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var commitHashPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+var tracer = otel.Tracer("github.com/megakuul/miam/internal/deploy")
+
+// Source describes the git location of a pulumi program.
+type Source struct {
+	URL string
+	Ref string
+}
+
+// Runner executes pulumi programs from git sources with the automation api.
+type Runner struct {
+	backend         string
+	secretsProvider string
+	s3Client        *s3.Client
+}
+
+// NewRunner creates a runner storing the stack state on the specified backend.
+// The s3 client is used to release stack locks on s3 backends.
+func NewRunner(backend, secretsProvider string, s3Client *s3.Client) *Runner {
+	return &Runner{
+		backend:         backend,
+		secretsProvider: secretsProvider,
+		s3Client:        s3Client,
+	}
+}
+
+// Stack is a pulumi stack checked out from a git source.
+type Stack struct {
+	stack auto.Stack
+	// Project is the name of the pulumi project of the stack.
+	Project string
+}
+
+// Checkout checks out the source and selects the stack, creating it if it does not exist.
+func (r *Runner) Checkout(ctx context.Context, stackName string, source Source) (*Stack, error) {
+	stack, err := r.stack(ctx, stackName, source)
+	if err != nil {
+		return nil, err
+	}
+	project, err := stack.Workspace().ProjectSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project settings: %v", err)
+	}
+	return &Stack{stack: stack, Project: string(project.Name)}, nil
+}
+
+// Up updates the stack with the provided config.
+func (r *Runner) Up(ctx context.Context, stack *Stack, config map[string]string) error {
+	ctx, span := tracer.Start(ctx, "pulumi.Up", trace.WithAttributes(attribute.String("pulumi.stack", stack.stack.Name())))
+	defer span.End()
+	configMap := auto.ConfigMap{}
+	for key, value := range config {
+		configMap[key] = auto.ConfigValue{Value: value}
+	}
+	if err := stack.stack.SetAllConfig(ctx, configMap); err != nil {
+		err = fmt.Errorf("failed to set stack config: %v", err)
+		tracing.RecordError(span, err)
+		return err
+	}
+	if _, err := stack.stack.Up(ctx); err != nil {
+		err = r.interrupted(ctx, stack, fmt.Errorf("failed to update stack: %v", err))
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// Destroy destroys all resources of the stack.
+func (r *Runner) Destroy(ctx context.Context, stack *Stack) error {
+	ctx, span := tracer.Start(ctx, "pulumi.Destroy", trace.WithAttributes(attribute.String("pulumi.stack", stack.stack.Name())))
+	defer span.End()
+	if _, err := stack.stack.Destroy(ctx); err != nil {
+		err = r.interrupted(ctx, stack, fmt.Errorf("failed to destroy stack: %v", err))
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// Unlock releases the lock of a stack of the pulumi project, e.g. left behind by a stopped operator.
+// Unlike Up and Destroy it operates without checkout of the source.
+func (r *Runner) Unlock(ctx context.Context, project, stackName string) error {
+	ctx, span := tracer.Start(ctx, "pulumi.Unlock", trace.WithAttributes(attribute.String("pulumi.stack", stackName)))
+	defer span.End()
+	err := r.unlock(ctx, project, stackName, func(ctx context.Context) error {
+		return r.cancel(ctx, project, stackName)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to release stack lock: %v", err)
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// interrupted releases the stack lock if the operation failed because its context was cancelled.
+func (r *Runner) interrupted(ctx context.Context, stack *Stack, err error) error {
+	if ctx.Err() == nil {
+		return err
+	}
+	unlockCtx, span := tracer.Start(context.WithoutCancel(ctx), "pulumi.Unlock")
+	defer span.End()
+	if unlockErr := r.unlock(unlockCtx, stack.Project, stack.stack.Name(), stack.stack.Cancel); unlockErr != nil {
+		tracing.RecordError(span, unlockErr)
+		return fmt.Errorf("%v (failed to release stack lock: %v)", context.Cause(ctx), unlockErr)
+	}
+	return context.Cause(ctx)
+}
+
+// cancel cancels the running update of a stack from a workspace that only holds the project settings.
+func (r *Runner) cancel(ctx context.Context, project, stackName string) error {
+	opts := append(r.workspaceOptions(), auto.Project(workspace.Project{
+		Name:    tokens.PackageName(project),
+		Runtime: workspace.NewProjectRuntimeInfo("go", nil),
+	}))
+	ws, err := auto.NewLocalWorkspace(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create workspace: %v", err)
+	}
+	defer os.RemoveAll(ws.WorkDir())
+	stack, err := auto.SelectStack(ctx, stackName, ws)
+	if err != nil {
+		return fmt.Errorf("failed to select stack: %v", err)
+	}
+	return stack.Cancel(ctx)
+}
+
+// CLIVersion returns the version of the pulumi cli the automation api operates, fails if the cli is not available.
+func (r *Runner) CLIVersion() (string, error) {
+	command, err := auto.NewPulumiCommand(nil)
+	if err != nil {
+		return "", fmt.Errorf("pulumi cli is not available: %v", err)
+	}
+	return command.Version().String(), nil
+}
+
+// stack checks out the source and selects the stack, creating it if it does not exist.
+func (r *Runner) stack(ctx context.Context, stackName string, source Source) (auto.Stack, error) {
+	ctx, span := tracer.Start(ctx, "git.Checkout", trace.WithAttributes(
+		attribute.String("git.url", source.URL),
+		attribute.String("git.ref", source.Ref),
+		attribute.String("pulumi.stack", stackName),
+	))
+	defer span.End()
+	repo := auto.GitRepo{URL: source.URL}
+	if commitHashPattern.MatchString(source.Ref) {
+		repo.CommitHash = source.Ref
+	} else {
+		repo.Branch = source.Ref
+	}
+	stack, err := auto.UpsertStackRemoteSource(ctx, stackName, repo, r.workspaceOptions()...)
+	if err != nil {
+		err = fmt.Errorf("failed to construct stack: %v", err)
+		tracing.RecordError(span, err)
+		return auto.Stack{}, err
+	}
+	return stack, nil
+}
+
+// workspaceOptions configures workspaces to operate on the backend and secrets provider of the runner.
+func (r *Runner) workspaceOptions() []auto.LocalWorkspaceOption {
+	opts := []auto.LocalWorkspaceOption{}
+	if r.backend != "" {
+		opts = append(opts, auto.EnvVars(map[string]string{"PULUMI_BACKEND_URL": r.backend}))
+	}
+	if r.secretsProvider != "" {
+		opts = append(opts, auto.SecretsProvider(r.secretsProvider))
+	}
+	return opts
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthCheckProcedure = "/grpc.health.v1.Health/Check"
+	healthWatchProcedure = "/grpc.health.v1.Health/Watch"
+	// watchInterval is the interval in which watched statuses are re-evaluated.
+	watchInterval = 5 * time.Second
+)
+
+// registerGRPC serves the standard grpc health service (grpc.health.v1.Health) over connect.
+// The empty service name reports the overall status of the operator.
+func (s *Server) registerGRPC(mux *http.ServeMux) {
+	mux.Handle(healthCheckProcedure, connect.NewUnaryHandler(healthCheckProcedure, s.check))
+	mux.Handle(healthWatchProcedure, connect.NewServerStreamHandler(healthWatchProcedure, s.watch))
+}
+
+func (s *Server) status(ctx context.Context, service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, bool) {
+	if service != "" && !slices.Contains(s.services, service) {
+		return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+	if !s.Ready(ctx).Ready {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, true
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING, true
+}
+
+func (s *Server) check(ctx context.Context, req *connect.Request[grpc_health_v1.HealthCheckRequest]) (*connect.Response[grpc_health_v1.HealthCheckResponse], error) {
+	status, ok := s.status(ctx, req.Msg.GetService())
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service '%s'", req.Msg.GetService()))
+	}
+	return connect.NewResponse(&grpc_health_v1.HealthCheckResponse{Status: status}), nil
+}
+
+// watch sends the status of the service whenever it changes. Streams end once the operator drains,
+// so that they do not block the shutdown.
+func (s *Server) watch(ctx context.Context, req *connect.Request[grpc_health_v1.HealthCheckRequest], stream *connect.ServerStream[grpc_health_v1.HealthCheckResponse]) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	last := grpc_health_v1.HealthCheckResponse_UNKNOWN
+	for {
+		status, _ := s.status(ctx, req.Msg.GetService())
+		if status != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+			last = status
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.draining:
+			if last != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+				return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING})
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Package health serves the liveness, readiness and version endpoints of the operator.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	// checkTimeout bounds the time a single readiness check may take.
+	checkTimeout = 5 * time.Second
+	// cacheLifetime is the time a readiness report is reused, so that frequent probes do not hammer the dependencies.
+	cacheLifetime = 2 * time.Second
+)
+
+// Check is a named dependency the operator requires to serve requests.
+type Check struct {
+	Name  string
+	Probe func(ctx context.Context) error
+}
+
+// Report is the outcome of the readiness checks, mapping each check to "ok" or its error.
+type Report struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Server reports the health of the operator. It reports not ready once draining started.
+type Server struct {
+	checks   []Check
+	services []string
+	revision func(ctx context.Context) (string, error)
+
+	lock     sync.Mutex
+	cached   *Report
+	cachedAt time.Time
+	drain    sync.Once
+	draining chan struct{}
+}
+
+// New creates a server running the readiness checks. The services are reported by the grpc health service,
+// the revision function returns the currently active operator revision.
+func New(checks []Check, services []string, revision func(ctx context.Context) (string, error)) *Server {
+	return &Server{
+		checks:   checks,
+		services: services,
+		revision: revision,
+		draining: make(chan struct{}),
+	}
+}
+
+// Register adds the health endpoints and the grpc health service to the mux.
+func (s *Server) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /readyz", s.handleReady)
+	mux.HandleFunc("GET /version", s.handleVersion)
+	s.registerGRPC(mux)
+}
+
+// Drain marks the operator as not ready, so that load balancers stop routing requests to it.
+func (s *Server) Drain() {
+	s.drain.Do(func() { close(s.draining) })
+}
+
+// Ready runs the readiness checks concurrently, reports are cached for a short time.
+func (s *Server) Ready(ctx context.Context) *Report {
+	select {
+	case <-s.draining:
+		return &Report{Ready: false, Checks: map[string]string{"shutdown": "operator is shutting down"}}
+	default:
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.cached != nil && time.Since(s.cachedAt) < cacheLifetime {
+		return s.cached
+	}
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	report := &Report{Ready: true, Checks: map[string]string{}}
+	results := make([]error, len(s.checks))
+	wg := sync.WaitGroup{}
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check.Probe(ctx)
+		}()
+	}
+	wg.Wait()
+	for i, check := range s.checks {
+		report.Checks[check.Name] = "ok"
+		if results[i] != nil {
+			report.Ready, report.Checks[check.Name] = false, results[i].Error()
+		}
+	}
+	s.cached, s.cachedAt = report, time.Now()
+	return report
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	report := s.Ready(r.Context())
+	if !report.Ready {
+		writeJSON(w, http.StatusServiceUnavailable, report)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// Version describes the operator build and the deployed operator revision.
+type Version struct {
+	Version          string `json:"version"`
+	GoVersion        string `json:"go_version"`
+	Commit           string `json:"commit,omitempty"`
+	CommitTime       string `json:"commit_time,omitempty"`
+	Modified         bool   `json:"modified,omitempty"`
+	OperatorRevision string `json:"operator_revision,omitempty"`
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	version := &Version{Version: "unknown"}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version.Version, version.GoVersion = info.Main.Version, info.GoVersion
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				version.Commit = setting.Value
+			case "vcs.time":
+				version.CommitTime = setting.Value
+			case "vcs.modified":
+				version.Modified = setting.Value == "true"
+			}
+		}
+	}
+	// the revision is informational, the version is served even if the store is unavailable.
+	if revision, err := s.revision(r.Context()); err == nil {
+		version.OperatorRevision = revision
+	}
+	writeJSON(w, http.StatusOK, version)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
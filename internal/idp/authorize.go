@@ -0,0 +1,165 @@
+package idp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/megakuul/miam/internal/auth"
+)
+
+// codeLifetime is the time a client has to redeem an authorization code.
+const codeLifetime = time.Minute
+
+// grant is an issued authorization code waiting to be redeemed at the token endpoint.
+type grant struct {
+	ClientID    string         `json:"client_id"`
+	RedirectURI string         `json:"redirect_uri"`
+	Identity    *auth.Identity `json:"identity"`
+	Scope       string         `json:"scope"`
+	Nonce       string         `json:"nonce"`
+	Challenge   string         `json:"challenge"`
+	Expires     time.Time      `json:"expires"`
+}
+
+// verifyChallenge checks the pkce verifier against the S256 challenge of the grant.
+func (g *grant) verifyChallenge(verifier string) bool {
+	if g.Challenge == "" {
+		return verifier == ""
+	}
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:]) == g.Challenge
+}
+
+// handleAuthorize implements the authorization endpoint of the authorization code flow.
+// The user is authenticated with the bearer token or the session of the request; unauthenticated requests
+// are sent to the upstream login if configured, otherwise back to the client with the login_required error.
+func (p *Provider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	client, ok := p.clients[r.Form.Get("client_id")]
+	if !ok {
+		http.Error(w, "unknown client", http.StatusBadRequest)
+		return
+	}
+	redirectURI := r.Form.Get("redirect_uri")
+	if !slices.Contains(client.RedirectURIs, redirectURI) {
+		// errors must not be redirected to unregistered uris.
+		http.Error(w, "redirect_uri is not registered for the client", http.StatusBadRequest)
+		return
+	}
+	state := r.Form.Get("state")
+	if r.Form.Get("response_type") != "code" {
+		redirectError(w, r, redirectURI, state, "unsupported_response_type", "only the code response type is supported")
+		return
+	}
+	challenge := r.Form.Get("code_challenge")
+	if challenge != "" && r.Form.Get("code_challenge_method") != "S256" {
+		redirectError(w, r, redirectURI, state, "invalid_request", "only the S256 code challenge method is supported")
+		return
+	}
+	if challenge == "" && client.public() {
+		redirectError(w, r, redirectURI, state, "invalid_request", "public clients must use pkce")
+		return
+	}
+
+	identity, err := p.authenticate(r)
+	if err != nil {
+		if len(p.upstreams) > 0 && r.Header.Get("Authorization") == "" && r.Form.Get("prompt") != "none" {
+			http.Redirect(w, r, p.loginURL(p.prefix+"/oauth2/authorize?"+r.Form.Encode()), http.StatusFound)
+			return
+		}
+		redirectError(w, r, redirectURI, state, "login_required", err.Error())
+		return
+	}
+	if _, _, _, err := p.authorizer.Grants(r.Context(), identity); err != nil {
+		if errors.Is(err, auth.ErrDisabled) {
+			redirectError(w, r, redirectURI, state, "access_denied", err.Error())
+		} else {
+			redirectError(w, r, redirectURI, state, "server_error", "failed to load user grants")
+		}
+		return
+	}
+
+	code, expires := rand.Text(), time.Now().Add(codeLifetime)
+	err = p.putState(r.Context(), stateCode, code, &grant{
+		ClientID:    client.ID,
+		RedirectURI: redirectURI,
+		Identity:    identity,
+		Scope:       r.Form.Get("scope"),
+		Nonce:       r.Form.Get("nonce"),
+		Challenge:   challenge,
+		Expires:     expires,
+	}, expires)
+	if err != nil {
+		redirectError(w, r, redirectURI, state, "server_error", "failed to store authorization code")
+		return
+	}
+
+	redirect(w, r, redirectURI, url.Values{"code": {code}, "state": {state}})
+}
+
+// authenticate resolves the identity of the bearer token with the upstream verifiers,
+// requests without bearer token are authenticated with their session.
+func (p *Provider) authenticate(r *http.Request) (*auth.Identity, error) {
+	if r.Header.Get("Authorization") == "" {
+		if identity, ok := p.session(r); ok {
+			return identity, nil
+		}
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, errors.New("authentication required")
+	}
+	identity, err := auth.Authenticate(r.Context(), p.verifiers, token)
+	if errors.Is(err, auth.ErrUnsupportedToken) {
+		return nil, errors.New("unsupported token")
+	} else if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	return identity, nil
+}
+
+// redeem returns and removes the grant of the code.
+func (p *Provider) redeem(ctx context.Context, code string) (*grant, bool) {
+	g := &grant{}
+	if code == "" || !p.takeState(ctx, stateCode, code, g) {
+		return nil, false
+	}
+	return g, time.Now().Before(g.Expires)
+}
+
+func redirectError(w http.ResponseWriter, r *http.Request, redirectURI, state, code, description string) {
+	redirect(w, r, redirectURI, url.Values{
+		"error":             {code},
+		"error_description": {description},
+		"state":             {state},
+	})
+}
+
+func redirect(w http.ResponseWriter, r *http.Request, redirectURI string, params url.Values) {
+	if params.Get("state") == "" {
+		params.Del("state")
+	}
+	target, _ := url.Parse(redirectURI)
+	query := target.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	target.RawQuery = query.Encode()
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
@@ -0,0 +1,135 @@
+package idp
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/megakuul/miam/internal/auth"
+)
+
+func TestGrantVerifyChallenge(t *testing.T) {
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	hash := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+	tests := []struct {
+		name      string
+		challenge string
+		verifier  string
+		want      bool
+	}{
+		{"matching verifier", challenge, verifier, true},
+		{"wrong verifier", challenge, verifier + "x", false},
+		{"missing verifier", challenge, "", false},
+		{"challenge used as verifier", challenge, challenge, false},
+		{"no challenge and no verifier", "", "", true},
+		{"verifier without challenge", "", verifier, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := &grant{Challenge: test.challenge}
+			if got := g.verifyChallenge(test.verifier); got != test.want {
+				t.Fatalf("verifyChallenge = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHandleAuthorizeRejectsInvalidRequests(t *testing.T) {
+	provider, err := New("https://miam.test", time.Hour, []Client{
+		{ID: "web", RedirectURIs: []string{"https://app.test/callback"}},
+		{ID: "backend", SecretHash: "00", RedirectURIs: []string{"https://backend.test/callback"}},
+	}, nil, nil, auth.NewAuthorizer(nil, nil, time.Minute), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		query    url.Values
+		status   int
+		redirect string // expected redirect target without query
+		errCode  string // expected error parameter of the redirect
+	}{
+		{
+			name:   "unknown client",
+			query:  url.Values{"client_id": {"other"}, "redirect_uri": {"https://app.test/callback"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "unregistered redirect uri is not redirected to",
+			query:  url.Values{"client_id": {"web"}, "redirect_uri": {"https://evil.test/callback"}, "response_type": {"code"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "redirect uri prefix is not registered",
+			query:  url.Values{"client_id": {"web"}, "redirect_uri": {"https://app.test/callback/../evil"}, "response_type": {"code"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:     "unsupported response type",
+			query:    url.Values{"client_id": {"web"}, "redirect_uri": {"https://app.test/callback"}, "response_type": {"token"}},
+			status:   http.StatusFound,
+			redirect: "https://app.test/callback",
+			errCode:  "unsupported_response_type",
+		},
+		{
+			name:     "public client without pkce",
+			query:    url.Values{"client_id": {"web"}, "redirect_uri": {"https://app.test/callback"}, "response_type": {"code"}},
+			status:   http.StatusFound,
+			redirect: "https://app.test/callback",
+			errCode:  "invalid_request",
+		},
+		{
+			name: "plain pkce method",
+			query: url.Values{"client_id": {"web"}, "redirect_uri": {"https://app.test/callback"}, "response_type": {"code"},
+				"code_challenge": {"abc"}, "code_challenge_method": {"plain"}},
+			status:   http.StatusFound,
+			redirect: "https://app.test/callback",
+			errCode:  "invalid_request",
+		},
+		{
+			name: "unauthenticated request",
+			query: url.Values{"client_id": {"web"}, "redirect_uri": {"https://app.test/callback"}, "response_type": {"code"},
+				"code_challenge": {"abc"}, "code_challenge_method": {"S256"}},
+			status:   http.StatusFound,
+			redirect: "https://app.test/callback",
+			errCode:  "login_required",
+		},
+		{
+			name:     "confidential client without pkce",
+			query:    url.Values{"client_id": {"backend"}, "redirect_uri": {"https://backend.test/callback"}, "response_type": {"code"}},
+			status:   http.StatusFound,
+			redirect: "https://backend.test/callback",
+			errCode:  "login_required",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			provider.handleAuthorize(w, httptest.NewRequest(http.MethodGet, "/oauth2/authorize?"+test.query.Encode(), nil))
+			if w.Code != test.status {
+				t.Fatalf("status = %d, want %d", w.Code, test.status)
+			}
+			if test.redirect == "" {
+				if location := w.Header().Get("Location"); location != "" {
+					t.Fatalf("redirected to %s", location)
+				}
+				return
+			}
+			location, err := url.Parse(w.Header().Get("Location"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if target := location.Scheme + "://" + location.Host + location.Path; target != test.redirect {
+				t.Fatalf("redirected to %s, want %s", target, test.redirect)
+			}
+			if code := location.Query().Get("error"); code != test.errCode {
+				t.Fatalf("error = %q, want %q", code, test.errCode)
+			}
+		})
+	}
+}
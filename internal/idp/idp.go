@@ -0,0 +1,291 @@
+// Package idp implements an oidc provider issuing tokens for miam identities.
+package idp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/interceptor"
+	"github.com/megakuul/miam/internal/store"
+)
+
+const (
+	// accessTokenType is the jwt type header of access tokens (rfc 9068), it separates them from id tokens.
+	accessTokenType = "at+jwt"
+	// ClientSubjectPrefix prefixes the subject of tokens issued to clients with the client credentials flow.
+	ClientSubjectPrefix = auth.ClientSubjectPrefix
+)
+
+// Client is an oauth2 client that may request tokens from the provider.
+// Clients without secret are public clients and must use the authorization code flow with pkce.
+type Client struct {
+	ID           string   `toml:"id"`
+	SecretHash   string   `toml:"secret_hash" redact:"true"` // hex encoded sha256 of the client secret
+	RedirectURIs []string `toml:"redirect_uris"`
+}
+
+func (c *Client) public() bool {
+	return c.SecretHash == ""
+}
+
+// authenticate checks the secret of confidential clients in constant time.
+func (c *Client) authenticate(secret string) bool {
+	if c.public() {
+		return secret == ""
+	}
+	hash := sha256.Sum256([]byte(secret))
+	return subtle.ConstantTimeCompare([]byte(hex.EncodeToString(hash[:])), []byte(strings.ToLower(c.SecretHash))) == 1
+}
+
+// Provider is an oidc provider issuing tokens for the identities authenticated by the upstream verifiers.
+// Tokens carry the groups and the (scoped) roles bound to the identity. Access tokens are addressed to the issuer itself,
+// so that the operator and the clusters trusting miam accept them; id tokens are addressed to the client.
+type Provider struct {
+	issuer     string
+	prefix     string
+	lifetime   time.Duration
+	clients    map[string]*Client
+	keys       KeyStore
+	state      StateStore
+	authorizer *auth.Authorizer
+	verifiers  []auth.Verifier
+
+	users           *store.Store
+	sessionLifetime time.Duration
+	upstreams       map[string]upstream
+	upstreamConfigs []Upstream
+}
+
+// New creates a provider for the issuer url. Users authenticate at the authorization endpoint with a bearer token
+// accepted by one of the verifiers. Authorization codes and pending logins are kept in the state store,
+// so that they can be redeemed at any replica.
+func New(issuer string, lifetime time.Duration, clients []Client, keys KeyStore, state StateStore, authorizer *auth.Authorizer, verifiers []auth.Verifier) (*Provider, error) {
+	issuerURL, err := url.Parse(issuer)
+	if err != nil || issuerURL.Scheme == "" || issuerURL.Host == "" {
+		return nil, fmt.Errorf("issuer must be an absolute url")
+	}
+	provider := &Provider{
+		issuer:     strings.TrimSuffix(issuer, "/"),
+		prefix:     strings.TrimSuffix(issuerURL.Path, "/"),
+		lifetime:   lifetime,
+		clients:    map[string]*Client{},
+		keys:       keys,
+		state:      state,
+		authorizer: authorizer,
+		verifiers:  verifiers,
+		upstreams:  map[string]upstream{},
+	}
+	for _, client := range clients {
+		if client.ID == "" {
+			return nil, fmt.Errorf("client id must not be empty")
+		}
+		if _, ok := provider.clients[client.ID]; ok {
+			return nil, fmt.Errorf("duplicate client '%s'", client.ID)
+		}
+		provider.clients[client.ID] = &client
+	}
+	return provider, nil
+}
+
+// Register adds the provider endpoints to the mux.
+func (p *Provider) Register(mux *http.ServeMux, limiter *interceptor.RateLimiter) {
+	mux.HandleFunc("GET "+p.prefix+"/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("GET "+p.prefix+"/oauth2/jwks", p.handleJWKS)
+	mux.Handle(p.prefix+"/oauth2/authorize", limiter.Handler(http.HandlerFunc(p.handleAuthorize)))
+	mux.Handle("POST "+p.prefix+"/oauth2/token", limiter.Handler(http.HandlerFunc(p.handleToken)))
+	if len(p.upstreams) > 0 {
+		mux.HandleFunc("GET "+p.prefix+"/login", p.handleChooser)
+		mux.HandleFunc("GET "+p.prefix+"/login/{upstream}", p.handleLogin)
+		mux.HandleFunc(p.prefix+"/login/{upstream}/callback", p.handleCallback)
+		mux.HandleFunc("GET "+p.prefix+"/login/{upstream}/metadata", p.handleMetadata)
+		mux.HandleFunc(p.prefix+"/logout", p.handleLogout)
+	}
+}
+
+func (p *Provider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/oauth2/authorize",
+		"token_endpoint":                        p.issuer + "/oauth2/token",
+		"jwks_uri":                              p.issuer + "/oauth2/jwks",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code", "client_credentials"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{string(signingAlgorithm)},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "email", "groups", "roles"},
+		"claims_supported":                      []string{"iss", "sub", "aud", "exp", "iat", "email", "groups", "roles", "scoped_roles", "nonce"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+	})
+}
+
+func (p *Provider) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	keys, err := p.keys.PublicKeys(r.Context())
+	if err != nil {
+		http.Error(w, "failed to load keys", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, jose.JSONWebKeySet{Keys: keys})
+}
+
+// Verify validates access tokens issued by the provider, so that they can be used against the operator api.
+func (p *Provider) Verify(ctx context.Context, token string) (*auth.Identity, error) {
+	if auth.TokenIssuer(token) != p.issuer {
+		return nil, auth.ErrUnsupportedToken
+	}
+	return p.verify(ctx, token, accessTokenType)
+}
+
+// verify validates a token of the type issued by the provider to itself.
+func (p *Provider) verify(ctx context.Context, token, typ string) (*auth.Identity, error) {
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{signingAlgorithm})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %v", err)
+	}
+	header := parsed.Headers[0]
+	if header.ExtraHeaders[jose.HeaderType] != typ {
+		return nil, fmt.Errorf("token is no %s token", typ)
+	}
+	keys, err := p.keys.PublicKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(keys, func(key jose.JSONWebKey) bool { return key.KeyID == header.KeyID })
+	if index < 0 {
+		return nil, fmt.Errorf("token is signed by an unknown key")
+	}
+	standard, custom := jwt.Claims{}, tokenClaims{}
+	if err := parsed.Claims(keys[index].Key, &standard, &custom); err != nil {
+		return nil, fmt.Errorf("failed to verify token: %v", err)
+	}
+	err = standard.Validate(jwt.Expected{
+		Issuer:      p.issuer,
+		AnyAudience: jwt.Audience{p.issuer},
+		Time:        time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Identity{
+		Issuer:  p.issuer,
+		Subject: standard.Subject,
+		Email:   custom.Email,
+		Groups:  custom.Groups,
+	}, nil
+}
+
+// tokenClaims are the non registered claims of issued tokens.
+// Scoped roles are listed apart from the roles, so that clients do not mistake them for roles on all clusters.
+type tokenClaims struct {
+	Email       string            `json:"email,omitempty"`
+	Groups      []string          `json:"groups,omitempty"`
+	Roles       []string          `json:"roles,omitempty"`
+	ScopedRoles []auth.ScopedRole `json:"scoped_roles,omitempty"`
+	ClientID    string            `json:"client_id,omitempty"`
+	Scope       string            `json:"scope,omitempty"`
+	Nonce       string            `json:"nonce,omitempty"`
+}
+
+// tokenResponse is the successful response of the token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token,omitempty"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// issue signs the tokens for the identity. An id token is only issued if the openid scope was requested.
+func (p *Provider) issue(ctx context.Context, client *Client, identity *auth.Identity, scope, nonce string) (*tokenResponse, error) {
+	groups, roles, scopedRoles, err := p.authorizer.Grants(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	key, err := p.keys.SigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// identities of untrusted issuers keep their qualified principal, so that tokens of the idp cannot be used
+	// to impersonate the miam user of the same name.
+	principal := p.authorizer.Principal(identity)
+	now := time.Now()
+	standard := jwt.Claims{
+		Issuer:    p.issuer,
+		Subject:   principal,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(now.Add(p.lifetime)),
+	}
+	custom := tokenClaims{
+		Groups:      groups,
+		Roles:       roles,
+		ScopedRoles: scopedRoles,
+	}
+	// the email names the identity if present, so it is only claimed if it is the principal.
+	if identity.Email == principal {
+		custom.Email = identity.Email
+	}
+
+	access := standard
+	access.Audience = jwt.Audience{p.issuer}
+	access.ID = rand.Text()
+	accessCustom := custom
+	accessCustom.ClientID, accessCustom.Scope = client.ID, scope
+	accessToken, err := sign(key, accessTokenType, access, accessCustom)
+	if err != nil {
+		return nil, err
+	}
+	response := &tokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(p.lifetime.Seconds()),
+		Scope:       scope,
+	}
+	if slices.Contains(strings.Fields(scope), "openid") {
+		id := standard
+		id.Audience = jwt.Audience{client.ID}
+		idCustom := custom
+		idCustom.Nonce = nonce
+		response.IDToken, err = sign(key, "JWT", id, idCustom)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func sign(key jose.SigningKey, typ jose.ContentType, claims ...any) (string, error) {
+	signer, err := jose.NewSigner(key, (&jose.SignerOptions{}).WithType(typ))
+	if err != nil {
+		return "", fmt.Errorf("failed to create signer: %v", err)
+	}
+	builder := jwt.Signed(signer)
+	for _, c := range claims {
+		builder = builder.Claims(c)
+	}
+	token, err := builder.Serialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %v", err)
+	}
+	return token, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,167 @@
+package idp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/oklog/ulid/v2"
+)
+
+// signingAlgorithm is the algorithm of all tokens issued by the provider.
+const signingAlgorithm = jose.RS256
+
+// KeyStore manages the keys used to sign issued tokens.
+type KeyStore interface {
+	// SigningKey returns the current signing key.
+	SigningKey(ctx context.Context) (jose.SigningKey, error)
+	// PublicKeys returns the public keys of all keys that may have signed tokens which are still valid.
+	PublicKeys(ctx context.Context) ([]jose.JSONWebKey, error)
+}
+
+// localKey is an rsa key generated by the LocalKeyStore, its id is a ulid carrying the creation time.
+type localKey struct {
+	id  string
+	key *rsa.PrivateKey
+}
+
+func (k *localKey) created() time.Time {
+	id, err := ulid.Parse(k.id)
+	if err != nil {
+		return time.Time{}
+	}
+	return ulid.Time(id.Time())
+}
+
+// LocalKeyStore generates rsa signing keys and persists them as pem files in a directory.
+// A new key is generated once the newest key is older than the rotation interval,
+// replaced keys are published until the retention passed and removed afterwards.
+type LocalKeyStore struct {
+	dir       string
+	rotation  time.Duration
+	retention time.Duration
+
+	lock sync.Mutex
+	keys []*localKey // oldest first
+}
+
+// NewLocalKeyStore loads the keys persisted in dir. If dir is empty, keys are only held in memory
+// and tokens issued before a restart can no longer be verified.
+func NewLocalKeyStore(dir string, rotation, retention time.Duration) (*LocalKeyStore, error) {
+	store := &LocalKeyStore{
+		dir:       dir,
+		rotation:  rotation,
+		retention: retention,
+		keys:      []*localKey{},
+	}
+	if dir == "" {
+		return store, nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create key directory: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key: %v", err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode key %s", file)
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %v", file, err)
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("key %s is no rsa key", file)
+		}
+		store.keys = append(store.keys, &localKey{
+			id:  strings.TrimSuffix(filepath.Base(file), ".pem"),
+			key: rsaKey,
+		})
+	}
+	return store, nil
+}
+
+// SigningKey returns the newest key, it is rotated first if it exceeded the rotation interval.
+func (s *LocalKeyStore) SigningKey(ctx context.Context) (jose.SigningKey, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.keys) < 1 || time.Since(s.keys[len(s.keys)-1].created()) > s.rotation {
+		if err := s.rotate(); err != nil {
+			return jose.SigningKey{}, err
+		}
+	}
+	current := s.keys[len(s.keys)-1]
+	return jose.SigningKey{
+		Algorithm: signingAlgorithm,
+		Key:       jose.JSONWebKey{Key: current.key, KeyID: current.id, Algorithm: string(signingAlgorithm)},
+	}, nil
+}
+
+// PublicKeys returns the public keys of the current key and the replaced keys within the retention.
+func (s *LocalKeyStore) PublicKeys(ctx context.Context) ([]jose.JSONWebKey, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := []jose.JSONWebKey{}
+	for _, key := range s.keys {
+		keys = append(keys, jose.JSONWebKey{
+			Key:       key.key.Public(),
+			KeyID:     key.id,
+			Algorithm: string(signingAlgorithm),
+			Use:       "sig",
+		})
+	}
+	return keys, nil
+}
+
+// rotate generates a new key and removes keys that were replaced longer than the retention ago.
+func (s *LocalKeyStore) rotate() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %v", err)
+	}
+	next := &localKey{id: ulid.Make().String(), key: key}
+	if s.dir != "" {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to encode key: %v", err)
+		}
+		data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		if err := os.WriteFile(filepath.Join(s.dir, next.id+".pem"), data, 0600); err != nil {
+			return fmt.Errorf("failed to persist key: %v", err)
+		}
+	}
+	keys := []*localKey{}
+	for i, key := range s.keys {
+		// a key is replaced at the creation of its successor.
+		replaced := next.created()
+		if i+1 < len(s.keys) {
+			replaced = s.keys[i+1].created()
+		}
+		if time.Since(replaced) <= s.retention {
+			keys = append(keys, key)
+		} else if s.dir != "" {
+			os.Remove(filepath.Join(s.dir, key.id+".pem"))
+		}
+	}
+	s.keys = append(keys, next)
+	return nil
+}
@@ -0,0 +1,105 @@
+package idp
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/go-jose/go-jose/v4"
+)
+
+// kmsSignTimeout bounds the kms call of a single signature, go-jose does not pass a context to opaque signers.
+const kmsSignTimeout = 10 * time.Second
+
+// KMSKeyStore signs tokens with asymmetric rsa keys held by aws kms.
+// The first key signs, the remaining keys are only published. Keys are rotated by prepending
+// a new key to the list and dropping the old key once all tokens signed by it expired.
+type KMSKeyStore struct {
+	signer *kmsSigner
+	public []jose.JSONWebKey
+}
+
+// NewKMSKeyStore fetches the public keys of the kms keys. Keys must be RSA_* SIGN_VERIFY keys.
+func NewKMSKeyStore(ctx context.Context, client *kms.Client, keyIds []string) (*KMSKeyStore, error) {
+	if len(keyIds) < 1 {
+		return nil, fmt.Errorf("at least one kms key is required")
+	}
+	store := &KMSKeyStore{public: []jose.JSONWebKey{}}
+	for _, keyId := range keyIds {
+		output, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyId)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch public key of '%s': %v", keyId, err)
+		}
+		if output.KeyUsage != types.KeyUsageTypeSignVerify ||
+			!slices.Contains(output.SigningAlgorithms, types.SigningAlgorithmSpecRsassaPkcs1V15Sha256) {
+			return nil, fmt.Errorf("kms key '%s' does not support %s signatures", keyId, signingAlgorithm)
+		}
+		key, err := x509.ParsePKIXPublicKey(output.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key of '%s': %v", keyId, err)
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("kms key '%s' is no rsa key", keyId)
+		}
+		public := jose.JSONWebKey{
+			Key:       rsaKey,
+			KeyID:     aws.ToString(output.KeyId),
+			Algorithm: string(signingAlgorithm),
+			Use:       "sig",
+		}
+		if store.signer == nil {
+			store.signer = &kmsSigner{client: client, public: public}
+		}
+		store.public = append(store.public, public)
+	}
+	return store, nil
+}
+
+func (s *KMSKeyStore) SigningKey(ctx context.Context) (jose.SigningKey, error) {
+	return jose.SigningKey{Algorithm: signingAlgorithm, Key: s.signer}, nil
+}
+
+func (s *KMSKeyStore) PublicKeys(ctx context.Context) ([]jose.JSONWebKey, error) {
+	return s.public, nil
+}
+
+// kmsSigner implements jose.OpaqueSigner with the kms sign api.
+type kmsSigner struct {
+	client *kms.Client
+	public jose.JSONWebKey
+}
+
+func (s *kmsSigner) Public() *jose.JSONWebKey {
+	return &s.public
+}
+
+func (s *kmsSigner) Algs() []jose.SignatureAlgorithm {
+	return []jose.SignatureAlgorithm{signingAlgorithm}
+}
+
+func (s *kmsSigner) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	if alg != signingAlgorithm {
+		return nil, fmt.Errorf("unsupported signing algorithm %s", alg)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), kmsSignTimeout)
+	defer cancel()
+	digest := sha256.Sum256(payload)
+	output, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.public.KeyID),
+		Message:          digest[:],
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecRsassaPkcs1V15Sha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with kms: %v", err)
+	}
+	return output.Signature, nil
+}
@@ -0,0 +1,347 @@
+package idp
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/iam/v1/user"
+)
+
+const (
+	// sessionTokenType is the jwt type header of session cookies, it separates them from access and id tokens.
+	sessionTokenType = "session+jwt"
+	sessionCookie    = "miam_session"
+	// loginLifetime is the time a user has to complete the login at the upstream provider.
+	loginLifetime = 10 * time.Minute
+)
+
+// Upstream is an identity provider users sign in with before miam issues tokens for them.
+// Users are matched by name with the miam users that are linked to the upstream (see user.User.Upstreams);
+// unknown users are created and linked on their first login if jit is enabled. Oidc logins naming the user by
+// email require the upstream to verify the email.
+// Upstream groups are prefixed with the upstream name (e.g. "corp:admins") and can be mapped to roles.
+type Upstream struct {
+	Name string `toml:"name"`
+	Type string `toml:"type"` // "oidc" or "saml"
+
+	// oidc providers
+	Issuer       string   `toml:"issuer"`
+	ClientID     string   `toml:"client_id"`
+	ClientSecret string   `toml:"client_secret" redact:"true"`
+	Scopes       []string `toml:"scopes"` // defaults to openid, email and profile
+
+	// saml providers, the service provider metadata is served at <issuer>/login/<name>/metadata.
+	// The service provider key is generated on startup if no key pair is configured.
+	IDPMetadataURL string `toml:"idp_metadata_url"`
+	EntityID       string `toml:"entity_id"` // defaults to the service provider metadata url
+	SPKeyFile      string `toml:"sp_key_file"`
+	SPCertFile     string `toml:"sp_cert_file"`
+
+	// claims (oidc) or attributes (saml) carrying the user name, email and groups.
+	// The user name defaults to the email claim (oidc) or the name id (saml).
+	UsernameClaim string `toml:"username_claim"`
+	EmailClaim    string `toml:"email_claim"`
+	GroupsClaim   string `toml:"groups_claim"`
+
+	JIT           bool           `toml:"jit"`
+	GroupMappings []GroupMapping `toml:"group_mappings"`
+}
+
+// GroupMapping grants roles to the members of an upstream group, optionally scoped like auth.Policy.
+type GroupMapping struct {
+	Group    string   `toml:"group"`
+	Roles    []string `toml:"roles"`
+	Clusters []string `toml:"clusters"`
+	Tags     []string `toml:"tags"`
+}
+
+// UpstreamPolicies returns the policies implementing the group mappings of the upstreams.
+func UpstreamPolicies(upstreams []Upstream) []auth.Policy {
+	policies := []auth.Policy{}
+	for _, u := range upstreams {
+		for _, mapping := range u.GroupMappings {
+			for _, role := range mapping.Roles {
+				policies = append(policies, auth.Policy{
+					Subjects: []string{"group:" + u.Name + ":" + mapping.Group},
+					Role:     role,
+					Clusters: mapping.Clusters,
+					Tags:     mapping.Tags,
+				})
+			}
+		}
+	}
+	return policies
+}
+
+// upstream authenticates users at an external identity provider.
+type upstream interface {
+	// redirect returns the url the user is sent to for authentication.
+	redirect(ctx context.Context, state string, pending *login) (string, error)
+	// identity completes the login with the callback request of the provider.
+	identity(r *http.Request, pending *login) (*upstreamIdentity, error)
+}
+
+// upstreamIdentity is the user as asserted by the upstream provider.
+type upstreamIdentity struct {
+	issuer      string
+	username    string
+	email       string
+	displayName string
+	groups      []string
+}
+
+// login is a pending login at an upstream provider, identified by its state.
+type login struct {
+	Upstream  string    `json:"upstream"`
+	ReturnTo  string    `json:"return_to"`
+	Verifier  string    `json:"verifier,omitempty"`   // oidc pkce verifier
+	Nonce     string    `json:"nonce,omitempty"`      // oidc nonce
+	RequestID string    `json:"request_id,omitempty"` // saml authn request id
+	Expires   time.Time `json:"expires"`
+}
+
+// EnableLogin allows users to sign in through the upstreams. Signed in users hold a session cookie
+// that authenticates them at the authorization endpoint.
+func (p *Provider) EnableLogin(ctx context.Context, upstreams []Upstream, users *store.Store, sessionLifetime time.Duration) error {
+	p.users, p.sessionLifetime = users, sessionLifetime
+	for _, config := range upstreams {
+		if config.Name == "" || strings.ContainsAny(config.Name, "/:") {
+			return fmt.Errorf("upstream name must be non-empty and must not contain '/' or ':'")
+		}
+		if _, ok := p.upstreams[config.Name]; ok {
+			return fmt.Errorf("duplicate upstream '%s'", config.Name)
+		}
+		var (
+			u   upstream
+			err error
+		)
+		switch config.Type {
+		case "oidc":
+			u, err = newOIDCUpstream(ctx, config, p.issuer+"/login/"+config.Name+"/callback")
+		case "saml":
+			u, err = newSAMLUpstream(ctx, config, p.issuer+"/login/"+config.Name)
+		default:
+			err = fmt.Errorf("unsupported type '%s'", config.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to initialize upstream '%s': %v", config.Name, err)
+		}
+		p.upstreams[config.Name] = u
+		p.upstreamConfigs = append(p.upstreamConfigs, config)
+	}
+	return nil
+}
+
+var chooserTemplate = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>miam login</title></head>
+<body><h1>Sign in</h1><ul>
+{{range .}}<li><a href="{{.URL}}">{{.Name}}</a></li>{{end}}
+</ul></body></html>
+`))
+
+// handleChooser lists the upstreams the user can sign in with.
+func (p *Provider) handleChooser(w http.ResponseWriter, r *http.Request) {
+	links := []struct{ Name, URL string }{}
+	for _, config := range p.upstreamConfigs {
+		links = append(links, struct{ Name, URL string }{
+			Name: config.Name,
+			URL:  p.prefix + "/login/" + config.Name + "?" + url.Values{"return_to": {r.URL.Query().Get("return_to")}}.Encode(),
+		})
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	chooserTemplate.Execute(w, links)
+}
+
+// handleLogin redirects the user to the upstream provider.
+func (p *Provider) handleLogin(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("upstream")
+	u, ok := p.upstreams[name]
+	if !ok {
+		http.Error(w, "unknown upstream", http.StatusNotFound)
+		return
+	}
+	pending := &login{
+		Upstream: name,
+		ReturnTo: p.localPath(r.URL.Query().Get("return_to")),
+		Expires:  time.Now().Add(loginLifetime),
+	}
+	state := rand.Text()
+	target, err := u.redirect(r.Context(), state, pending)
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusBadGateway)
+		return
+	}
+	if err := p.putState(r.Context(), stateLogin, state, pending, pending.Expires); err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// handleCallback completes the login, provisions the user and starts the session.
+func (p *Provider) handleCallback(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	state := r.Form.Get("state")
+	if state == "" {
+		state = r.Form.Get("RelayState")
+	}
+	pending := &login{}
+	ok := state != "" && p.takeState(r.Context(), stateLogin, state, pending)
+	if !ok || time.Now().After(pending.Expires) || pending.Upstream != r.PathValue("upstream") {
+		http.Error(w, "login expired, please sign in again", http.StatusBadRequest)
+		return
+	}
+	upstreamIdentity, err := p.upstreams[pending.Upstream].identity(r, pending)
+	if err != nil {
+		http.Error(w, "login failed: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+	identity, err := p.provision(r.Context(), pending.Upstream, upstreamIdentity)
+	if err != nil {
+		http.Error(w, "login failed: "+err.Error(), http.StatusForbidden)
+		return
+	}
+	session, err := p.signSession(r.Context(), identity)
+	if err != nil {
+		http.Error(w, "failed to create session", http.StatusInternalServerError)
+		return
+	}
+	p.setSession(w, session, p.sessionLifetime)
+	http.Redirect(w, r, pending.ReturnTo, http.StatusFound)
+}
+
+// provision resolves the miam user of the upstream identity and creates it if jit is enabled.
+func (p *Provider) provision(ctx context.Context, upstreamName string, upstreamIdentity *upstreamIdentity) (*auth.Identity, error) {
+	if upstreamIdentity.username == "" {
+		return nil, errors.New("upstream did not provide a user name")
+	}
+	if auth.Reserved(upstreamIdentity.username) {
+		return nil, fmt.Errorf("user name '%s' uses a reserved form", upstreamIdentity.username)
+	}
+	config := p.upstreamConfigs[slices.IndexFunc(p.upstreamConfigs, func(u Upstream) bool { return u.Name == upstreamName })]
+	u, _, err := p.users.GetUser(ctx, upstreamIdentity.username)
+	if errors.Is(err, store.ErrNotFound) {
+		if !config.JIT {
+			return nil, fmt.Errorf("user '%s' is not known", upstreamIdentity.username)
+		}
+		u, err = p.users.CreateUser(ctx, &user.User{
+			Name:        upstreamIdentity.username,
+			DisplayName: upstreamIdentity.displayName,
+			Email:       upstreamIdentity.email,
+			Upstreams:   []string{upstreamName},
+		})
+		// concurrent first logins of the same user race for the creation.
+		if errors.Is(err, store.ErrAlreadyExists) {
+			u, _, err = p.users.GetUser(ctx, upstreamIdentity.username)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %v", err)
+	}
+	// users of another upstream (or created through the api) are not taken over by an upstream asserting their name.
+	if !slices.Contains(u.GetUpstreams(), upstreamName) {
+		return nil, fmt.Errorf("user '%s' is not linked to upstream '%s'", u.GetName(), upstreamName)
+	}
+	if u.GetDisabled() {
+		return nil, auth.ErrDisabled
+	}
+	// the identity name must resolve to the miam user, therefore the email is only carried if it is the user name.
+	identity := &auth.Identity{
+		Issuer:  upstreamIdentity.issuer,
+		Subject: u.GetName(),
+		Groups:  []string{},
+	}
+	if u.GetEmail() == u.GetName() {
+		identity.Email = u.GetEmail()
+	}
+	for _, group := range upstreamIdentity.groups {
+		identity.Groups = append(identity.Groups, upstreamName+":"+group)
+	}
+	return identity, nil
+}
+
+// handleLogout ends the session.
+func (p *Provider) handleLogout(w http.ResponseWriter, r *http.Request) {
+	p.setSession(w, "", -1)
+	http.Redirect(w, r, p.localPath(r.URL.Query().Get("return_to")), http.StatusFound)
+}
+
+func (p *Provider) setSession(w http.ResponseWriter, value string, lifetime time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    value,
+		Path:     p.prefix + "/",
+		MaxAge:   int(lifetime.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(p.issuer, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// session returns the identity of the session cookie of the request.
+func (p *Provider) session(r *http.Request) (*auth.Identity, bool) {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil || cookie.Value == "" {
+		return nil, false
+	}
+	identity, err := p.verify(r.Context(), cookie.Value, sessionTokenType)
+	if err != nil {
+		return nil, false
+	}
+	return identity, true
+}
+
+// loginURL returns the page the user is sent to if the authorization endpoint requires a login.
+func (p *Provider) loginURL(returnTo string) string {
+	query := "?" + url.Values{"return_to": {returnTo}}.Encode()
+	if len(p.upstreamConfigs) == 1 {
+		return p.prefix + "/login/" + p.upstreamConfigs[0].Name + query
+	}
+	return p.prefix + "/login" + query
+}
+
+// localPath returns the path if it points to the provider itself, so that logins cannot be used as open redirect.
+// Browsers strip control characters from urls (e.g. "/\t/host" becomes "//host"), so paths containing them are rejected.
+func (p *Provider) localPath(path string) string {
+	if !strings.HasPrefix(path, p.prefix+"/") || strings.HasPrefix(path, "//") || strings.Contains(path, `\`) ||
+		strings.ContainsFunc(path, unicode.IsControl) {
+		return p.prefix + "/"
+	}
+	return path
+}
+
+// signSession issues the session token of the identity.
+func (p *Provider) signSession(ctx context.Context, identity *auth.Identity) (string, error) {
+	key, err := p.keys.SigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	return sign(key, sessionTokenType, jwt.Claims{
+		Issuer:    p.issuer,
+		Subject:   identity.Name(),
+		Audience:  jwt.Audience{p.issuer},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(now.Add(p.sessionLifetime)),
+		ID:        rand.Text(),
+	}, tokenClaims{
+		Email:  identity.Email,
+		Groups: identity.Groups,
+	})
+}
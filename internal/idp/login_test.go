@@ -0,0 +1,97 @@
+package idp
+
+import "testing"
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct {
+		prefix string
+		path   string
+		want   string
+	}{
+		{"", "/oauth2/authorize?client_id=web", "/oauth2/authorize?client_id=web"},
+		{"", "", "/"},
+		{"", "https://evil.test/", "/"},
+		{"", "//evil.test/", "/"},
+		{"", `/\evil.test/`, "/"},
+		{"", "/\t/evil.test/", "/"},
+		{"", "/\n/evil.test/", "/"},
+		{"/idp", "/idp/login", "/idp/login"},
+		{"/idp", "/other", "/idp/"},
+		{"/idp", "/idpx/login", "/idp/"},
+		{"/idp", "//idp/login", "/idp/"},
+	}
+	for _, test := range tests {
+		t.Run(test.prefix+" "+test.path, func(t *testing.T) {
+			p := &Provider{prefix: test.prefix}
+			if got := p.localPath(test.path); got != test.want {
+				t.Fatalf("localPath(%q) = %q, want %q", test.path, got, test.want)
+			}
+		})
+	}
+}
+
+func TestOIDCUpstreamIdentityFromClaims(t *testing.T) {
+	byEmail := &oidcUpstream{issuer: "https://corp.test", usernameClaim: "email", emailClaim: "email", groupsClaim: "groups"}
+	byName := &oidcUpstream{issuer: "https://corp.test", usernameClaim: "preferred_username", emailClaim: "email", groupsClaim: "groups"}
+	tests := []struct {
+		name     string
+		upstream *oidcUpstream
+		claims   map[string]any
+		username string
+		email    string
+		groups   int
+		wantErr  bool
+	}{
+		{
+			name:     "verified email names the user",
+			upstream: byEmail,
+			claims:   map[string]any{"email": "alice@corp.test", "email_verified": true, "groups": []any{"admins", "dev"}},
+			username: "alice@corp.test",
+			email:    "alice@corp.test",
+			groups:   2,
+		},
+		{
+			name:     "verified email encoded as string",
+			upstream: byEmail,
+			claims:   map[string]any{"email": "alice@corp.test", "email_verified": "true"},
+			username: "alice@corp.test",
+			email:    "alice@corp.test",
+		},
+		{
+			name:     "unverified email cannot name the user",
+			upstream: byEmail,
+			claims:   map[string]any{"email": "alice@corp.test", "email_verified": false},
+			wantErr:  true,
+		},
+		{
+			name:     "missing verification cannot name the user",
+			upstream: byEmail,
+			claims:   map[string]any{"email": "alice@corp.test"},
+			wantErr:  true,
+		},
+		{
+			name:     "unverified email is dropped",
+			upstream: byName,
+			claims:   map[string]any{"preferred_username": "alice", "email": "alice@corp.test", "groups": "admins"},
+			username: "alice",
+			groups:   1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identity, err := test.upstream.identityFromClaims(test.claims)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("identity %+v was accepted", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identity.username != test.username || identity.email != test.email || len(identity.groups) != test.groups {
+				t.Fatalf("identity = %+v, want username %q, email %q and %d groups", identity, test.username, test.email, test.groups)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package idp
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// oidcUpstream authenticates users with the authorization code flow at an oidc provider.
+type oidcUpstream struct {
+	issuer        string
+	config        oauth2.Config
+	verifier      *oidc.IDTokenVerifier
+	usernameClaim string
+	emailClaim    string
+	groupsClaim   string
+}
+
+func newOIDCUpstream(ctx context.Context, config Upstream, redirectURL string) (*oidcUpstream, error) {
+	if config.Issuer == "" || config.ClientID == "" {
+		return nil, fmt.Errorf("issuer and client_id must be set")
+	}
+	provider, err := oidc.NewProvider(ctx, config.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover provider: %v", err)
+	}
+	scopes := config.Scopes
+	if len(scopes) < 1 {
+		scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	}
+	return &oidcUpstream{
+		issuer: config.Issuer,
+		config: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			Endpoint:     provider.Endpoint(),
+			RedirectURL:  redirectURL,
+			Scopes:       scopes,
+		},
+		verifier:      provider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+		usernameClaim: fallback(config.UsernameClaim, "email"),
+		emailClaim:    fallback(config.EmailClaim, "email"),
+		groupsClaim:   fallback(config.GroupsClaim, "groups"),
+	}, nil
+}
+
+func (u *oidcUpstream) redirect(ctx context.Context, state string, pending *login) (string, error) {
+	pending.Verifier, pending.Nonce = oauth2.GenerateVerifier(), rand.Text()
+	return u.config.AuthCodeURL(state, oidc.Nonce(pending.Nonce), oauth2.S256ChallengeOption(pending.Verifier)), nil
+}
+
+func (u *oidcUpstream) identity(r *http.Request, pending *login) (*upstreamIdentity, error) {
+	if errorCode := r.Form.Get("error"); errorCode != "" {
+		return nil, fmt.Errorf("%s: %s", errorCode, r.Form.Get("error_description"))
+	}
+	token, err := u.config.Exchange(r.Context(), r.Form.Get("code"), oauth2.VerifierOption(pending.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem code: %v", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("provider did not issue an id token")
+	}
+	idToken, err := u.verifier.Verify(r.Context(), rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id token: %v", err)
+	}
+	if idToken.Nonce != pending.Nonce {
+		return nil, fmt.Errorf("id token nonce does not match")
+	}
+	claims := map[string]any{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id token claims: %v", err)
+	}
+	return u.identityFromClaims(claims)
+}
+
+// identityFromClaims maps the id token claims to the user. An unverified email is dropped,
+// logins naming the user by an unverified email are refused as the user may choose it freely.
+func (u *oidcUpstream) identityFromClaims(claims map[string]any) (*upstreamIdentity, error) {
+	identity := &upstreamIdentity{
+		issuer:      u.issuer,
+		username:    stringClaim(claims[u.usernameClaim]),
+		email:       stringClaim(claims[u.emailClaim]),
+		displayName: stringClaim(claims["name"]),
+	}
+	// some providers (e.g. cognito) encode the claim as string.
+	if verified := claims["email_verified"]; verified != true && verified != "true" {
+		if u.usernameClaim == u.emailClaim || u.usernameClaim == "email" {
+			return nil, fmt.Errorf("upstream did not verify the email address")
+		}
+		identity.email = ""
+	}
+	switch groups := claims[u.groupsClaim].(type) {
+	case []any:
+		for _, group := range groups {
+			if name := stringClaim(group); name != "" {
+				identity.groups = append(identity.groups, name)
+			}
+		}
+	case string:
+		identity.groups = []string{groups}
+	}
+	return identity, nil
+}
+
+func stringClaim(claim any) string {
+	value, _ := claim.(string)
+	return value
+}
+
+func fallback(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
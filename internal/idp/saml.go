@@ -0,0 +1,200 @@
+package idp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/crewjam/saml"
+)
+
+// samlUpstream authenticates users with the web browser sso profile at a saml 2.0 identity provider.
+type samlUpstream struct {
+	sp                *saml.ServiceProvider
+	usernameAttribute string
+	emailAttribute    string
+	groupsAttribute   string
+}
+
+// newSAMLUpstream creates the service provider served below the base url.
+func newSAMLUpstream(ctx context.Context, config Upstream, baseURL string) (*samlUpstream, error) {
+	if config.IDPMetadataURL == "" {
+		return nil, fmt.Errorf("idp_metadata_url must be set")
+	}
+	metadata, err := fetchSAMLMetadata(ctx, config.IDPMetadataURL)
+	if err != nil {
+		return nil, err
+	}
+	var keyPair tls.Certificate
+	if config.SPKeyFile != "" || config.SPCertFile != "" {
+		keyPair, err = tls.LoadX509KeyPair(config.SPCertFile, config.SPKeyFile)
+	} else {
+		keyPair, err = selfSignedCertificate(baseURL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load service provider key pair: %v", err)
+	}
+	key, ok := keyPair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("service provider key must be an rsa key")
+	}
+	certificate, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service provider certificate: %v", err)
+	}
+	metadataURL, err := url.Parse(baseURL + "/metadata")
+	if err != nil {
+		return nil, err
+	}
+	acsURL, err := url.Parse(baseURL + "/callback")
+	if err != nil {
+		return nil, err
+	}
+	return &samlUpstream{
+		sp: &saml.ServiceProvider{
+			EntityID:    config.EntityID,
+			Key:         key,
+			Certificate: certificate,
+			MetadataURL: *metadataURL,
+			AcsURL:      *acsURL,
+			IDPMetadata: metadata,
+		},
+		usernameAttribute: config.UsernameClaim,
+		emailAttribute:    fallback(config.EmailClaim, "email"),
+		groupsAttribute:   fallback(config.GroupsClaim, "groups"),
+	}, nil
+}
+
+// fetchSAMLMetadata loads the metadata of the identity provider, aggregates are reduced to their first entity.
+func fetchSAMLMetadata(ctx context.Context, metadataURL string) (*saml.EntityDescriptor, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch idp metadata: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch idp metadata: unexpected status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idp metadata: %v", err)
+	}
+	entity := &saml.EntityDescriptor{}
+	if err := xml.Unmarshal(body, entity); err == nil {
+		return entity, nil
+	}
+	entities := &saml.EntitiesDescriptor{}
+	if err := xml.Unmarshal(body, entities); err != nil || len(entities.EntityDescriptors) < 1 {
+		return nil, fmt.Errorf("failed to parse idp metadata")
+	}
+	return &entities.EntityDescriptors[0], nil
+}
+
+// selfSignedCertificate generates a service provider key pair for the process lifetime.
+// Identity providers that pin the service provider certificate require a configured key pair.
+func selfSignedCertificate(commonName string) (tls.Certificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+func (u *samlUpstream) redirect(ctx context.Context, state string, pending *login) (string, error) {
+	request, err := u.sp.MakeAuthenticationRequest(
+		u.sp.GetSSOBindingLocation(saml.HTTPRedirectBinding), saml.HTTPRedirectBinding, saml.HTTPPostBinding,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create authentication request: %v", err)
+	}
+	pending.RequestID = request.ID
+	target, err := request.Redirect(state, u.sp)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode authentication request: %v", err)
+	}
+	return target.String(), nil
+}
+
+func (u *samlUpstream) identity(r *http.Request, pending *login) (*upstreamIdentity, error) {
+	assertion, err := u.sp.ParseResponse(r, []string{pending.RequestID})
+	if err != nil {
+		return nil, fmt.Errorf("invalid saml response")
+	}
+	identity := &upstreamIdentity{issuer: u.sp.IDPMetadata.EntityID}
+	if u.usernameAttribute == "" && assertion.Subject != nil && assertion.Subject.NameID != nil {
+		identity.username = assertion.Subject.NameID.Value
+	}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			values := []string{}
+			for _, value := range attribute.Values {
+				values = append(values, value.Value)
+			}
+			if len(values) < 1 {
+				continue
+			}
+			switch {
+			case u.usernameAttribute != "" && matchesAttribute(attribute, u.usernameAttribute):
+				identity.username = values[0]
+			case matchesAttribute(attribute, u.emailAttribute):
+				identity.email = values[0]
+			case matchesAttribute(attribute, "displayName"):
+				identity.displayName = values[0]
+			case matchesAttribute(attribute, u.groupsAttribute):
+				identity.groups = append(identity.groups, values...)
+			}
+		}
+	}
+	return identity, nil
+}
+
+func matchesAttribute(attribute saml.Attribute, name string) bool {
+	return attribute.Name == name || attribute.FriendlyName == name
+}
+
+// handleMetadata serves the service provider metadata of saml upstreams.
+func (p *Provider) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	u, ok := p.upstreams[r.PathValue("upstream")].(*samlUpstream)
+	if !ok {
+		http.Error(w, "unknown saml upstream", http.StatusNotFound)
+		return
+	}
+	metadata, err := xml.MarshalIndent(u.sp.Metadata(), "", "  ")
+	if err != nil {
+		http.Error(w, "failed to encode metadata", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.Write(metadata)
+}
@@ -0,0 +1,47 @@
+package idp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/megakuul/miam/internal/store"
+)
+
+const (
+	stateCode  = "code"
+	stateLogin = "login"
+)
+
+// StateStore keeps the short-lived state of the provider (authorization codes and pending logins) until it expires.
+// Taken state is removed, so that it can only be used once.
+type StateStore interface {
+	PutIDPState(ctx context.Context, kind, id string, state []byte, expiry time.Time) error
+	TakeIDPState(ctx context.Context, kind, id string) ([]byte, error)
+}
+
+func (p *Provider) putState(ctx context.Context, kind, id string, value any, expiry time.Time) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := p.state.PutIDPState(ctx, kind, id, raw, expiry); err != nil {
+		slog.ErrorContext(ctx, "failed to store idp state", "kind", kind, "error", err)
+		return err
+	}
+	return nil
+}
+
+// takeState removes the state and decodes it into value, reports false if it does not exist.
+func (p *Provider) takeState(ctx context.Context, kind, id string, value any) bool {
+	raw, err := p.state.TakeIDPState(ctx, kind, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			slog.ErrorContext(ctx, "failed to load idp state", "kind", kind, "error", err)
+		}
+		return false
+	}
+	return json.Unmarshal(raw, value) == nil
+}
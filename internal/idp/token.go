@@ -0,0 +1,73 @@
+package idp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/megakuul/miam/internal/auth"
+)
+
+// handleToken implements the token endpoint for the authorization code and client credentials grants.
+func (p *Provider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request", "failed to parse form")
+		return
+	}
+	clientID, secret, ok := r.BasicAuth()
+	if !ok {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	client, ok := p.clients[clientID]
+	if !ok || !client.authenticate(secret) {
+		tokenError(w, http.StatusUnauthorized, "invalid_client", "client authentication failed")
+		return
+	}
+
+	var (
+		response *tokenResponse
+		err      error
+	)
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		g, ok := p.redeem(r.Context(), r.PostForm.Get("code"))
+		if !ok || g.ClientID != client.ID || g.RedirectURI != r.PostForm.Get("redirect_uri") {
+			tokenError(w, http.StatusBadRequest, "invalid_grant", "invalid or expired authorization code")
+			return
+		}
+		if !g.verifyChallenge(r.PostForm.Get("code_verifier")) {
+			tokenError(w, http.StatusBadRequest, "invalid_grant", "code verifier does not match the challenge")
+			return
+		}
+		response, err = p.issue(r.Context(), client, g.Identity, g.Scope, g.Nonce)
+	case "client_credentials":
+		if client.public() {
+			tokenError(w, http.StatusUnauthorized, "unauthorized_client", "public clients cannot use client credentials")
+			return
+		}
+		response, err = p.issue(r.Context(), client, &auth.Identity{
+			Issuer:  p.issuer,
+			Subject: ClientSubjectPrefix + client.ID,
+		}, r.PostForm.Get("scope"), "")
+	default:
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code and client_credentials are supported")
+		return
+	}
+	if errors.Is(err, auth.ErrDisabled) {
+		tokenError(w, http.StatusBadRequest, "invalid_grant", err.Error())
+		return
+	} else if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error", "failed to issue token")
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func tokenError(w http.ResponseWriter, status int, code, description string) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="miam"`)
+	}
+	writeJSON(w, status, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
@@ -0,0 +1,87 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/pkg/api/iam/v1/group/groupconnect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/role/roleconnect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/serviceaccount/serviceaccountconnect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/user/userconnect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator/operatorconnect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// auditedProcedures lists the procedures that change infrastructure or permissions and are therefore recorded.
+var auditedProcedures = map[string]bool{
+	clusterconnect.ClusterServiceUpdateProcedure:                  true,
+	clusterconnect.ClusterServiceDestroyProcedure:                 true,
+	clusterconnect.ClusterServiceCancelProcedure:                  true,
+	clusterconnect.ClusterServicePruneProcedure:                   true,
+	operatorconnect.MaintenanceServiceUpdateProcedure:             true,
+	operatorconnect.MaintenanceServiceDestroyProcedure:            true,
+	operatorconnect.MaintenanceServiceCancelProcedure:             true,
+	operatorconnect.MaintenanceServicePruneProcedure:              true,
+	userconnect.UserServiceCreateProcedure:                        true,
+	userconnect.UserServiceUpdateProcedure:                        true,
+	userconnect.UserServiceDeleteProcedure:                        true,
+	groupconnect.GroupServiceCreateProcedure:                      true,
+	groupconnect.GroupServiceUpdateProcedure:                      true,
+	groupconnect.GroupServiceDeleteProcedure:                      true,
+	groupconnect.GroupServiceAddMemberProcedure:                   true,
+	groupconnect.GroupServiceRemoveMemberProcedure:                true,
+	roleconnect.RoleServiceCreateProcedure:                        true,
+	roleconnect.RoleServiceUpdateProcedure:                        true,
+	roleconnect.RoleServiceDeleteProcedure:                        true,
+	roleconnect.RoleServiceCreateBindingProcedure:                 true,
+	roleconnect.RoleServiceDeleteBindingProcedure:                 true,
+	serviceaccountconnect.ServiceAccountServiceCreateProcedure:    true,
+	serviceaccountconnect.ServiceAccountServiceUpdateProcedure:    true,
+	serviceaccountconnect.ServiceAccountServiceDeleteProcedure:    true,
+	serviceaccountconnect.ServiceAccountServiceCreateKeyProcedure: true,
+	serviceaccountconnect.ServiceAccountServiceRevokeKeyProcedure: true,
+}
+
+// AuditRecorder appends an entry to the audit log.
+type AuditRecorder func(ctx context.Context, entry *audit.AuditEntry) error
+
+// NewAuditInterceptor records an audit entry for every call of a mutating procedure, including rejected calls.
+// Must run after the auth interceptor to attribute the entries to the caller.
+func NewAuditInterceptor(record AuditRecorder) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient || !auditedProcedures[req.Spec().Procedure] {
+				return next(ctx, req)
+			}
+			entry := &audit.AuditEntry{
+				Time:      timestamppb.Now(),
+				Actor:     auth.PrincipalFrom(ctx, req.Peer().Addr),
+				Procedure: req.Spec().Procedure,
+				Code:      "ok",
+			}
+			if msg, ok := req.Any().(proto.Message); ok {
+				raw, _ := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+				digest := sha256.Sum256(raw)
+				entry.RequestDigest = hex.EncodeToString(digest[:])
+			}
+			resp, err := next(ctx, req)
+			if err != nil {
+				entry.Code = connect.CodeOf(err).String()
+			} else if produced, ok := resp.Any().(interface{ GetRevision() string }); ok {
+				entry.Revision = produced.GetRevision()
+			}
+			// the call already took effect, therefore a failed audit write must not fail the call.
+			if auditErr := record(context.WithoutCancel(ctx), entry); auditErr != nil {
+				slog.ErrorContext(ctx, "failed to record audit entry", "procedure", entry.Procedure, "error", auditErr)
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+)
+
+func TestAuditInterceptor(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		entries []*audit.AuditEntry
+		failing bool
+	)
+	record := func(ctx context.Context, entry *audit.AuditEntry) error {
+		lock.Lock()
+		defer lock.Unlock()
+		if failing {
+			return errors.New("audit table unavailable")
+		}
+		entries = append(entries, entry)
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(clusterconnect.NewClusterServiceHandler(&fakeClusterService{}, connect.WithInterceptors(
+		testIdentityInterceptor(), NewAuditInterceptor(record),
+	)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := clusterconnect.NewClusterServiceClient(server.Client(), server.URL)
+
+	tests := []struct {
+		name         string
+		caller       string
+		issuer       string
+		message      string
+		get          bool
+		failing      bool
+		wantEntry    bool
+		wantActor    string // prefix of the recorded actor
+		wantCode     string
+		wantRevision string
+	}{
+		{name: "unauthenticated caller is recorded by address", message: "a", wantEntry: true, wantActor: "127.0.0.1:", wantCode: "ok", wantRevision: "rev-1"},
+		{name: "caller of trusted issuer", caller: "bob", message: "a", wantEntry: true, wantActor: "bob", wantCode: "ok", wantRevision: "rev-2"},
+		{name: "caller of untrusted issuer is qualified", caller: "bob", issuer: "https://other.test", message: "a", wantEntry: true, wantActor: "https://other.test#bob", wantCode: "ok", wantRevision: "rev-3"},
+		{name: "failed call", caller: "bob", message: "fail", wantEntry: true, wantActor: "bob", wantCode: "internal"},
+		{name: "reading call is not recorded", caller: "bob", get: true},
+		{name: "failed audit write does not fail the call", caller: "bob", message: "a", failing: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lock.Lock()
+			entries, failing = nil, test.failing
+			lock.Unlock()
+			authenticate := func(header http.Header) {
+				if test.caller != "" {
+					header.Set("X-Caller", test.caller)
+				}
+				if test.issuer != "" {
+					header.Set("X-Issuer", test.issuer)
+				}
+			}
+			if test.get {
+				req := connect.NewRequest(&cluster.GetRequest{Name: "prod"})
+				authenticate(req.Header())
+				client.Get(context.Background(), req)
+			} else {
+				req := connect.NewRequest(&cluster.UpdateRequest{Message: test.message})
+				authenticate(req.Header())
+				_, err := client.Update(context.Background(), req)
+				if (err != nil) != (test.message == "fail") {
+					t.Fatalf("unexpected update result: %v", err)
+				}
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			if !test.wantEntry {
+				if len(entries) > 0 {
+					t.Fatalf("recorded %d entries, want none", len(entries))
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Fatalf("recorded %d entries, want 1", len(entries))
+			}
+			entry := entries[0]
+			if !strings.HasPrefix(entry.GetActor(), test.wantActor) {
+				t.Fatalf("actor = %q, want %q", entry.GetActor(), test.wantActor)
+			}
+			if entry.GetProcedure() != clusterconnect.ClusterServiceUpdateProcedure {
+				t.Fatalf("procedure = %q, want %q", entry.GetProcedure(), clusterconnect.ClusterServiceUpdateProcedure)
+			}
+			if entry.GetCode() != test.wantCode {
+				t.Fatalf("code = %q, want %q", entry.GetCode(), test.wantCode)
+			}
+			if entry.GetRevision() != test.wantRevision {
+				t.Fatalf("revision = %q, want %q", entry.GetRevision(), test.wantRevision)
+			}
+			if entry.GetRequestDigest() == "" || entry.GetTime() == nil {
+				t.Fatal("entry has no request digest or time")
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+)
+
+// NewAuthInterceptor rejects requests without a bearer token accepted by one of the verifiers with Unauthenticated.
+// The identity of the caller and its principal resolved by the authorizer are placed in the request context
+// (see auth.IdentityFrom and auth.PrincipalFrom).
+func NewAuthInterceptor(authorizer *auth.Authorizer, verifiers ...auth.Verifier) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			scheme, token, _ := strings.Cut(req.Header().Get("Authorization"), " ")
+			if !strings.EqualFold(scheme, "Bearer") || token == "" {
+				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("missing bearer token"))
+			}
+			identity, err := auth.Authenticate(ctx, verifiers, token)
+			if errors.Is(err, auth.ErrUnsupportedToken) {
+				return nil, connect.NewError(connect.CodeUnauthenticated, err)
+			} else if err != nil {
+				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid bearer token: %v", err))
+			}
+			ctx = auth.WithPrincipal(auth.WithIdentity(ctx, identity), authorizer.Principal(identity))
+			return next(ctx, req)
+		}
+	}
+}
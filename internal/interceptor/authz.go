@@ -0,0 +1,137 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+)
+
+// TagResolver returns the tags of the latest revision of a cluster (nil if the cluster does not exist).
+type TagResolver func(ctx context.Context, name string) ([]string, error)
+
+// NewAuthzInterceptor rejects requests whose caller is not granted the procedure on the targeted cluster
+// with PermissionDenied. Must run after the auth interceptor.
+// Cluster lists of callers with scoped policies are reduced to the clusters they may view.
+func NewAuthzInterceptor(authorizer *auth.Authorizer, resolveTags TagResolver) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			identity, ok := auth.IdentityFrom(ctx)
+			if !ok {
+				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("request is not authenticated"))
+			}
+			procedure := req.Spec().Procedure
+			denied := connect.NewError(connect.CodePermissionDenied, fmt.Errorf(
+				"'%s' is not permitted to call %s", identity.Name(), procedure,
+			))
+
+			if procedure == clusterconnect.ClusterServiceListProcedure {
+				return authorizeList(ctx, authorizer, identity, req, next, denied)
+			}
+			resources, err := resources(ctx, req.Any(), resolveTags)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve cluster: %v", err))
+			}
+			for _, resource := range resources {
+				allowed, err := authorizer.Allowed(ctx, identity, procedure, resource)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize: %v", err))
+				}
+				if !allowed {
+					return nil, denied
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// defaultListPageSize is the page size of cluster listings that do not specify one, it matches the cluster service.
+const defaultListPageSize = 100
+
+// authorizeList calls the list procedure and removes the clusters the identity may not view.
+// Pages are fetched until the requested page size is reached, so that hidden clusters do not shorten the page.
+// Subsequent pages are requested with the remaining size, therefore the token of the last page continues
+// the listing right after the last returned cluster.
+func authorizeList(ctx context.Context, authorizer *auth.Authorizer, identity *auth.Identity, req connect.AnyRequest, next connect.UnaryFunc, denied error) (connect.AnyResponse, error) {
+	procedure := req.Spec().Procedure
+	allowed, err := authorizer.AllowedAny(ctx, identity, procedure)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize: %v", err))
+	}
+	if !allowed {
+		return nil, denied
+	}
+	listReq := req.Any().(*cluster.ListRequest)
+	pageSize := int(listReq.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	visible := []*cluster.ClusterStatus{}
+	for {
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		list := resp.Any().(*cluster.ListResponse)
+		for _, status := range list.GetClusters() {
+			resource := &auth.Resource{Cluster: status.GetName(), Tags: status.GetTags()}
+			allowed, err := authorizer.Allowed(ctx, identity, procedure, resource)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize: %v", err))
+			}
+			if allowed {
+				visible = append(visible, status)
+			}
+		}
+		if len(visible) >= pageSize || list.GetNextPageToken() == "" {
+			list.Clusters = visible
+			return resp, nil
+		}
+		listReq.PageSize = int32(pageSize - len(visible))
+		listReq.PageToken = list.GetNextPageToken()
+	}
+}
+
+// resources returns the resources a request operates on. Updates must be permitted on the current
+// cluster and on the requested config, so that scoped callers cannot move clusters out of their scope.
+func resources(ctx context.Context, msg any, resolveTags TagResolver) ([]*auth.Resource, error) {
+	var (
+		name  string
+		extra *auth.Resource
+	)
+	switch m := msg.(type) {
+	case *cluster.UpdateRequest:
+		name = m.GetConfig().GetName()
+		extra = &auth.Resource{Cluster: name, Tags: m.GetConfig().GetTags()}
+	case *cluster.GetRequest:
+		name = m.GetName()
+	case *cluster.DescribeRequest:
+		name = m.GetName()
+	case *cluster.DestroyRequest:
+		name = m.GetName()
+	case *cluster.CancelRequest:
+		name = m.GetName()
+	case *cluster.PruneRequest:
+		name = m.GetName()
+	}
+	if name == "" {
+		// requests without cluster (e.g. maintenance or pruning all clusters) are global.
+		return []*auth.Resource{nil}, nil
+	}
+	tags, err := resolveTags(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	resources := []*auth.Resource{{Cluster: name, Tags: tags}}
+	if extra != nil {
+		resources = append(resources, extra)
+	}
+	return resources, nil
+}
@@ -0,0 +1,88 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+)
+
+// fakeListService lists the clusters in pages, its page tokens are the index of the next cluster.
+type fakeListService struct {
+	clusterconnect.UnimplementedClusterServiceHandler
+	names []string
+}
+
+func (s *fakeListService) List(ctx context.Context, req *connect.Request[cluster.ListRequest]) (*connect.Response[cluster.ListResponse], error) {
+	start, _ := strconv.Atoi(req.Msg.GetPageToken())
+	end := min(start+int(req.Msg.GetPageSize()), len(s.names))
+	if req.Msg.GetPageSize() == 0 {
+		end = len(s.names)
+	}
+	resp := &cluster.ListResponse{}
+	for _, name := range s.names[start:end] {
+		resp.Clusters = append(resp.Clusters, &cluster.ClusterStatus{Name: name})
+	}
+	if end < len(s.names) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func TestAuthorizeListFillsPages(t *testing.T) {
+	authorizer := auth.NewAuthorizer([]auth.Policy{
+		{Subjects: []string{"bob"}, Role: auth.RoleViewer, Clusters: []string{"prod-*"}},
+	}, nil, 0)
+	service := &fakeListService{names: []string{
+		"dev-1", "dev-2", "dev-3", "prod-1", "dev-4", "prod-2", "dev-5", "dev-6", "prod-3", "test-1",
+	}}
+	mux := http.NewServeMux()
+	mux.Handle(clusterconnect.NewClusterServiceHandler(service, connect.WithInterceptors(
+		testIdentityInterceptor(), NewAuthzInterceptor(authorizer, nil),
+	)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := clusterconnect.NewClusterServiceClient(server.Client(), server.URL)
+
+	tests := []struct {
+		name      string
+		pageSize  int32
+		wantPages [][]string
+	}{
+		{name: "default page size", wantPages: [][]string{{"prod-1", "prod-2", "prod-3"}}},
+		{name: "pages are full", pageSize: 2, wantPages: [][]string{{"prod-1", "prod-2"}, {"prod-3"}}},
+		{name: "single cluster pages", pageSize: 1, wantPages: [][]string{{"prod-1"}, {"prod-2"}, {"prod-3"}, {}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pages, token := [][]string{}, ""
+			for {
+				req := connect.NewRequest(&cluster.ListRequest{PageSize: test.pageSize, PageToken: token})
+				req.Header().Set("X-Caller", "bob")
+				resp, err := client.List(context.Background(), req)
+				if err != nil {
+					t.Fatalf("page %d: %v", len(pages), err)
+				}
+				page := []string{}
+				for _, c := range resp.Msg.GetClusters() {
+					page = append(page, c.GetName())
+				}
+				pages = append(pages, page)
+				if resp.Msg.GetNextPageToken() == "" {
+					break
+				}
+				token = resp.Msg.GetNextPageToken()
+			}
+			if !slices.EqualFunc(pages, test.wantPages, slices.Equal) {
+				t.Fatalf("pages = %v, want %v", pages, test.wantPages)
+			}
+		})
+	}
+}
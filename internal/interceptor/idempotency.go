@@ -0,0 +1,144 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator/operatorconnect"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client chosen key identifying a request across retries.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from an earlier request with the same key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// idempotencyLease bounds the time a request holds its key before it is completed,
+	// so that keys of requests lost in a crash can be retried.
+	idempotencyLease = time.Minute
+)
+
+// idempotencyKeyPattern restricts keys to printable ascii, uuids or random tokens are recommended.
+var idempotencyKeyPattern = regexp.MustCompile(`^[\x21-\x7e]{1,255}$`)
+
+// idempotentProcedures lists the procedures honoring idempotency keys with the decoder of their response.
+var idempotentProcedures = map[string]func(raw []byte) (connect.AnyResponse, error){
+	clusterconnect.ClusterServiceUpdateProcedure:       replay[cluster.UpdateResponse],
+	clusterconnect.ClusterServiceDestroyProcedure:      replay[cluster.DestroyResponse],
+	operatorconnect.MaintenanceServiceUpdateProcedure:  replay[operator.UpdateResponse],
+	operatorconnect.MaintenanceServiceDestroyProcedure: replay[operator.DestroyResponse],
+}
+
+func replay[T any, PT interface {
+	*T
+	proto.Message
+}](raw []byte) (connect.AnyResponse, error) {
+	msg := PT(new(T))
+	if err := proto.Unmarshal(raw, msg); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse((*T)(msg)), nil
+}
+
+// NewIdempotencyInterceptor stores the first successful response of requests with an idempotency key for the ttl
+// and replays it to retries with the same key instead of calling the procedure again. Keys are scoped to the caller
+// (its principal or, if unauthenticated, its address) and procedure, reusing a key for a different request fails
+// with InvalidArgument. Failed requests release their key.
+// Must run after the auth and authz interceptors, so that only permitted callers can replay responses.
+func NewIdempotencyInterceptor(s *store.Store, ttl time.Duration) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			decode, ok := idempotentProcedures[req.Spec().Procedure]
+			if req.Spec().IsClient || !ok || req.Header().Get(IdempotencyKeyHeader) == "" {
+				return next(ctx, req)
+			}
+			key := req.Header().Get(IdempotencyKeyHeader)
+			if !idempotencyKeyPattern.MatchString(key) {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf(
+					"%s must consist of 1 to 255 printable ascii characters", IdempotencyKeyHeader,
+				))
+			}
+			msg, ok := req.Any().(proto.Message)
+			if !ok {
+				return next(ctx, req)
+			}
+			raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to serialize request: %v", err))
+			}
+			requestDigest := sha256.Sum256(raw)
+			digest := hex.EncodeToString(requestDigest[:])
+			// anonymous callers are scoped by their address, which must not collide with the principal of an identity.
+			// identities are scoped by their principal, so that callers of different issuers with the same name are apart.
+			caller := "address:" + peerHost(req)
+			if _, ok := auth.IdentityFrom(ctx); ok {
+				caller = "principal:" + auth.PrincipalFrom(ctx, "")
+			}
+			scope := sha256.Sum256([]byte(caller + "\x00" + req.Spec().Procedure + "\x00" + key))
+			id := hex.EncodeToString(scope[:])
+
+			claim := rand.Text()
+			err = s.ClaimIdempotencyKey(ctx, id, claim, digest, time.Now().Add(idempotencyLease))
+			if errors.Is(err, store.ErrAlreadyExists) {
+				return replayIdempotent(ctx, s, id, digest, decode)
+			} else if err != nil {
+				return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to claim idempotency key: %v", err))
+			}
+
+			resp, err := next(ctx, req)
+			// the call already took effect, the key is therefore settled even if the request was cancelled meanwhile.
+			settleCtx := context.WithoutCancel(ctx)
+			if err != nil {
+				if releaseErr := s.ReleaseIdempotencyKey(settleCtx, id, claim); releaseErr != nil {
+					slog.WarnContext(ctx, "failed to release idempotency key", "error", releaseErr)
+				}
+				return nil, err
+			}
+			rawResp, err := proto.Marshal(resp.Any().(proto.Message))
+			if err == nil {
+				err = s.CompleteIdempotencyKey(settleCtx, id, claim, rawResp, time.Now().Add(ttl))
+			}
+			if err != nil {
+				// the response is returned regardless, retries with the key are rejected until the lease expires.
+				slog.WarnContext(ctx, "failed to record idempotent response", "error", err)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// replayIdempotent returns the stored response of the key if the request finished.
+func replayIdempotent(ctx context.Context, s *store.Store, id, digest string, decode func([]byte) (connect.AnyResponse, error)) (connect.AnyResponse, error) {
+	record, err := s.GetIdempotencyKey(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		// the claim expired or was released between the claim attempt and the lookup.
+		return nil, connect.NewError(connect.CodeAborted, fmt.Errorf("request with this %s was released, retry the request", IdempotencyKeyHeader))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to load idempotency key: %v", err))
+	}
+	if record.Digest != digest {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%s was already used for a different request", IdempotencyKeyHeader))
+	}
+	if !record.Completed {
+		return nil, connect.NewError(connect.CodeAborted, fmt.Errorf("request with this %s is still in progress", IdempotencyKeyHeader))
+	}
+	resp, err := decode(record.Response)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to decode stored response: %v", err))
+	}
+	resp.Header().Set(IdempotentReplayedHeader, "true")
+	return resp, nil
+}
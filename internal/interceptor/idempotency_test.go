@@ -0,0 +1,222 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+)
+
+type attributes = map[string]map[string]any
+
+// fakeDynamo implements the item operations of the dynamodb json protocol used by the idempotency records.
+// Conditions are evaluated only in the forms the store uses for them.
+type fakeDynamo struct {
+	lock  sync.Mutex
+	items map[string]attributes
+}
+
+func (f *fakeDynamo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Item                      attributes
+		Key                       attributes
+		UpdateExpression          string
+		ConditionExpression       string
+		ExpressionAttributeNames  map[string]string
+		ExpressionAttributeValues attributes
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	keyAttrs := input.Key
+	if keyAttrs == nil {
+		keyAttrs = input.Item
+	}
+	key := fmt.Sprint(keyAttrs["name"]["S"], "/", keyAttrs["revision"]["S"])
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	item, exists := f.items[key]
+	if !f.condition(item, exists, input.ConditionExpression, input.ExpressionAttributeValues) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"__type":  "com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException",
+			"message": "The conditional request failed",
+		})
+		return
+	}
+	output := map[string]any{}
+	switch op := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "DynamoDB_20120810."); op {
+	case "PutItem":
+		f.items[key] = input.Item
+	case "GetItem":
+		if exists {
+			output["Item"] = item
+		}
+	case "UpdateItem":
+		if !exists {
+			item = attributes{"name": keyAttrs["name"], "revision": keyAttrs["revision"]}
+			f.items[key] = item
+		}
+		for _, assignment := range strings.Split(strings.TrimPrefix(input.UpdateExpression, "SET "), ", ") {
+			name, value, _ := strings.Cut(assignment, " = ")
+			item[input.ExpressionAttributeNames[name]] = input.ExpressionAttributeValues[value]
+		}
+	case "DeleteItem":
+		delete(f.items, key)
+	default:
+		http.Error(w, "unsupported operation "+op, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (f *fakeDynamo) condition(item attributes, exists bool, expression string, values attributes) bool {
+	switch {
+	case expression == "":
+		return true
+	case strings.HasPrefix(expression, "attribute_not_exists"):
+		if !exists {
+			return true
+		}
+		expiry, _ := strconv.ParseInt(fmt.Sprint(item[store.ExpiryAttribute]["N"]), 10, 64)
+		now, _ := strconv.ParseInt(fmt.Sprint(values[":now"]["N"]), 10, 64)
+		return expiry < now
+	case expression == "#claim = :claim":
+		return exists && item["claim"]["S"] == values[":claim"]["S"]
+	default:
+		return false
+	}
+}
+
+// fakeClusterService counts the updates, an update with the message "fail" fails.
+type fakeClusterService struct {
+	clusterconnect.UnimplementedClusterServiceHandler
+	lock  sync.Mutex
+	calls int
+}
+
+func (s *fakeClusterService) Update(ctx context.Context, req *connect.Request[cluster.UpdateRequest]) (*connect.Response[cluster.UpdateResponse], error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.calls++
+	if req.Msg.Message == "fail" {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("update failed"))
+	}
+	return connect.NewResponse(&cluster.UpdateResponse{Revision: fmt.Sprintf("rev-%d", s.calls)}), nil
+}
+
+func (s *fakeClusterService) count() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.calls
+}
+
+// testIdentityInterceptor authenticates requests as the subject in the X-Caller header,
+// issued by the untrusted issuer in the X-Issuer header if set.
+func testIdentityInterceptor() connect.UnaryInterceptorFunc {
+	authorizer := auth.NewAuthorizer(nil, nil, 0)
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if caller := req.Header().Get("X-Caller"); caller != "" {
+				identity := &auth.Identity{Issuer: req.Header().Get("X-Issuer"), Subject: caller}
+				ctx = auth.WithPrincipal(auth.WithIdentity(ctx, identity), authorizer.Principal(identity))
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	dynamo := httptest.NewServer(&fakeDynamo{items: map[string]attributes{}})
+	defer dynamo.Close()
+	s := store.New(dynamodb.New(dynamodb.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(dynamo.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	}), "test", store.Tables{Cluster: "test-cluster"})
+
+	service := &fakeClusterService{}
+	mux := http.NewServeMux()
+	mux.Handle(clusterconnect.NewClusterServiceHandler(service, connect.WithInterceptors(
+		testIdentityInterceptor(), NewIdempotencyInterceptor(s, time.Hour),
+	)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := clusterconnect.NewClusterServiceClient(server.Client(), server.URL)
+
+	// the steps run in order against the same store.
+	tests := []struct {
+		name     string
+		key      string
+		caller   string
+		issuer   string
+		message  string
+		code     connect.Code
+		revision string
+		replayed bool
+		calls    int
+	}{
+		{name: "without key", message: "a", revision: "rev-1", calls: 1},
+		{name: "first request with key", key: "k1", message: "a", revision: "rev-2", calls: 2},
+		{name: "retry is replayed", key: "k1", message: "a", revision: "rev-2", replayed: true, calls: 2},
+		{name: "key reused for different request", key: "k1", message: "b", code: connect.CodeInvalidArgument, calls: 2},
+		{name: "key is scoped to the caller", key: "k1", caller: "bob", message: "b", revision: "rev-3", calls: 3},
+		{name: "identity is scoped apart from address", key: "k1", caller: "127.0.0.1", message: "a", revision: "rev-4", calls: 4},
+		{name: "key is scoped to the issuer", key: "k1", caller: "bob", issuer: "https://other.test", message: "b", revision: "rev-5", calls: 5},
+		{name: "failed request", key: "k2", message: "fail", code: connect.CodeInternal, calls: 6},
+		{name: "failed request released its key", key: "k2", message: "fail", code: connect.CodeInternal, calls: 7},
+		{name: "invalid key", key: "not a key", message: "a", code: connect.CodeInvalidArgument, calls: 7},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := connect.NewRequest(&cluster.UpdateRequest{Message: test.message})
+			if test.key != "" {
+				req.Header().Set(IdempotencyKeyHeader, test.key)
+			}
+			if test.caller != "" {
+				req.Header().Set("X-Caller", test.caller)
+			}
+			if test.issuer != "" {
+				req.Header().Set("X-Issuer", test.issuer)
+			}
+			resp, err := client.Update(context.Background(), req)
+			if test.code != 0 {
+				if code := connect.CodeOf(err); code != test.code {
+					t.Fatalf("code = %v, want %v (%v)", code, test.code, err)
+				}
+			} else if err != nil {
+				t.Fatalf("update failed: %v", err)
+			} else {
+				if resp.Msg.Revision != test.revision {
+					t.Fatalf("revision = %q, want %q", resp.Msg.Revision, test.revision)
+				}
+				if replayed := resp.Header().Get(IdempotentReplayedHeader) == "true"; replayed != test.replayed {
+					t.Fatalf("replayed = %v, want %v", replayed, test.replayed)
+				}
+			}
+			if calls := service.count(); calls != test.calls {
+				t.Fatalf("calls = %d, want %d", calls, test.calls)
+			}
+		})
+	}
+}
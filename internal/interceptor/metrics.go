@@ -0,0 +1,29 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/metrics"
+)
+
+// NewMetricsInterceptor records the count, latency and response code of every handled request.
+// Must run before the auth, rate limit and validate interceptors to include the requests they reject.
+func NewMetricsInterceptor(m *metrics.Metrics) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			start := time.Now()
+			resp, err := next(ctx, req)
+			code := "ok"
+			if err != nil {
+				code = connect.CodeOf(err).String()
+			}
+			m.ObserveRequest(req.Spec().Procedure, code, time.Since(start))
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"golang.org/x/time/rate"
+)
+
+// limiterIdleTime is the time after which the bucket of an inactive caller is dropped.
+// A dropped bucket is full again, so it must be at least the time a bucket takes to refill.
+const limiterIdleTime = 10 * time.Minute
+
+// RateLimit allows Rate requests per second in average with bursts of up to Burst requests.
+// A rate of zero or below disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type limiter struct {
+	bucket   *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter holds a token bucket for every caller and procedure.
+type RateLimiter struct {
+	limit        RateLimit
+	procedures   map[string]RateLimit
+	clientHeader string
+
+	lock      sync.Mutex
+	limiters  map[string]*limiter
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a limiter applying the limit to every procedure unless the procedure has its own limit.
+// If a client header is specified, unauthenticated callers are identified by the address the trusted proxy in front
+// of the operator appends to this header (e.g. X-Forwarded-For) instead of the address of the connection.
+func NewRateLimiter(limit RateLimit, procedures map[string]RateLimit, clientHeader string) *RateLimiter {
+	return &RateLimiter{
+		limit:        limit,
+		procedures:   procedures,
+		clientHeader: clientHeader,
+		limiters:     map[string]*limiter{},
+		lastSweep:    time.Now(),
+	}
+}
+
+// Caller returns the key of the caller, which is its principal or, if unauthenticated, its address.
+func (l *RateLimiter) Caller(ctx context.Context, addr string, header http.Header) string {
+	return auth.PrincipalFrom(ctx, l.clientHost(addr, header))
+}
+
+// clientHost returns the address of the client without the port, which changes with every connection.
+func (l *RateLimiter) clientHost(addr string, header http.Header) string {
+	if l.clientHeader != "" {
+		// the proxy appends the address it received the request from, entries before it are set by the client.
+		if values := header.Values(l.clientHeader); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if client := strings.TrimSpace(entries[len(entries)-1]); client != "" {
+				return client
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// Reserve takes a token from the bucket of the caller and procedure.
+// Returns the time until a token is available if the bucket is empty.
+func (l *RateLimiter) Reserve(caller, procedure string) (time.Duration, bool) {
+	limit, ok := l.procedures[procedure]
+	if !ok {
+		limit = l.limit
+	}
+	if limit.Rate <= 0 {
+		return 0, true
+	}
+	now := time.Now()
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if now.Sub(l.lastSweep) > limiterIdleTime {
+		for key, entry := range l.limiters {
+			if now.Sub(entry.lastSeen) > limiterIdleTime {
+				delete(l.limiters, key)
+			}
+		}
+		l.lastSweep = now
+	}
+	key := caller + " " + procedure
+	entry, ok := l.limiters[key]
+	if !ok {
+		entry = &limiter{bucket: rate.NewLimiter(rate.Limit(limit.Rate), max(limit.Burst, 1))}
+		l.limiters[key] = entry
+	}
+	entry.lastSeen = now
+	reservation := entry.bucket.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
+
+// NewRateLimitInterceptor rejects requests exceeding the rate limit of the caller with ResourceExhausted,
+// the Retry-After header tells the caller when to retry. Callers are identified by their principal or,
+// if unauthenticated, by their address. Must run after the auth interceptor to limit identities.
+func NewRateLimitInterceptor(limiter *RateLimiter) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			delay, ok := limiter.Reserve(limiter.Caller(ctx, req.Peer().Addr, req.Header()), req.Spec().Procedure)
+			if !ok {
+				retryAfter := int(math.Ceil(delay.Seconds()))
+				err := connect.NewError(connect.CodeResourceExhausted,
+					fmt.Errorf("rate limit exceeded for %s, retry in %ds", req.Spec().Procedure, retryAfter))
+				err.Meta().Set("Retry-After", strconv.Itoa(retryAfter))
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// Handler rejects http requests exceeding the rate limit of the caller with 429 Too Many Requests,
+// requests are limited per route pattern of the mux.
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delay, ok := l.Reserve(l.Caller(r.Context(), r.RemoteAddr, r.Header), r.Pattern)
+		if !ok {
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, fmt.Sprintf("rate limit exceeded, retry in %ds", retryAfter), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// peerHost returns the address of the caller without the port, which changes with every connection.
+func peerHost(req connect.AnyRequest) string {
+	host, _, err := net.SplitHostPort(req.Peer().Addr)
+	if err != nil {
+		return req.Peer().Addr
+	}
+	return host
+}
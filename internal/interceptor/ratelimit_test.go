@@ -0,0 +1,194 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	type call struct {
+		caller    string
+		procedure string
+		allowed   bool
+	}
+	tests := []struct {
+		name       string
+		limit      RateLimit
+		procedures map[string]RateLimit
+		calls      []call
+	}{
+		{
+			name:  "burst is exhausted",
+			limit: RateLimit{Rate: 0.001, Burst: 2},
+			calls: []call{
+				{"alice", "/a", true},
+				{"alice", "/a", true},
+				{"alice", "/a", false},
+			},
+		},
+		{
+			name:  "callers and procedures have own buckets",
+			limit: RateLimit{Rate: 0.001, Burst: 1},
+			calls: []call{
+				{"alice", "/a", true},
+				{"bob", "/a", true},
+				{"alice", "/b", true},
+				{"alice", "/a", false},
+			},
+		},
+		{
+			name:       "procedure limit overrides the default",
+			limit:      RateLimit{Rate: 0.001, Burst: 5},
+			procedures: map[string]RateLimit{"/deploy": {Rate: 0.001, Burst: 1}},
+			calls: []call{
+				{"alice", "/deploy", true},
+				{"alice", "/deploy", false},
+				{"alice", "/a", true},
+				{"alice", "/a", true},
+			},
+		},
+		{
+			name:       "procedure limit disables the default",
+			limit:      RateLimit{Rate: 0.001, Burst: 1},
+			procedures: map[string]RateLimit{"/a": {Rate: -1}},
+			calls: []call{
+				{"alice", "/a", true},
+				{"alice", "/a", true},
+				{"alice", "/b", true},
+				{"alice", "/b", false},
+			},
+		},
+		{
+			name:  "zero burst allows a single request",
+			limit: RateLimit{Rate: 0.001},
+			calls: []call{
+				{"alice", "/a", true},
+				{"alice", "/a", false},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := NewRateLimiter(test.limit, test.procedures, "")
+			for i, c := range test.calls {
+				delay, allowed := limiter.Reserve(c.caller, c.procedure)
+				if allowed != c.allowed {
+					t.Fatalf("call %d (%s %s): allowed = %v, want %v", i, c.caller, c.procedure, allowed, c.allowed)
+				}
+				if !allowed && delay <= 0 {
+					t.Fatalf("call %d (%s %s): rejected without delay", i, c.caller, c.procedure)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 0.5, Burst: 1}, nil, "")
+	mux := http.NewServeMux()
+	mux.Handle(clusterconnect.NewClusterServiceHandler(clusterconnect.UnimplementedClusterServiceHandler{},
+		connect.WithInterceptors(NewRateLimitInterceptor(limiter)),
+	))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := clusterconnect.NewClusterServiceClient(server.Client(), server.URL)
+
+	tests := []struct {
+		name       string
+		code       connect.Code
+		retryAfter string
+	}{
+		{name: "first request passes", code: connect.CodeUnimplemented},
+		{name: "second request is limited", code: connect.CodeResourceExhausted, retryAfter: "2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.Get(context.Background(), connect.NewRequest(&cluster.GetRequest{}))
+			if code := connect.CodeOf(err); code != test.code {
+				t.Fatalf("code = %v, want %v (%v)", code, test.code, err)
+			}
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("error is no connect error: %v", err)
+			}
+			if got := connectErr.Meta().Get("Retry-After"); got != test.retryAfter {
+				t.Fatalf("Retry-After = %q, want %q", got, test.retryAfter)
+			}
+		})
+	}
+}
+
+func TestRateLimiterCaller(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientHeader string
+		header       http.Header
+		identity     *auth.Identity
+		principal    string
+		want         string
+	}{
+		{name: "address without port", want: "192.0.2.1"},
+		{name: "forwarded header is ignored without trusted proxy", header: http.Header{"X-Forwarded-For": {"198.51.100.1"}}, want: "192.0.2.1"},
+		{name: "address appended by the trusted proxy", clientHeader: "X-Forwarded-For", header: http.Header{"X-Forwarded-For": {"203.0.113.9, 198.51.100.1"}}, want: "198.51.100.1"},
+		{name: "last header appended by the trusted proxy", clientHeader: "X-Forwarded-For", header: http.Header{"X-Forwarded-For": {"203.0.113.9", "198.51.100.1"}}, want: "198.51.100.1"},
+		{name: "connection address without proxy header", clientHeader: "X-Forwarded-For", want: "192.0.2.1"},
+		{name: "identities are keyed by principal", clientHeader: "X-Forwarded-For", header: http.Header{"X-Forwarded-For": {"198.51.100.1"}},
+			identity: &auth.Identity{Issuer: "https://other.test", Subject: "bob"}, principal: "https://other.test#bob", want: "https://other.test#bob"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.identity != nil {
+				ctx = auth.WithPrincipal(auth.WithIdentity(ctx, test.identity), test.principal)
+			}
+			limiter := NewRateLimiter(RateLimit{}, nil, test.clientHeader)
+			if got := limiter.Caller(ctx, "192.0.2.1:1000", test.header); got != test.want {
+				t.Fatalf("caller = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterHandler(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1}, nil, "X-Forwarded-For")
+	mux := http.NewServeMux()
+	mux.Handle("POST /token", limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	mux.Handle("GET /authorize", limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	// the steps run in order against the same limiter.
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		client     string
+		wantStatus int
+	}{
+		{name: "first request passes", method: http.MethodPost, path: "/token", client: "198.51.100.1", wantStatus: http.StatusOK},
+		{name: "second request is limited", method: http.MethodPost, path: "/token", client: "198.51.100.1", wantStatus: http.StatusTooManyRequests},
+		{name: "routes have own buckets", method: http.MethodGet, path: "/authorize", client: "198.51.100.1", wantStatus: http.StatusOK},
+		{name: "clients behind the proxy have own buckets", method: http.MethodPost, path: "/token", client: "198.51.100.2", wantStatus: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, nil)
+			req.RemoteAddr = "192.0.2.1:1000"
+			req.Header.Set("X-Forwarded-For", test.client)
+			resp := httptest.NewRecorder()
+			mux.ServeHTTP(resp, req)
+			if resp.Code != test.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.Code, test.wantStatus)
+			}
+			if test.wantStatus == http.StatusTooManyRequests && resp.Header().Get("Retry-After") == "" {
+				t.Fatal("rate limited response has no Retry-After header")
+			}
+		})
+	}
+}
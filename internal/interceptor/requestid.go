@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/logging"
+)
+
+// RequestIDHeader carries the id of a request, it is echoed in the response headers.
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDPattern restricts client provided ids, so that they cannot forge log lines.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// NewRequestIDInterceptor tags the logs of every request with a request id and logs the handled request.
+// The id is taken from the request header if valid, otherwise generated.
+// Must run before the other interceptors, so that their logs carry the id.
+func NewRequestIDInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			id := req.Header().Get(RequestIDHeader)
+			if !requestIDPattern.MatchString(id) {
+				id = rand.Text()
+			}
+			ctx = logging.WithRequestID(ctx, id)
+			start := time.Now()
+			resp, err := next(ctx, req)
+			attrs := []slog.Attr{
+				slog.String("procedure", req.Spec().Procedure),
+				slog.String("peer", req.Peer().Addr),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				connectErr := &connect.Error{}
+				if !errors.As(err, &connectErr) {
+					connectErr = connect.NewError(connect.CodeUnknown, err)
+				}
+				connectErr.Meta().Set(RequestIDHeader, id)
+				level := slog.LevelInfo
+				switch connectErr.Code() {
+				case connect.CodeInternal, connect.CodeUnknown, connect.CodeDataLoss:
+					level = slog.LevelError
+				}
+				attrs = append(attrs, slog.String("code", connectErr.Code().String()), slog.String("error", connectErr.Message()))
+				slog.LogAttrs(ctx, level, "request failed", attrs...)
+				return nil, connectErr
+			}
+			resp.Header().Set(RequestIDHeader, id)
+			slog.LogAttrs(ctx, slog.LevelInfo, "request handled", append(attrs, slog.String("code", "ok"))...)
+			return resp, nil
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package interceptor
+
+import (
+	"connectrpc.com/connect"
+	"connectrpc.com/validate"
+)
+
+// NewValidateInterceptor rejects requests violating the buf.validate rules of their message with InvalidArgument
+// before they reach the handler. The violations are attached as buf.validate.Violations error detail.
+func NewValidateInterceptor() connect.Interceptor {
+	return validate.NewInterceptor()
+}
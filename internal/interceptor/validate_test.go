@@ -0,0 +1,264 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	bufvalidate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/pkg/api/iam/v1/authorize"
+	"github.com/megakuul/miam/pkg/api/iam/v1/group"
+	"github.com/megakuul/miam/pkg/api/iam/v1/role"
+	"github.com/megakuul/miam/pkg/api/iam/v1/serviceaccount"
+	"github.com/megakuul/miam/pkg/api/iam/v1/user"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster/clusterconnect"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestValidateInterceptor(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(clusterconnect.NewClusterServiceHandler(clusterconnect.UnimplementedClusterServiceHandler{},
+		connect.WithInterceptors(NewValidateInterceptor()),
+	))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := clusterconnect.NewClusterServiceClient(server.Client(), server.URL)
+
+	tests := []struct {
+		name  string
+		req   *cluster.GetRequest
+		code  connect.Code
+		field string
+	}{
+		{name: "valid request passes", req: &cluster.GetRequest{Name: "prod-1"}, code: connect.CodeUnimplemented},
+		{name: "missing name", req: &cluster.GetRequest{}, code: connect.CodeInvalidArgument, field: "name"},
+		{name: "invalid name", req: &cluster.GetRequest{Name: "Prod"}, code: connect.CodeInvalidArgument, field: "name"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.Get(context.Background(), connect.NewRequest(test.req))
+			if code := connect.CodeOf(err); code != test.code {
+				t.Fatalf("code = %v, want %v (%v)", code, test.code, err)
+			}
+			if test.field == "" {
+				return
+			}
+			connectErr := &connect.Error{}
+			if !errors.As(err, &connectErr) {
+				t.Fatalf("error is no connect error: %v", err)
+			}
+			for _, detail := range connectErr.Details() {
+				value, err := detail.Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if violations, ok := value.(*bufvalidate.Violations); ok {
+					if len(violations.GetViolations()) != 1 || protovalidate.FieldPathString(violations.GetViolations()[0].GetField()) != test.field {
+						t.Fatalf("violations = %v, want a violation of %s", violations, test.field)
+					}
+					return
+				}
+			}
+			t.Fatal("error carries no violations detail")
+		})
+	}
+}
+
+func validCluster() *cluster.ClusterConfig {
+	return &cluster.ClusterConfig{
+		Name:          "prod-1",
+		RepoUrl:       "git@github.com:megakuul/miam.git",
+		ControlConfig: &cluster.InstanceConfig{Type: "t3.small", MinScale: 1, MaxScale: 3},
+		WorkerConfig:  &cluster.InstanceConfig{Type: "t3.large", MinScale: 0, MaxScale: 5},
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		msg  proto.Message
+		// violations lists the expected violations as "<field> [<rule id>]".
+		violations []string
+	}{
+		{
+			name: "valid cluster",
+			msg:  &cluster.UpdateRequest{Config: validCluster()},
+		},
+		{
+			name:       "missing config",
+			msg:        &cluster.UpdateRequest{},
+			violations: []string{"config [required]"},
+		},
+		{
+			name: "invalid nested fields",
+			msg: &cluster.UpdateRequest{Config: func() *cluster.ClusterConfig {
+				config := validCluster()
+				config.Name = "Prod_1"
+				config.RepoUrl = "not a url"
+				return config
+			}()},
+			violations: []string{
+				"config.name [string.pattern]",
+				"config.repo_url [string.pattern]",
+			},
+		},
+		{
+			name: "min scale exceeds max scale",
+			msg: &cluster.UpdateRequest{Config: func() *cluster.ClusterConfig {
+				config := validCluster()
+				config.WorkerConfig.MinScale = 6
+				return config
+			}()},
+			violations: []string{"config.worker_config [min_scale_lte_max_scale]"},
+		},
+		{
+			name: "operator source fallback",
+			msg:  &operator.UpdateRequest{Config: &operator.OperatorConfig{}},
+		},
+		{
+			name:       "invalid operator source",
+			msg:        &operator.UpdateRequest{Config: &operator.OperatorConfig{RepoUrl: "ftp://example.com/miam.git"}},
+			violations: []string{"config.repo_url [string.pattern]"},
+		},
+		{
+			name:       "operator cancel without revision",
+			msg:        &operator.CancelRequest{Force: true},
+			violations: []string{"revision [required]"},
+		},
+		{
+			name: "invalid tag",
+			msg: &cluster.UpdateRequest{Config: func() *cluster.ClusterConfig {
+				config := validCluster()
+				config.Tags = []string{"team=a", "bad tag"}
+				return config
+			}()},
+			violations: []string{"config.tags[1] [string.pattern]"},
+		},
+		{
+			name:       "page size out of range",
+			msg:        &cluster.ListRequest{PageSize: 1001},
+			violations: []string{"page_size [int32.gte_lte]"},
+		},
+		{
+			name:       "invalid name prefix",
+			msg:        &cluster.ListRequest{NamePrefix: "Prod"},
+			violations: []string{"name_prefix [string.pattern]"},
+		},
+		{
+			name: "unset optional name is ignored",
+			msg:  &cluster.PruneRequest{},
+		},
+		{
+			name:       "set optional name is validated",
+			msg:        &cluster.PruneRequest{Name: "-"},
+			violations: []string{"name [string.pattern]"},
+		},
+		{
+			name:       "message too long",
+			msg:        &cluster.UpdateRequest{Config: validCluster(), Message: strings.Repeat("x", 1025)},
+			violations: []string{"message [string.max_len]"},
+		},
+		{
+			name:       "audit time range reversed",
+			msg:        &audit.ListRequest{StartTime: timestamppb.New(now), EndTime: timestamppb.New(now.Add(-time.Hour))},
+			violations: []string{" [end_time_after_start_time]"},
+		},
+		{
+			name: "audit time range open",
+			msg:  &audit.ListRequest{StartTime: timestamppb.New(now)},
+		},
+		{
+			name: "valid user",
+			msg:  &user.CreateRequest{User: &user.User{Name: "alice@example.com", Email: "alice@example.com"}},
+		},
+		{
+			name:       "invalid email",
+			msg:        &user.CreateRequest{User: &user.User{Name: "alice", Email: "alice"}},
+			violations: []string{"user.email [string.email]"},
+		},
+		{
+			name:       "invalid group member",
+			msg:        &group.AddMemberRequest{Group: "Admins", User: "alice smith"},
+			violations: []string{"group [string.pattern]", "user [string.pattern]"},
+		},
+		{
+			name: "valid role",
+			msg: &role.CreateRequest{Role: &role.Role{Name: "deployer", Statements: []*role.Statement{{
+				Effect:     role.Effect_ALLOW,
+				Actions:    []string{"cluster:*"},
+				Resources:  []string{"cluster:dev-*"},
+				Conditions: []*role.Condition{{Key: "cluster:tag", Operator: "equals", Values: []string{"team=a"}}},
+			}}}},
+		},
+		{
+			name: "invalid statement",
+			msg: &role.CreateRequest{Role: &role.Role{Name: "deployer", Statements: []*role.Statement{{
+				Effect:     role.Effect(7),
+				Conditions: []*role.Condition{{Key: "cluster:name", Operator: "equals"}},
+			}}}},
+			violations: []string{
+				"role.statements[0].effect [enum.defined_only]",
+				"role.statements[0].actions [repeated.min_items]",
+				"role.statements[0].resources [repeated.min_items]",
+				"role.statements[0].conditions[0].key [string.in]",
+				"role.statements[0].conditions[0].values [repeated.min_items]",
+			},
+		},
+		{
+			name: "check of cluster",
+			msg:  &authorize.CheckRequest{Action: "cluster:Update", Resource: "cluster:prod-1"},
+		},
+		{
+			name: "check of service",
+			msg:  &authorize.CheckRequest{Action: "operator:Update", Resource: "operator"},
+		},
+		{
+			name:       "check of other service",
+			msg:        &authorize.CheckRequest{Action: "operator:Update", Resource: "cluster"},
+			violations: []string{" [resource]"},
+		},
+		{
+			name:       "expired api key",
+			msg:        &serviceaccount.CreateKeyRequest{Key: &serviceaccount.ApiKey{ServiceAccount: "ci", ExpiresAt: timestamppb.New(now.Add(-time.Hour))}},
+			violations: []string{"key.expires_at [timestamp.gt_now]"},
+		},
+		{
+			name: "api key without expiry",
+			msg:  &serviceaccount.CreateKeyRequest{Key: &serviceaccount.ApiKey{ServiceAccount: "ci"}},
+		},
+		{
+			name:       "invalid api key scope",
+			msg:        &serviceaccount.CreateKeyRequest{Key: &serviceaccount.ApiKey{ServiceAccount: "ci", Procedures: []string{"/operator.v1.cluster.ClusterService/Update"}, Clusters: []string{"[a-"}}},
+			violations: []string{"key.procedures[0] [string.pattern]", "key.clusters[0] [string.pattern]"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := protovalidate.Validate(test.msg)
+			violations := []string{}
+			validationErr := &protovalidate.ValidationError{}
+			if errors.As(err, &validationErr) {
+				for _, violation := range validationErr.Violations {
+					violations = append(violations, protovalidate.FieldPathString(violation.Proto.GetField())+" ["+violation.Proto.GetRuleId()+"]")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(violations, append([]string{}, test.violations...)) {
+				t.Fatalf("violations = %q, want %q", violations, test.violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package metrics collects the prometheus metrics of the operator.
+//
+// Labels are restricted to bounded sets (procedures, codes, store operations, states and cluster names),
+// caller identities or revision ids are never used as labels.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "miam"
+
+// Metrics holds the collectors of the operator in a dedicated registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	deployments        *prometheus.CounterVec
+	deploymentDuration *prometheus.HistogramVec
+
+	storeDuration *prometheus.HistogramVec
+}
+
+// New creates the collectors and registers them together with the go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "api",
+			Name:      "requests_total",
+			Help:      "Number of handled api requests by procedure and response code.",
+		}, []string{"procedure", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "api",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of handled api requests by procedure.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"procedure"}),
+		deployments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "reconciler",
+			Name:      "deployments_total",
+			Help:      "Number of finished deployments by cluster (empty for the operator), action and resulting state.",
+		}, []string{"cluster", "action", "state"}),
+		deploymentDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "reconciler",
+			Name:      "deployment_duration_seconds",
+			Help:      "Duration of finished deployments by target, action and resulting state.",
+			// pulumi deployments take from seconds up to an hour.
+			Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+		}, []string{"target", "action", "state"}),
+		storeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of dynamodb operations by operation and outcome.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "outcome"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests, m.requestDuration,
+		m.deployments, m.deploymentDuration,
+		m.storeDuration,
+	)
+	return m
+}
+
+// Register adds the /metrics endpoint to the mux.
+func (m *Metrics) Register(mux *http.ServeMux) {
+	mux.Handle("GET /metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry}))
+}
+
+// ObserveRequest records a handled api request, code is the connect code name or "ok".
+func (m *Metrics) ObserveRequest(procedure, code string, duration time.Duration) {
+	m.requests.WithLabelValues(procedure, code).Inc()
+	m.requestDuration.WithLabelValues(procedure).Observe(duration.Seconds())
+}
+
+// ObserveDeployment records a finished deployment, cluster is empty for operator deployments.
+func (m *Metrics) ObserveDeployment(target, cluster, action, state string, duration time.Duration) {
+	m.deployments.WithLabelValues(cluster, action, state).Inc()
+	m.deploymentDuration.WithLabelValues(target, action, state).Observe(duration.Seconds())
+}
+
+// ObserveReconciler exposes the queued and running jobs of the reconciler.
+func (m *Metrics) ObserveReconciler(queued, running func() int) {
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "reconciler",
+			Name:      "queue_depth",
+			Help:      "Number of jobs waiting for a worker.",
+		}, func() float64 { return float64(queued()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "reconciler",
+			Name:      "running_jobs",
+			Help:      "Number of jobs currently deployed by a worker.",
+		}, func() float64 { return float64(running()) }),
+	)
+}
+
+// StoreOption instruments the dynamodb client of the store with the operation latency (including retries).
+// The middleware is added after the service metadata, which provides the operation name.
+func (m *Metrics) StoreOption() func(*dynamodb.Options) {
+	return func(o *dynamodb.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("StoreMetrics", func(
+				ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				start := time.Now()
+				out, metadata, err := next.HandleInitialize(ctx, in)
+				outcome := "ok"
+				if err != nil {
+					outcome = "error"
+				}
+				m.storeDuration.WithLabelValues(awsmiddleware.GetOperationName(ctx), outcome).Observe(time.Since(start).Seconds())
+				return out, metadata, err
+			}), middleware.After)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+)
+
+// Retention specifies which cluster and operator revisions survive pruning.
+// A revision is kept if it is one of the Count newest revisions or younger than Age,
+// a zero value disables the respective rule. The latest and the active revision are always kept.
+type Retention struct {
+	Count    int
+	Age      time.Duration
+	Interval time.Duration // interval of the background pruning, disabled if zero
+	History  time.Duration // time the history of destroyed clusters and operators is kept, kept forever if zero
+}
+
+func (r Retention) enabled() bool {
+	return r.Count > 0 || r.Age > 0
+}
+
+// revision is the part of a cluster or operator revision relevant to the retention.
+type revision struct {
+	id        string
+	created   time.Time
+	active    bool
+	deploying bool
+}
+
+// prunable returns the ids of the revisions (ordered from newest to oldest) that fall outside the retention.
+func (r Retention) prunable(revisions []revision) []string {
+	pruned := []string{}
+	activeKept := false
+	for i, revision := range revisions {
+		switch {
+		case i == 0, revision.deploying:
+		case revision.active && !activeKept:
+		case r.Count > 0 && i < r.Count:
+		case r.Age > 0 && time.Since(revision.created) < r.Age:
+		default:
+			pruned = append(pruned, revision.id)
+		}
+		if revision.active {
+			activeKept = true
+		}
+	}
+	return pruned
+}
+
+// Prune deletes the revisions of a cluster that fall outside the retention.
+func (r *Reconciler) Prune(ctx context.Context, name string) ([]*cluster.ClusterStatus, error) {
+	if !r.retention.enabled() {
+		return []*cluster.ClusterStatus{}, nil
+	}
+	statuses, err := r.store.ClusterRevisions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	revisions := []revision{}
+	for _, status := range statuses {
+		revisions = append(revisions, revision{
+			id:        status.GetRevision(),
+			created:   status.GetCreatedAt().AsTime(),
+			active:    status.GetState() == cluster.State_ACTIVE,
+			deploying: status.GetState() == cluster.State_DEPLOYING,
+		})
+	}
+	prunedIds := r.retention.prunable(revisions)
+	if err := r.store.DeleteClusterRevisions(ctx, name, prunedIds); err != nil {
+		return nil, err
+	}
+	pruned := []*cluster.ClusterStatus{}
+	for _, status := range statuses {
+		if slices.Contains(prunedIds, status.GetRevision()) {
+			pruned = append(pruned, status)
+		}
+	}
+	return pruned, nil
+}
+
+// PruneClusters prunes the revisions of all clusters.
+func (r *Reconciler) PruneClusters(ctx context.Context) ([]*cluster.ClusterStatus, error) {
+	pruned := []*cluster.ClusterStatus{}
+	token := ""
+	for {
+		clusters, next, err := r.store.ListClusters(ctx, store.ClusterFilter{}, 100, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range clusters {
+			revisions, err := r.Prune(ctx, c.GetName())
+			if err != nil {
+				return nil, fmt.Errorf("failed to prune cluster '%s': %v", c.GetName(), err)
+			}
+			pruned = append(pruned, revisions...)
+		}
+		if next == "" {
+			return pruned, nil
+		}
+		token = next
+	}
+}
+
+// PruneOperator deletes the revisions of the operator that fall outside the retention.
+func (r *Reconciler) PruneOperator(ctx context.Context) ([]*operator.OperatorStatus, error) {
+	if !r.retention.enabled() {
+		return []*operator.OperatorStatus{}, nil
+	}
+	statuses, err := r.store.GetOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	revisions := []revision{}
+	for _, status := range statuses {
+		revisions = append(revisions, revision{
+			id:        status.GetRevision(),
+			created:   status.GetCreatedAt().AsTime(),
+			active:    status.GetState() == operator.State_ACTIVE,
+			deploying: status.GetState() == operator.State_DEPLOYING,
+		})
+	}
+	prunedIds := r.retention.prunable(revisions)
+	if err := r.store.DeleteOperatorRevisions(ctx, prunedIds); err != nil {
+		return nil, err
+	}
+	pruned := []*operator.OperatorStatus{}
+	for _, status := range statuses {
+		if slices.Contains(prunedIds, status.GetRevision()) {
+			pruned = append(pruned, status)
+		}
+	}
+	return pruned, nil
+}
+
+// PruneAll prunes the revisions of all clusters and of the operator.
+func (r *Reconciler) PruneAll(ctx context.Context) error {
+	if _, err := r.PruneClusters(ctx); err != nil {
+		return err
+	}
+	if _, err := r.PruneOperator(ctx); err != nil {
+		return fmt.Errorf("failed to prune operator: %v", err)
+	}
+	return nil
+}
+
+// runPruner periodically prunes all clusters until the context is cancelled.
+func (r *Reconciler) runPruner(ctx context.Context) {
+	if !r.retention.enabled() || r.retention.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(r.retention.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// failures are retried on the next tick.
+			if err := r.PruneAll(ctx); err != nil {
+				slog.WarnContext(ctx, "failed to prune revisions", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,546 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/megakuul/miam/internal/deploy"
+	"github.com/megakuul/miam/internal/logging"
+	"github.com/megakuul/miam/internal/metrics"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/internal/tracing"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Target specifies which kind of stack a job operates on.
+type Target int
+
+const (
+	TargetCluster Target = iota
+	TargetOperator
+)
+
+// Action specifies what a job does with its stack.
+type Action int
+
+const (
+	ActionUp Action = iota
+	ActionDestroy
+)
+
+// Job describes the deployment of a single revision.
+type Job struct {
+	Target   Target
+	Action   Action
+	Name     string // cluster name, ignored for operator jobs
+	Revision string
+
+	// trace is the span of the request that enqueued the job, the deployment continues its trace.
+	trace trace.SpanContext
+	// request is the id of the request that enqueued the job, it is added to the deployment logs.
+	request string
+}
+
+// ErrCancelled is the cause recorded on jobs that were cancelled through the api.
+var ErrCancelled = errors.New("cancelled")
+
+var tracer = otel.Tracer("github.com/megakuul/miam/internal/reconciler")
+
+// interruptTimeout bounds the store writes recording interrupted jobs during shutdown.
+const interruptTimeout = 10 * time.Second
+
+const (
+	// HeartbeatInterval is the interval in which the reconciler renews the leases of its queued and running revisions.
+	HeartbeatInterval = 30 * time.Second
+	// LeaseTimeout is the time after which a deploying revision without renewed lease is considered abandoned by its owner.
+	LeaseTimeout = 3 * HeartbeatInterval
+)
+
+// id uniquely identifies the revision a job deploys.
+func (j Job) id() string {
+	if j.Target == TargetOperator {
+		return "operator@" + j.Revision
+	}
+	return "cluster/" + j.Name + "@" + j.Revision
+}
+
+// labels returns the names of the job target and action used in metrics and traces.
+func (j Job) labels() (target, action string) {
+	target, action = "cluster", "up"
+	if j.Target == TargetOperator {
+		target = "operator"
+	}
+	if j.Action == ActionDestroy {
+		action = "destroy"
+	}
+	return target, action
+}
+
+// logContext returns a context whose logs carry the job attributes.
+func (j Job) logContext(ctx context.Context) context.Context {
+	target, action := j.labels()
+	args := []any{"target", target, "action", action, "revision", j.Revision}
+	if j.Target == TargetCluster {
+		args = append(args, "cluster", j.Name)
+	}
+	if j.request != "" {
+		args = append(args, "request_id", j.request)
+	}
+	return logging.With(ctx, args...)
+}
+
+// Reconciler deploys the revisions enqueued by the api and records their outcome in the store.
+type Reconciler struct {
+	store     *store.Store
+	runner    *deploy.Runner
+	project   string
+	workers   int
+	retention Retention
+	metrics   *metrics.Metrics
+	queue     chan Job
+
+	lock      sync.Mutex
+	jobs      map[string]Job
+	pending   map[string]bool
+	running   map[string]context.CancelCauseFunc
+	cancelled map[string]bool
+}
+
+// New creates a reconciler processing up to workers jobs concurrently, queueing up to queueSize jobs.
+// Deployment outcomes are recorded in the metrics if specified.
+func New(store *store.Store, runner *deploy.Runner, project string, workers, queueSize int, retention Retention, metrics *metrics.Metrics) *Reconciler {
+	r := &Reconciler{
+		store:     store,
+		runner:    runner,
+		project:   project,
+		workers:   workers,
+		retention: retention,
+		metrics:   metrics,
+		queue:     make(chan Job, queueSize),
+
+		jobs:      map[string]Job{},
+		pending:   map[string]bool{},
+		running:   map[string]context.CancelCauseFunc{},
+		cancelled: map[string]bool{},
+	}
+	if metrics != nil {
+		metrics.ObserveReconciler(func() int { return len(r.queue) }, func() int {
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			return len(r.running)
+		})
+	}
+	return r
+}
+
+// Enqueue schedules a job for deployment. Fails if the queue is exhausted.
+// The deployment is traced as child of the span in the context, even though it outlives the request.
+func (r *Reconciler) Enqueue(ctx context.Context, job Job) error {
+	job.trace = trace.SpanContextFromContext(ctx)
+	job.request = logging.RequestID(ctx)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	select {
+	case r.queue <- job:
+		r.jobs[job.id()] = job
+		r.pending[job.id()] = true
+		return nil
+	default:
+		return fmt.Errorf("deployment queue is full")
+	}
+}
+
+// Cancel stops the deployment of the job revision. Running pulumi operations are interrupted
+// through their context, queued jobs are dropped once they are dequeued.
+// Returns false if the revision is neither queued nor running on this reconciler.
+func (r *Reconciler) Cancel(job Job) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if cancel, ok := r.running[job.id()]; ok {
+		cancel(ErrCancelled)
+		return true
+	}
+	if r.pending[job.id()] {
+		r.cancelled[job.id()] = true
+		return true
+	}
+	return false
+}
+
+// Release cancels a job revision that is not owned by this reconciler.
+// The owner is asked to cancel the revision on its next lease renewal. If the lease is still valid and force is not set,
+// the revision is left to the owner. Otherwise the stack lock is released and the revision is recorded as cancelled.
+func (r *Reconciler) Release(ctx context.Context, job Job, force bool) error {
+	var (
+		lease store.Lease
+		err   error
+	)
+	switch job.Target {
+	case TargetCluster:
+		if err = r.store.RequestClusterCancel(ctx, job.Name, job.Revision); err == nil {
+			lease, err = r.store.ClusterLease(ctx, job.Name, job.Revision)
+		}
+	case TargetOperator:
+		if err = r.store.RequestOperatorCancel(ctx, job.Revision); err == nil {
+			lease, err = r.store.OperatorLease(ctx, job.Revision)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to request cancellation: %v", err)
+	}
+	if !force && time.Since(lease.Renewed) < LeaseTimeout {
+		return nil
+	}
+	// without project the stack was never checked out, hence it cannot be locked.
+	if lease.Project != "" {
+		// the stack lock of the stopped update is released first, otherwise the next deployment could not acquire it.
+		if err := r.runner.Unlock(ctx, lease.Project, r.stackName(job)); err != nil {
+			return err
+		}
+	}
+	switch job.Target {
+	case TargetCluster:
+		err = r.store.SetClusterState(ctx, job.Name, job.Revision, cluster.State_FAILED, ErrCancelled.Error())
+	case TargetOperator:
+		err = r.store.SetOperatorState(ctx, job.Revision, operator.State_FAILED, ErrCancelled.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record cancellation: %v", err)
+	}
+	return nil
+}
+
+// Run resumes interrupted deployments, processes enqueued jobs and prunes old revisions until the context is cancelled.
+// Jobs running or queued on cancellation are marked as interrupted and resumed by the next Run.
+// Returns once all running jobs stopped.
+func (r *Reconciler) Run(ctx context.Context) {
+	wg := sync.WaitGroup{}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		r.runPruner(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		r.runHeartbeat(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		r.resume(ctx)
+	}()
+	for range r.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-r.queue:
+					r.start(ctx, job)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for {
+		select {
+		case job := <-r.queue:
+			r.lock.Lock()
+			delete(r.jobs, job.id())
+			delete(r.pending, job.id())
+			cancelled := r.cancelled[job.id()]
+			delete(r.cancelled, job.id())
+			r.lock.Unlock()
+			jobCtx := job.logContext(context.WithoutCancel(ctx))
+			if cancelled {
+				r.record(jobCtx, job, ErrCancelled)
+			} else {
+				r.interrupt(jobCtx, job)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// resume enqueues the deployments interrupted by the last shutdown.
+func (r *Reconciler) resume(ctx context.Context) {
+	// interruptions that fail to load remain marked and are resumed on the next start.
+	interruptions, err := r.store.Interruptions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load interrupted deployments", "error", err)
+		return
+	}
+	for _, interruption := range interruptions {
+		job := Job{Target: TargetCluster, Action: ActionUp, Name: interruption.Name, Revision: interruption.Revision}
+		if interruption.Operator {
+			job.Target = TargetOperator
+		}
+		if interruption.Destroy {
+			job.Action = ActionDestroy
+		}
+		slog.InfoContext(job.logContext(ctx), "resuming interrupted deployment")
+		r.lock.Lock()
+		r.jobs[job.id()] = job
+		r.pending[job.id()] = true
+		r.lock.Unlock()
+		// unlike Enqueue, resumed jobs wait for space in the queue.
+		select {
+		case <-ctx.Done():
+			return
+		case r.queue <- job:
+		}
+	}
+}
+
+// start processes a dequeued job with a context that can be cancelled through Cancel.
+func (r *Reconciler) start(ctx context.Context, job Job) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	r.lock.Lock()
+	delete(r.pending, job.id())
+	if r.cancelled[job.id()] {
+		delete(r.cancelled, job.id())
+		cancel(ErrCancelled)
+	} else {
+		r.running[job.id()] = cancel
+	}
+	r.lock.Unlock()
+
+	target, action := job.labels()
+	jobCtx = job.logContext(jobCtx)
+	jobCtx, span := tracer.Start(trace.ContextWithSpanContext(jobCtx, job.trace), "reconciler.Deploy",
+		trace.WithAttributes(
+			attribute.String("miam.target", target),
+			attribute.String("miam.action", action),
+			attribute.String("miam.cluster", job.Name),
+			attribute.String("miam.revision", job.Revision),
+		),
+	)
+	r.process(jobCtx, job)
+	span.End()
+
+	r.lock.Lock()
+	delete(r.jobs, job.id())
+	delete(r.running, job.id())
+	r.lock.Unlock()
+}
+
+// runHeartbeat renews the leases of the queued and running revisions until the context is cancelled.
+// Revisions whose cancellation was requested through another operator instance are cancelled on renewal.
+func (r *Reconciler) runHeartbeat(ctx context.Context) {
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.lock.Lock()
+			jobs := slices.Collect(maps.Values(r.jobs))
+			r.lock.Unlock()
+			for _, job := range jobs {
+				cancelled, err := r.renew(ctx, job, "")
+				if err != nil {
+					// revisions that are no longer deploying finished since the jobs were collected.
+					if !errors.Is(err, store.ErrConflict) {
+						slog.WarnContext(job.logContext(ctx), "failed to renew deployment lease", "error", err)
+					}
+					continue
+				}
+				if cancelled {
+					slog.InfoContext(job.logContext(ctx), "deployment cancelled through another operator instance")
+					r.Cancel(job)
+				}
+			}
+		}
+	}
+}
+
+// renew renews the lease of the job revision and records the pulumi project of its stack if specified.
+// Returns whether the cancellation of the revision was requested.
+func (r *Reconciler) renew(ctx context.Context, job Job, project string) (bool, error) {
+	switch job.Target {
+	case TargetCluster:
+		return r.store.RenewClusterLease(ctx, job.Name, job.Revision, project)
+	case TargetOperator:
+		return r.store.RenewOperatorLease(ctx, job.Revision, project)
+	}
+	return false, nil
+}
+
+// checkout checks out the stack of the job and records its project on the revision before the stack is locked,
+// so that the lock can be released without checkout if this reconciler stops.
+func (r *Reconciler) checkout(ctx context.Context, job Job, source deploy.Source) (*deploy.Stack, error) {
+	stack, err := r.runner.Checkout(ctx, r.stackName(job), source)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, err := r.renew(ctx, job, stack.Project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record stack project: %v", err)
+	}
+	if cancelled {
+		return nil, ErrCancelled
+	}
+	return stack, nil
+}
+
+// stackName returns the name of the stack the job deploys.
+func (r *Reconciler) stackName(job Job) string {
+	if job.Target == TargetOperator {
+		return r.project
+	}
+	return job.Name
+}
+
+func (r *Reconciler) process(ctx context.Context, job Job) {
+	started := time.Now()
+	slog.InfoContext(ctx, "deployment started")
+	var err error
+	switch job.Target {
+	case TargetCluster:
+		err = r.deployCluster(ctx, job)
+	case TargetOperator:
+		err = r.deployOperator(ctx, job)
+	}
+	// the outcome must be recorded even if the deployment was interrupted by the context.
+	tracing.RecordError(trace.SpanFromContext(ctx), err)
+	if err != nil && ctx.Err() != nil && !errors.Is(context.Cause(ctx), ErrCancelled) {
+		r.interrupt(context.WithoutCancel(ctx), job)
+		r.observe(job, "INTERRUPTED", started)
+		slog.WarnContext(ctx, "deployment interrupted", "duration", time.Since(started), "error", err)
+		return
+	}
+	state := r.record(context.WithoutCancel(ctx), job, err)
+	r.observe(job, state, started)
+	if err != nil {
+		slog.ErrorContext(ctx, "deployment failed", "state", state, "duration", time.Since(started), "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "deployment finished", "state", state, "duration", time.Since(started))
+}
+
+// observe records the outcome of a processed job in the metrics.
+func (r *Reconciler) observe(job Job, state string, started time.Time) {
+	if r.metrics == nil {
+		return
+	}
+	target, action := job.labels()
+	r.metrics.ObserveDeployment(target, job.Name, action, state, time.Since(started))
+}
+
+// record stores the outcome of the job and returns the resulting state.
+func (r *Reconciler) record(ctx context.Context, job Job, err error) string {
+	switch job.Target {
+	case TargetCluster:
+		state, reason := cluster.State_ACTIVE, ""
+		if job.Action == ActionDestroy {
+			state = cluster.State_INACTIVE
+		}
+		if err != nil {
+			state, reason = cluster.State_FAILED, err.Error()
+		}
+		if err := r.store.SetClusterState(ctx, job.Name, job.Revision, state, reason); err != nil {
+			slog.ErrorContext(ctx, "failed to record deployment state", "state", state.String(), "error", err)
+		}
+		if state == cluster.State_INACTIVE && r.retention.History > 0 {
+			// the history of destroyed clusters is removed by the dynamodb ttl once the history ttl passed.
+			if err := r.store.ExpireCluster(ctx, job.Name, time.Now().Add(r.retention.History)); err != nil {
+				slog.ErrorContext(ctx, "failed to expire cluster", "error", err)
+			}
+		}
+		return state.String()
+	case TargetOperator:
+		state, reason := operator.State_ACTIVE, ""
+		if job.Action == ActionDestroy {
+			state = operator.State_INACTIVE
+		}
+		if err != nil {
+			state, reason = operator.State_FAILED, err.Error()
+		}
+		if err := r.store.SetOperatorState(ctx, job.Revision, state, reason); err != nil {
+			slog.ErrorContext(ctx, "failed to record deployment state", "state", state.String(), "error", err)
+		}
+		if state == operator.State_INACTIVE && r.retention.History > 0 {
+			if err := r.store.ExpireOperator(ctx, time.Now().Add(r.retention.History)); err != nil {
+				slog.ErrorContext(ctx, "failed to expire operator", "error", err)
+			}
+		}
+		return state.String()
+	}
+	return ""
+}
+
+// interrupt marks the job revision to be resumed by the next start of the reconciler.
+func (r *Reconciler) interrupt(ctx context.Context, job Job) {
+	ctx, cancel := context.WithTimeout(ctx, interruptTimeout)
+	defer cancel()
+	var err error
+	switch job.Target {
+	case TargetCluster:
+		err = r.store.MarkClusterInterrupted(ctx, job.Name, job.Revision, job.Action == ActionDestroy)
+	case TargetOperator:
+		err = r.store.MarkOperatorInterrupted(ctx, job.Revision, job.Action == ActionDestroy)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to mark deployment as interrupted", "error", err)
+	}
+}
+
+func (r *Reconciler) deployCluster(ctx context.Context, job Job) error {
+	if err := context.Cause(ctx); err != nil {
+		return err
+	}
+	_, config, err := r.store.DescribeCluster(ctx, job.Name, job.Revision)
+	if err != nil {
+		return fmt.Errorf("failed to load cluster config: %v", err)
+	}
+	stack, err := r.checkout(ctx, job, deploy.Source{URL: config.GetRepoUrl(), Ref: config.GetRepoRef()})
+	if err != nil {
+		return err
+	}
+	if job.Action == ActionDestroy {
+		return r.runner.Destroy(ctx, stack)
+	}
+	return r.runner.Up(ctx, stack, map[string]string{
+		"controlType":     config.GetControlConfig().GetType(),
+		"controlMinScale": strconv.FormatInt(config.GetControlConfig().GetMinScale(), 10),
+		"controlMaxScale": strconv.FormatInt(config.GetControlConfig().GetMaxScale(), 10),
+		"workerType":      config.GetWorkerConfig().GetType(),
+		"workerMinScale":  strconv.FormatInt(config.GetWorkerConfig().GetMinScale(), 10),
+		"workerMaxScale":  strconv.FormatInt(config.GetWorkerConfig().GetMaxScale(), 10),
+	})
+}
+
+func (r *Reconciler) deployOperator(ctx context.Context, job Job) error {
+	if err := context.Cause(ctx); err != nil {
+		return err
+	}
+	_, config, err := r.store.DescribeOperator(ctx, job.Revision)
+	if err != nil {
+		return fmt.Errorf("failed to load operator config: %v", err)
+	}
+	stack, err := r.checkout(ctx, job, deploy.Source{URL: config.GetRepoUrl(), Ref: config.GetRepoRef()})
+	if err != nil {
+		return err
+	}
+	if job.Action == ActionDestroy {
+		return r.runner.Destroy(ctx, stack)
+	}
+	return r.runner.Up(ctx, stack, map[string]string{})
+}
@@ -0,0 +1,145 @@
+package scim
+
+import (
+	"slices"
+	"strings"
+)
+
+// filter is a parsed scim filter expression (rfc 7644 section 3.4.2.2) in disjunctive form.
+// Supported are attribute expressions (eq, ne, co, sw, ew, pr) combined with "and" and "or",
+// grouping and "not" are not supported.
+type filter [][]comparison
+
+type comparison struct {
+	attribute string // lower case attribute path
+	operator  string
+	value     string
+}
+
+// attributes resolves the values of an attribute path (lower case) of a resource.
+type attributes func(attribute string) []string
+
+// parseFilter parses the filter expression, an empty expression matches everything.
+func parseFilter(expression string) (filter, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	f := filter{}
+	term := []comparison{}
+	for len(tokens) > 0 {
+		if len(tokens) < 2 {
+			return nil, badRequest("invalidFilter", "incomplete filter expression")
+		}
+		c := comparison{attribute: strings.ToLower(tokens[0]), operator: strings.ToLower(tokens[1])}
+		tokens = tokens[2:]
+		switch c.operator {
+		case "pr":
+		case "eq", "ne", "co", "sw", "ew":
+			if len(tokens) < 1 {
+				return nil, badRequest("invalidFilter", "missing value for operator '%s'", c.operator)
+			}
+			c.value, tokens = unquote(tokens[0]), tokens[1:]
+		default:
+			return nil, badRequest("invalidFilter", "unsupported filter operator '%s'", c.operator)
+		}
+		term = append(term, c)
+		if len(tokens) < 1 {
+			break
+		}
+		switch strings.ToLower(tokens[0]) {
+		case "and":
+		case "or":
+			f, term = append(f, term), []comparison{}
+		default:
+			return nil, badRequest("invalidFilter", "unsupported filter expression '%s'", tokens[0])
+		}
+		tokens = tokens[1:]
+		if len(tokens) < 1 {
+			return nil, badRequest("invalidFilter", "incomplete filter expression")
+		}
+	}
+	if len(term) > 0 {
+		f = append(f, term)
+	}
+	return f, nil
+}
+
+// tokenize splits the expression at spaces outside of quoted strings.
+func tokenize(expression string) ([]string, error) {
+	tokens := []string{}
+	current := strings.Builder{}
+	quoted, escaped := false, false
+	for _, r := range expression {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == '(' || r == ')' || r == '['):
+			return nil, badRequest("invalidFilter", "grouping is not supported")
+		case !quoted && r == ' ':
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if quoted {
+		return nil, badRequest("invalidFilter", "unterminated string")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+func unquote(token string) string {
+	if len(token) >= 2 && strings.HasPrefix(token, `"`) && strings.HasSuffix(token, `"`) {
+		token = token[1 : len(token)-1]
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(token, `\"`, `"`), `\\`, `\`)
+}
+
+// matches reports whether the resource satisfies the filter. Strings are compared case insensitive.
+func (f filter) matches(attrs attributes) bool {
+	if len(f) < 1 {
+		return true
+	}
+	return slices.ContainsFunc(f, func(term []comparison) bool {
+		for _, c := range term {
+			if !c.matches(attrs(c.attribute)) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (c *comparison) matches(values []string) bool {
+	value := strings.ToLower(c.value)
+	switch c.operator {
+	case "pr":
+		return slices.ContainsFunc(values, func(v string) bool { return v != "" })
+	case "ne":
+		return !slices.ContainsFunc(values, func(v string) bool { return strings.ToLower(v) == value })
+	}
+	return slices.ContainsFunc(values, func(v string) bool {
+		v = strings.ToLower(v)
+		switch c.operator {
+		case "eq":
+			return v == value
+		case "co":
+			return strings.Contains(v, value)
+		case "sw":
+			return strings.HasPrefix(v, value)
+		case "ew":
+			return strings.HasSuffix(v, value)
+		}
+		return false
+	})
+}
@@ -0,0 +1,103 @@
+package scim
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       filter
+		wantErr    bool
+	}{
+		{name: "empty", expression: "", want: filter{}},
+		{
+			name:       "equality",
+			expression: `userName eq "alice@example.com"`,
+			want:       filter{{{attribute: "username", operator: "eq", value: "alice@example.com"}}},
+		},
+		{
+			name:       "present",
+			expression: "externalId pr",
+			want:       filter{{{attribute: "externalid", operator: "pr"}}},
+		},
+		{
+			name:       "and binds stronger than or",
+			expression: `userName sw "a" and active eq true or displayName co "bob"`,
+			want: filter{
+				{{attribute: "username", operator: "sw", value: "a"}, {attribute: "active", operator: "eq", value: "true"}},
+				{{attribute: "displayname", operator: "co", value: "bob"}},
+			},
+		},
+		{
+			name:       "quoted spaces and escapes",
+			expression: `displayName eq "Alice \"Al\" Smith"`,
+			want:       filter{{{attribute: "displayname", operator: "eq", value: `Alice "Al" Smith`}}},
+		},
+		{name: "unsupported operator", expression: `userName gt "a"`, wantErr: true},
+		{name: "missing value", expression: "userName eq", wantErr: true},
+		{name: "dangling logical operator", expression: `userName eq "a" and`, wantErr: true},
+		{name: "unknown logical operator", expression: `userName eq "a" xor active pr`, wantErr: true},
+		{name: "grouping", expression: `(userName eq "a")`, wantErr: true},
+		{name: "value path", expression: `emails[type eq "work"]`, wantErr: true},
+		{name: "unterminated string", expression: `userName eq "a`, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseFilter(test.expression)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("filter was parsed: %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("filter = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	resource := map[string][]string{
+		"username":     {"Alice@Example.com"},
+		"emails.value": {"alice@example.com", "a.smith@corp.example"},
+		"externalid":   {""},
+	}
+	attrs := func(attribute string) []string { return resource[attribute] }
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"", true},
+		{`userName eq "alice@example.com"`, true},
+		{`userName eq "bob@example.com"`, false},
+		{`userName ne "bob@example.com"`, true},
+		{`emails.value ne "alice@example.com"`, false},
+		{`emails.value co "smith"`, true},
+		{`userName sw "ALICE"`, true},
+		{`userName ew ".org"`, false},
+		{"userName pr", true},
+		{"externalId pr", false},
+		{"title pr", false},
+		{`userName sw "alice" and emails.value ew "corp.example"`, true},
+		{`userName sw "alice" and title pr`, false},
+		{`title pr or userName ew "example.com"`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			f, err := parseFilter(test.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.matches(attrs); got != test.want {
+				t.Fatalf("matches = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
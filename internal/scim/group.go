@@ -0,0 +1,260 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/iam/v1/group"
+)
+
+// memberPathPattern matches patch paths selecting a single member (e.g. members[value eq "alice"]).
+var memberPathPattern = regexp.MustCompile(`(?i)^members\[value eq "(.*)"\]$`)
+
+// groupResource is the scim representation of a miam group. The id and displayName are the group name,
+// which cannot be changed.
+type groupResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []reference `json:"members,omitempty"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+func newGroupResource(r *http.Request, g *group.Group) *groupResource {
+	resource := &groupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          g.GetName(),
+		DisplayName: g.GetName(),
+		Meta:        newMeta(r, "Group", g.GetName(), g.GetCreatedAt(), g.GetUpdatedAt()),
+	}
+	// large groups are commonly fetched without members, as the members are not needed to find the group.
+	if !slices.Contains(strings.Split(strings.ToLower(r.URL.Query().Get("excludedAttributes")), ","), "members") {
+		for _, member := range g.GetMembers() {
+			resource.Members = append(resource.Members, reference{Value: member, Display: member})
+		}
+	}
+	return resource
+}
+
+func memberNames(members []reference) []string {
+	names := []string{}
+	for _, member := range members {
+		names = append(names, member.Value)
+	}
+	return names
+}
+
+// groupAttributes resolves the filterable attributes of a group.
+func groupAttributes(g *group.Group) attributes {
+	return func(attribute string) []string {
+		switch attribute {
+		case "id", "displayname":
+			return []string{g.GetName()}
+		case "members", "members.value":
+			return g.GetMembers()
+		default:
+			return nil
+		}
+	}
+}
+
+func (s *Server) listGroups(r *http.Request) (int, any, error) {
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return 0, nil, err
+	}
+	groups, _, err := s.store.ListGroups(r.Context(), 0, "")
+	if err != nil {
+		return 0, nil, err
+	}
+	resources := []*groupResource{}
+	for _, g := range groups {
+		if f.matches(groupAttributes(g)) {
+			resources = append(resources, newGroupResource(r, g))
+		}
+	}
+	response, err := page(r, resources)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, response, nil
+}
+
+func (s *Server) getGroup(r *http.Request) (int, any, error) {
+	g, err := s.store.GetGroup(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, newGroupResource(r, g), nil
+}
+
+func (s *Server) createGroup(r *http.Request) (int, any, error) {
+	resource := &groupResource{}
+	if err := decode(r, resource); err != nil {
+		return 0, nil, err
+	}
+	g := &group.Group{Name: resource.DisplayName}
+	if err := validateMessage(&group.CreateRequest{Group: g}); err != nil {
+		return 0, nil, err
+	}
+	members := memberNames(resource.Members)
+	if err := s.checkMembers(r, members); err != nil {
+		return 0, nil, err
+	}
+	g, err := s.store.CreateGroup(r.Context(), g)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := s.setMembers(r, g.GetName(), nil, members); err != nil {
+		return 0, nil, err
+	}
+	r.SetPathValue("id", g.GetName())
+	_, response, err := s.getGroup(r)
+	return http.StatusCreated, response, err
+}
+
+func (s *Server) replaceGroup(r *http.Request) (int, any, error) {
+	resource := &groupResource{}
+	if err := decode(r, resource); err != nil {
+		return 0, nil, err
+	}
+	if resource.DisplayName != r.PathValue("id") {
+		return 0, nil, badRequest("mutability", "displayName cannot be changed")
+	}
+	g, err := s.store.GetGroup(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	members := memberNames(resource.Members)
+	if err := s.checkMembers(r, members); err != nil {
+		return 0, nil, err
+	}
+	if err := s.setMembers(r, g.GetName(), g.GetMembers(), members); err != nil {
+		return 0, nil, err
+	}
+	return s.getGroup(r)
+}
+
+func (s *Server) patchGroup(r *http.Request) (int, any, error) {
+	patch := &patchRequest{}
+	if err := decode(r, patch); err != nil {
+		return 0, nil, err
+	}
+	g, err := s.store.GetGroup(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	members := g.GetMembers()
+	for _, operation := range patch.Operations {
+		op, err := operation.op()
+		if err != nil {
+			return 0, nil, err
+		}
+		if operation.Path == "" {
+			values, err := operation.object()
+			if err != nil {
+				return 0, nil, err
+			}
+			for attribute, value := range values {
+				members, err = patchGroupAttribute(g, members, attribute, op, value)
+				if err != nil {
+					return 0, nil, err
+				}
+			}
+			continue
+		}
+		members, err = patchGroupAttribute(g, members, operation.Path, op, operation.Value)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	if err := s.checkMembers(r, members); err != nil {
+		return 0, nil, err
+	}
+	if err := s.setMembers(r, g.GetName(), g.GetMembers(), members); err != nil {
+		return 0, nil, err
+	}
+	return s.getGroup(r)
+}
+
+// patchGroupAttribute applies a patch operation to the members or the name of the group
+// and returns the resulting members.
+func patchGroupAttribute(g *group.Group, members []string, path, op string, value json.RawMessage) ([]string, error) {
+	if match := memberPathPattern.FindStringSubmatch(path); match != nil {
+		if op != "remove" {
+			return nil, badRequest("invalidPath", "single members can only be removed")
+		}
+		return slices.DeleteFunc(members, func(member string) bool { return member == match[1] }), nil
+	}
+	switch strings.ToLower(path) {
+	case "displayname":
+		name := ""
+		if err := json.Unmarshal(value, &name); err != nil || name != g.GetName() || op == "remove" {
+			return nil, badRequest("mutability", "displayName cannot be changed")
+		}
+		return members, nil
+	case "members":
+		if op == "remove" && len(value) == 0 {
+			return []string{}, nil
+		}
+		references := []reference{}
+		if err := json.Unmarshal(value, &references); err != nil {
+			return nil, badRequest("invalidValue", "members must be a list of members")
+		}
+		changed := memberNames(references)
+		switch op {
+		case "add":
+			return append(members, changed...), nil
+		case "replace":
+			return changed, nil
+		default:
+			return slices.DeleteFunc(members, func(member string) bool { return slices.Contains(changed, member) }), nil
+		}
+	default:
+		return nil, badRequest("invalidPath", "unsupported attribute '%s'", path)
+	}
+}
+
+// checkMembers ensures that all members are known users, otherwise typos would silently grant nothing.
+func (s *Server) checkMembers(r *http.Request, members []string) error {
+	for _, member := range members {
+		if _, _, err := s.store.GetUser(r.Context(), member); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return badRequest("invalidValue", "member '%s' is not a known user", member)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// setMembers adds and removes members until the group consists of the desired members.
+func (s *Server) setMembers(r *http.Request, name string, current, desired []string) error {
+	for _, member := range desired {
+		if !slices.Contains(current, member) {
+			if err := s.store.AddGroupMember(r.Context(), name, member); err != nil {
+				return err
+			}
+		}
+	}
+	for _, member := range current {
+		if !slices.Contains(desired, member) {
+			if err := s.store.RemoveGroupMember(r.Context(), name, member); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteGroup(r *http.Request) (int, any, error) {
+	if err := s.store.DeleteGroup(r.Context(), r.PathValue("id")); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
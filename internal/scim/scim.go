@@ -0,0 +1,351 @@
+// Package scim implements a scim 2.0 provisioning endpoint (rfc 7643, rfc 7644) for the users and groups of miam.
+package scim
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/interceptor"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// BasePath is the path prefix of all scim endpoints.
+	BasePath = "/scim/v2"
+
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaList                  = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatch                 = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	contentType = "application/scim+json"
+	// maxBodySize limits the size of request bodies (large group member lists fit easily).
+	maxBodySize = 1 << 20
+	// maxCount is the maximum number of resources returned per page.
+	maxCount     = 1000
+	defaultCount = 100
+)
+
+// Server serves the scim endpoints on top of the identity store. Requests are authenticated with the verifiers,
+// authorized with the actions of the equivalent api procedures (e.g. user:Create) and limited by the rate limiter
+// per caller and endpoint. Mutating requests are recorded in the audit log.
+type Server struct {
+	store      *store.Store
+	authorizer *auth.Authorizer
+	verifiers  []auth.Verifier
+	limiter    *interceptor.RateLimiter
+	record     interceptor.AuditRecorder
+}
+
+// New creates a scim server managing the users and groups in the store.
+// Fails without verifiers, the endpoints manage identities and are never served unauthenticated.
+func New(store *store.Store, authorizer *auth.Authorizer, verifiers []auth.Verifier, limiter *interceptor.RateLimiter, record interceptor.AuditRecorder) (*Server, error) {
+	if len(verifiers) < 1 {
+		return nil, fmt.Errorf("scim endpoints require at least one verifier")
+	}
+	return &Server{
+		store:      store,
+		authorizer: authorizer,
+		verifiers:  verifiers,
+		limiter:    limiter,
+		record:     record,
+	}, nil
+}
+
+// Register adds the scim endpoints to the mux.
+func (s *Server) Register(mux *http.ServeMux) {
+	mux.Handle("GET "+BasePath+"/ServiceProviderConfig", s.handle("", s.serviceProviderConfig))
+	mux.Handle("GET "+BasePath+"/Users", s.handle("user:List", s.listUsers))
+	mux.Handle("POST "+BasePath+"/Users", s.handle("user:Create", s.createUser))
+	mux.Handle("GET "+BasePath+"/Users/{id}", s.handle("user:Get", s.getUser))
+	mux.Handle("PUT "+BasePath+"/Users/{id}", s.handle("user:Update", s.replaceUser))
+	mux.Handle("PATCH "+BasePath+"/Users/{id}", s.handle("user:Update", s.patchUser))
+	mux.Handle("DELETE "+BasePath+"/Users/{id}", s.handle("user:Delete", s.deleteUser))
+	mux.Handle("GET "+BasePath+"/Groups", s.handle("group:List", s.listGroups))
+	mux.Handle("POST "+BasePath+"/Groups", s.handle("group:Create", s.createGroup))
+	mux.Handle("GET "+BasePath+"/Groups/{id}", s.handle("group:Get", s.getGroup))
+	mux.Handle("PUT "+BasePath+"/Groups/{id}", s.handle("group:Update", s.replaceGroup))
+	mux.Handle("PATCH "+BasePath+"/Groups/{id}", s.handle("group:Update", s.patchGroup))
+	mux.Handle("DELETE "+BasePath+"/Groups/{id}", s.handle("group:Delete", s.deleteGroup))
+}
+
+// scimError is an error response as defined in rfc 7644 section 3.12.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func badRequest(scimType, format string, args ...any) *scimError {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+// handlerFunc handles a scim request and returns the status and body of the response.
+type handlerFunc func(r *http.Request) (int, any, error)
+
+// handle authenticates and authorizes the request for the action and records mutating requests.
+// Actions are global (e.g. "user:Create"), an empty action only requires authentication.
+func (s *Server) handle(action string, handler handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		if err != nil {
+			writeError(w, r, &scimError{status: http.StatusRequestEntityTooLarge, detail: "request body is too large"})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var (
+			status   int
+			response any
+		)
+		ctx, err := s.authorize(r, action)
+		if ctx != nil {
+			// rejected requests of authenticated callers are attributed to the caller too.
+			r = r.WithContext(ctx)
+		}
+		if delay, ok := s.limiter.Reserve(s.limiter.Caller(r.Context(), r.RemoteAddr, r.Header), r.Pattern); !ok {
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			writeError(w, r, &scimError{
+				status: http.StatusTooManyRequests,
+				detail: fmt.Sprintf("rate limit exceeded, retry in %ds", retryAfter),
+			})
+			return
+		}
+		if err == nil {
+			status, response, err = handler(r)
+		}
+		if r.Method != http.MethodGet {
+			s.audit(r, body, err)
+		}
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if response == nil {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+// authorize returns the request context carrying the identity and principal of the caller.
+// The context is returned with the error if the authenticated caller is not permitted to call the action.
+func (s *Server) authorize(r *http.Request, action string) (context.Context, error) {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, &scimError{status: http.StatusUnauthorized, detail: "missing bearer token"}
+	}
+	identity, err := auth.Authenticate(r.Context(), s.verifiers, token)
+	if err != nil {
+		return nil, &scimError{status: http.StatusUnauthorized, detail: "invalid bearer token"}
+	}
+	ctx := auth.WithPrincipal(auth.WithIdentity(r.Context(), identity), s.authorizer.Principal(identity))
+	if action == "" {
+		return ctx, nil
+	}
+	decision, err := s.authorizer.Check(ctx, identity, action, nil)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to authorize request: %v", err)
+	}
+	if !decision.Allowed {
+		return ctx, &scimError{
+			status: http.StatusForbidden,
+			detail: fmt.Sprintf("'%s' is not permitted to call %s", identity.Name(), action),
+		}
+	}
+	return ctx, nil
+}
+
+// audit records the request in the audit log, the procedure is the method and path of the request.
+func (s *Server) audit(r *http.Request, body []byte, err error) {
+	digest := sha256.Sum256(body)
+	entry := &audit.AuditEntry{
+		Time:          timestamppb.Now(),
+		Actor:         auth.PrincipalFrom(r.Context(), r.RemoteAddr),
+		Procedure:     r.Method + " " + r.URL.Path,
+		RequestDigest: hex.EncodeToString(digest[:]),
+		Code:          "ok",
+	}
+	if err != nil {
+		entry.Code = errorCode(err)
+	}
+	if auditErr := s.record(context.WithoutCancel(r.Context()), entry); auditErr != nil {
+		slog.ErrorContext(r.Context(), "failed to record audit entry", "procedure", entry.Procedure, "error", auditErr)
+	}
+}
+
+// errorCode returns the connect code name equivalent to the error, so that audit entries are uniform.
+func errorCode(err error) string {
+	switch status := toSCIMError(err).status; status {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return "invalid_argument"
+	case http.StatusUnauthorized:
+		return "unauthenticated"
+	case http.StatusForbidden:
+		return "permission_denied"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "already_exists"
+	default:
+		return "internal"
+	}
+}
+
+// toSCIMError translates store errors into their scim equivalent.
+func toSCIMError(err error) *scimError {
+	var scimErr *scimError
+	switch {
+	case errors.As(err, &scimErr):
+		return scimErr
+	case errors.Is(err, store.ErrNotFound):
+		return &scimError{status: http.StatusNotFound, detail: "resource not found"}
+	case errors.Is(err, store.ErrAlreadyExists):
+		return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "resource already exists"}
+	default:
+		// internal errors are logged by writeError, their details are not exposed to the client.
+		return &scimError{status: http.StatusInternalServerError, detail: "internal server error"}
+	}
+}
+
+// writeError writes the scim error response equivalent to the error, internal errors are logged.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	scimErr := toSCIMError(err)
+	if scimErr.status == http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "failed to handle scim request", "method", r.Method, "path", r.URL.Path, "error", err)
+	}
+	body := map[string]any{
+		"schemas": []string{schemaError},
+		"status":  strconv.Itoa(scimErr.status),
+		"detail":  scimErr.detail,
+	}
+	if scimErr.scimType != "" {
+		body["scimType"] = scimErr.scimType
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(scimErr.status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// decode parses the json body of the request into v.
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest("invalidSyntax", "failed to parse request body: %v", err)
+	}
+	return nil
+}
+
+// meta is the resource metadata of rfc 7643 section 3.1.
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location"`
+}
+
+func newMeta(r *http.Request, resourceType, id string, created, modified *timestamppb.Timestamp) *meta {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	m := &meta{
+		ResourceType: resourceType,
+		Location:     fmt.Sprintf("%s://%s%s/%ss/%s", scheme, r.Host, BasePath, resourceType, id),
+	}
+	if created != nil {
+		m.Created = created.AsTime().Format(time.RFC3339)
+	}
+	if modified != nil {
+		m.LastModified = modified.AsTime().Format(time.RFC3339)
+	}
+	return m
+}
+
+// reference is a multi valued attribute referencing another resource or value (e.g. members or emails).
+type reference struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// listResponse is a page of resources as defined in rfc 7644 section 3.4.2.
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// page applies the startIndex (1-based) and count query parameters to the matching resources.
+func page[T any](r *http.Request, resources []T) (*listResponse, error) {
+	start, count := 1, defaultCount
+	if value := r.URL.Query().Get("startIndex"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, badRequest("invalidValue", "startIndex must be a number")
+		}
+		start = max(parsed, 1)
+	}
+	if value := r.URL.Query().Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, badRequest("invalidValue", "count must be a number")
+		}
+		count = min(max(parsed, 0), maxCount)
+	}
+	response := &listResponse{
+		Schemas:      []string{schemaList},
+		TotalResults: len(resources),
+		StartIndex:   start,
+		Resources:    []any{},
+	}
+	for i := start - 1; i < len(resources) && len(response.Resources) < count; i++ {
+		response.Resources = append(response.Resources, resources[i])
+	}
+	response.ItemsPerPage = len(response.Resources)
+	return response, nil
+}
+
+func (s *Server) serviceProviderConfig(r *http.Request) (int, any, error) {
+	return http.StatusOK, map[string]any{
+		"schemas":        []string{schemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxCount},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Authentication with a bearer token accepted by the operator",
+		}},
+	}, nil
+}
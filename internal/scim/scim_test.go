@@ -0,0 +1,83 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/interceptor"
+)
+
+// tokenVerifier accepts the token "valid" as the subject "provisioner".
+type tokenVerifier struct{}
+
+func (tokenVerifier) Verify(ctx context.Context, token string) (*auth.Identity, error) {
+	if token != "valid" {
+		return nil, errors.New("invalid token")
+	}
+	return &auth.Identity{Subject: "provisioner"}, nil
+}
+
+func TestNewRequiresVerifiers(t *testing.T) {
+	if _, err := New(nil, auth.NewAuthorizer(nil, nil, 0), nil, nil, nil); err == nil {
+		t.Fatal("scim server was created without verifiers")
+	}
+}
+
+func TestHandle(t *testing.T) {
+	limiter := interceptor.NewRateLimiter(interceptor.RateLimit{Rate: 0.001, Burst: 2}, nil, "")
+	server, err := New(nil, auth.NewAuthorizer(nil, nil, 0), []auth.Verifier{tokenVerifier{}}, limiter, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	server.Register(mux)
+
+	// the steps run in order against the same limiter.
+	tests := []struct {
+		name       string
+		token      string
+		remote     string
+		wantStatus int
+	}{
+		{name: "authenticated request", token: "valid", remote: "192.0.2.1:1000", wantStatus: http.StatusOK},
+		{name: "missing token", remote: "192.0.2.1:1000", wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", token: "invalid", remote: "192.0.2.1:1001", wantStatus: http.StatusUnauthorized},
+		{name: "unauthenticated callers are limited by address", token: "invalid", remote: "192.0.2.1:1002", wantStatus: http.StatusTooManyRequests},
+		{name: "authenticated callers have their own bucket", token: "valid", remote: "192.0.2.1:1003", wantStatus: http.StatusOK},
+		{name: "authenticated callers are limited", token: "valid", remote: "192.0.2.2:1000", wantStatus: http.StatusTooManyRequests},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, BasePath+"/ServiceProviderConfig", nil)
+			req.RemoteAddr = test.remote
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			resp := httptest.NewRecorder()
+			mux.ServeHTTP(resp, req)
+			if resp.Code != test.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", resp.Code, test.wantStatus, resp.Body)
+			}
+			if test.wantStatus == http.StatusTooManyRequests && resp.Header().Get("Retry-After") == "" {
+				t.Fatal("rate limited response has no Retry-After header")
+			}
+		})
+	}
+}
+
+func TestWriteErrorHidesInternalErrors(t *testing.T) {
+	resp := httptest.NewRecorder()
+	writeError(resp, httptest.NewRequest(http.MethodGet, BasePath+"/Users", nil), errors.New("dial tcp 10.0.0.1:443: connection refused"))
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Code != http.StatusInternalServerError || body["detail"] != "internal server error" {
+		t.Fatalf("status = %d, detail = %v, want generic internal error", resp.Code, body["detail"])
+	}
+}
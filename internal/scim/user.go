@@ -0,0 +1,329 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"github.com/megakuul/miam/pkg/api/iam/v1/group"
+	"github.com/megakuul/miam/pkg/api/iam/v1/user"
+	"google.golang.org/protobuf/proto"
+)
+
+// userResource is the scim representation of a miam user. The id and userName are the user name,
+// group memberships are read only and managed through the group resources.
+type userResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	UserName    string      `json:"userName"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []reference `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Groups      []reference `json:"groups,omitempty"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+func newUserResource(r *http.Request, u *user.User, groups []string) *userResource {
+	active := !u.GetDisabled()
+	resource := &userResource{
+		Schemas:     []string{schemaUser},
+		ID:          u.GetName(),
+		UserName:    u.GetName(),
+		DisplayName: u.GetDisplayName(),
+		Active:      &active,
+		Meta:        newMeta(r, "User", u.GetName(), u.GetCreatedAt(), u.GetUpdatedAt()),
+	}
+	if u.GetEmail() != "" {
+		resource.Emails = []reference{{Value: u.GetEmail(), Type: "work", Primary: true}}
+	}
+	for _, g := range groups {
+		resource.Groups = append(resource.Groups, reference{Value: g, Display: g})
+	}
+	return resource
+}
+
+// user converts the resource into a user, users are active unless specified otherwise.
+func (u *userResource) user() *user.User {
+	return &user.User{
+		Name:        u.UserName,
+		DisplayName: u.DisplayName,
+		Email:       primaryEmail(u.Emails),
+		Disabled:    u.Active != nil && !*u.Active,
+	}
+}
+
+func primaryEmail(emails []reference) string {
+	if index := slices.IndexFunc(emails, func(e reference) bool { return e.Primary }); index >= 0 {
+		return emails[index].Value
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
+
+// userAttributes resolves the filterable attributes of a user.
+func userAttributes(u *user.User, groups []string) attributes {
+	return func(attribute string) []string {
+		switch attribute {
+		case "id", "username":
+			return []string{u.GetName()}
+		case "displayname":
+			return []string{u.GetDisplayName()}
+		case "emails", "emails.value":
+			return []string{u.GetEmail()}
+		case "active":
+			return []string{strconv.FormatBool(!u.GetDisabled())}
+		case "groups", "groups.value":
+			return groups
+		default:
+			return nil
+		}
+	}
+}
+
+// memberships maps the user names to the names of their groups.
+func memberships(groups []*group.Group) map[string][]string {
+	members := map[string][]string{}
+	for _, g := range groups {
+		for _, member := range g.GetMembers() {
+			members[member] = append(members[member], g.GetName())
+		}
+	}
+	return members
+}
+
+func (s *Server) listUsers(r *http.Request) (int, any, error) {
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return 0, nil, err
+	}
+	users, _, err := s.store.ListUsers(r.Context(), 0, "")
+	if err != nil {
+		return 0, nil, err
+	}
+	groups, _, err := s.store.ListGroups(r.Context(), 0, "")
+	if err != nil {
+		return 0, nil, err
+	}
+	members := memberships(groups)
+	resources := []*userResource{}
+	for _, u := range users {
+		if f.matches(userAttributes(u, members[u.GetName()])) {
+			resources = append(resources, newUserResource(r, u, members[u.GetName()]))
+		}
+	}
+	response, err := page(r, resources)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, response, nil
+}
+
+func (s *Server) getUser(r *http.Request) (int, any, error) {
+	u, groups, err := s.store.GetUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, newUserResource(r, u, groups), nil
+}
+
+func (s *Server) createUser(r *http.Request) (int, any, error) {
+	resource := &userResource{}
+	if err := decode(r, resource); err != nil {
+		return 0, nil, err
+	}
+	u := resource.user()
+	if err := validateMessage(&user.CreateRequest{User: u}); err != nil {
+		return 0, nil, err
+	}
+	u, err := s.store.CreateUser(r.Context(), u)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, newUserResource(r, u, nil), nil
+}
+
+func (s *Server) replaceUser(r *http.Request) (int, any, error) {
+	resource := &userResource{}
+	if err := decode(r, resource); err != nil {
+		return 0, nil, err
+	}
+	if resource.UserName != r.PathValue("id") {
+		return 0, nil, badRequest("mutability", "userName cannot be changed")
+	}
+	return s.updateUser(r, resource.user())
+}
+
+func (s *Server) patchUser(r *http.Request) (int, any, error) {
+	patch := &patchRequest{}
+	if err := decode(r, patch); err != nil {
+		return 0, nil, err
+	}
+	u, _, err := s.store.GetUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, operation := range patch.Operations {
+		op, err := operation.op()
+		if err != nil {
+			return 0, nil, err
+		}
+		if operation.Path == "" {
+			values, err := operation.object()
+			if err != nil {
+				return 0, nil, err
+			}
+			for attribute, value := range values {
+				if err := patchUserAttribute(u, attribute, op, value); err != nil {
+					return 0, nil, err
+				}
+			}
+			continue
+		}
+		if err := patchUserAttribute(u, operation.Path, op, operation.Value); err != nil {
+			return 0, nil, err
+		}
+	}
+	return s.updateUser(r, u)
+}
+
+// patchUserAttribute applies a patch operation to an attribute of the user.
+// Attributes that miam does not store (e.g. name.givenName) are ignored.
+func patchUserAttribute(u *user.User, path, op string, value json.RawMessage) error {
+	path = strings.ToLower(path)
+	switch {
+	case path == "active":
+		if op == "remove" {
+			return badRequest("mutability", "active cannot be removed")
+		}
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		u.Disabled = !active
+	case path == "displayname":
+		if op == "remove" {
+			u.DisplayName = ""
+			return nil
+		}
+		if err := json.Unmarshal(value, &u.DisplayName); err != nil {
+			return badRequest("invalidValue", "displayName must be a string")
+		}
+	case path == "emails":
+		if op == "remove" {
+			u.Email = ""
+			return nil
+		}
+		emails := []reference{}
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return badRequest("invalidValue", "emails must be a list of emails")
+		}
+		u.Email = primaryEmail(emails)
+	case path == "emails.value", strings.HasPrefix(path, "emails[") && strings.HasSuffix(path, "].value"):
+		if op == "remove" {
+			u.Email = ""
+			return nil
+		}
+		if err := json.Unmarshal(value, &u.Email); err != nil {
+			return badRequest("invalidValue", "email must be a string")
+		}
+	case path == "username":
+		name := ""
+		if err := json.Unmarshal(value, &name); err != nil || name != u.GetName() {
+			return badRequest("mutability", "userName cannot be changed")
+		}
+	}
+	return nil
+}
+
+// updateUser stores the user and returns its resource.
+// The upstreams of the user are not managed through scim and are kept.
+func (s *Server) updateUser(r *http.Request, u *user.User) (int, any, error) {
+	u.Name = r.PathValue("id")
+	current, _, err := s.store.GetUser(r.Context(), u.GetName())
+	if err != nil {
+		return 0, nil, err
+	}
+	u.Upstreams = current.GetUpstreams()
+	if err := validateMessage(&user.UpdateRequest{User: u}); err != nil {
+		return 0, nil, err
+	}
+	if _, err := s.store.UpdateUser(r.Context(), u); err != nil {
+		return 0, nil, err
+	}
+	return s.getUser(r)
+}
+
+func (s *Server) deleteUser(r *http.Request) (int, any, error) {
+	if err := s.store.DeleteUser(r.Context(), r.PathValue("id")); err != nil {
+		return 0, nil, err
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// patchRequest is a patch request as defined in rfc 7644 section 3.5.2.
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// op returns the lower case operation (some providers send "Replace").
+func (o *patchOperation) op() (string, error) {
+	op := strings.ToLower(o.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return "", badRequest("invalidSyntax", "unsupported patch operation '%s'", o.Op)
+	}
+	if op == "remove" && o.Path == "" {
+		return "", badRequest("noTarget", "remove operations require a path")
+	}
+	return op, nil
+}
+
+// object returns the attributes of an operation without path.
+func (o *patchOperation) object() (map[string]json.RawMessage, error) {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(o.Value, &values); err != nil {
+		return nil, badRequest("invalidValue", "operations without path require an object value")
+	}
+	return values, nil
+}
+
+// parseBool accepts json booleans and strings, as some providers send "True" and "False".
+func parseBool(value json.RawMessage) (bool, error) {
+	result := false
+	if err := json.Unmarshal(value, &result); err == nil {
+		return result, nil
+	}
+	text := ""
+	if err := json.Unmarshal(value, &text); err == nil {
+		if result, err := strconv.ParseBool(strings.ToLower(text)); err == nil {
+			return result, nil
+		}
+	}
+	return false, badRequest("invalidValue", "value must be a boolean")
+}
+
+// validateMessage checks the api message equivalent to the scim request against the api rules.
+func validateMessage(msg proto.Message) error {
+	if err := protovalidate.Validate(msg); err != nil {
+		validationErr := &protovalidate.ValidationError{}
+		if errors.As(err, &validationErr) && len(validationErr.Violations) > 0 {
+			violation := validationErr.Violations[0].Proto
+			return badRequest("invalidValue", "%s: %s", protovalidate.FieldPathString(violation.GetField()), violation.GetMessage())
+		}
+		return fmt.Errorf("failed to validate %s: %v", msg.ProtoReflect().Descriptor().Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit"
+)
+
+// AuditService implements the audit log api on top of the store.
+type AuditService struct {
+	store *store.Store
+}
+
+// NewAuditService creates an audit service reading the audit log from the store.
+func NewAuditService(store *store.Store) *AuditService {
+	return &AuditService{store: store}
+}
+
+func (s *AuditService) List(ctx context.Context, req *connect.Request[audit.ListRequest]) (*connect.Response[audit.ListResponse], error) {
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	filter := store.AuditFilter{Actor: req.Msg.GetActor()}
+	if req.Msg.GetStartTime() != nil {
+		filter.Start = req.Msg.GetStartTime().AsTime()
+	}
+	if req.Msg.GetEndTime() != nil {
+		filter.End = req.Msg.GetEndTime().AsTime()
+	}
+	entries, next, err := s.store.ListAudit(ctx, filter, pageSize, req.Msg.GetPageToken())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&audit.ListResponse{
+		Entries:       entries,
+		NextPageToken: next,
+	}), nil
+}
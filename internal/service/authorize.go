@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/iam/v1/authorize"
+)
+
+// AuthorizeService exposes the decisions of the authorizer used by the operator itself.
+type AuthorizeService struct {
+	authorizer *auth.Authorizer
+	store      *store.Store
+}
+
+// NewAuthorizeService creates an authorize service evaluating requests with the authorizer.
+func NewAuthorizeService(authorizer *auth.Authorizer, store *store.Store) *AuthorizeService {
+	return &AuthorizeService{
+		authorizer: authorizer,
+		store:      store,
+	}
+}
+
+func (s *AuthorizeService) Check(ctx context.Context, req *connect.Request[authorize.CheckRequest]) (*connect.Response[authorize.CheckResponse], error) {
+	identity, ok := auth.IdentityFrom(ctx)
+	if req.Msg.GetSubject() != "" {
+		// callers are authenticated unless authentication is disabled, which allows every request.
+		if ok {
+			decision, err := s.authorizer.Check(ctx, identity, auth.ActionCheckOther, nil)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize: %v", err))
+			}
+			if !decision.Allowed {
+				return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf(
+					"'%s' is not permitted to check other principals (requires %s)", identity.Name(), auth.ActionCheckOther,
+				))
+			}
+		}
+		identity = s.authorizer.IdentityOf(req.Msg.GetSubject())
+	} else if !ok {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf(
+			"request is not authenticated, a subject must be specified",
+		))
+	}
+	var resource *auth.Resource
+	if name, ok := strings.CutPrefix(req.Msg.GetResource(), "cluster:"); ok {
+		resource = &auth.Resource{Cluster: name, Tags: req.Msg.GetTags()}
+		if len(resource.Tags) < 1 {
+			tags, err := s.store.ClusterTags(ctx, name)
+			if err != nil {
+				return nil, storeError(err)
+			}
+			resource.Tags = tags
+		}
+	}
+	decision, err := s.authorizer.Check(ctx, identity, req.Msg.GetAction(), resource)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize: %v", err))
+	}
+	return connect.NewResponse(&authorize.CheckResponse{
+		Allowed: decision.Allowed,
+		Reason:  decision.Reason,
+	}), nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/pkg/api/iam/v1/authorize"
+)
+
+func TestAuthorizeServiceCheck(t *testing.T) {
+	authorizer := auth.NewAuthorizer(nil, func(ctx context.Context) (*auth.Directory, error) {
+		return &auth.Directory{
+			Policies: []auth.Policy{
+				{Subjects: []string{"admin@example.com"}, Role: auth.RoleAdmin},
+				{Subjects: []string{"viewer@example.com"}, Role: auth.RoleViewer},
+				{Subjects: []string{"group:deployers"}, Role: auth.RoleOperator},
+			},
+			Groups: map[string][]string{"carol@example.com": {"deployers"}},
+		}, nil
+	}, time.Minute)
+	service := NewAuthorizeService(authorizer, nil)
+
+	tests := []struct {
+		name    string
+		caller  string
+		subject string
+		action  string
+		code    connect.Code
+		allowed bool
+	}{
+		{name: "caller checks itself", caller: "viewer@example.com", action: "cluster:Get", allowed: true},
+		{name: "caller is checked without subject", caller: "viewer@example.com", action: "cluster:Update", allowed: false},
+		{name: "viewer checks other subject", caller: "viewer@example.com", subject: "admin@example.com", action: "cluster:Get", code: connect.CodePermissionDenied},
+		{name: "admin checks other subject", caller: "admin@example.com", subject: "viewer@example.com", action: "cluster:Update", allowed: false},
+		{name: "groups of subject come from the directory", caller: "admin@example.com", subject: "carol@example.com", action: "cluster:Update", allowed: true},
+		{name: "unauthenticated without subject", action: "cluster:Get", code: connect.CodeFailedPrecondition},
+		{name: "unauthenticated with subject", subject: "carol@example.com", action: "cluster:Update", allowed: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.caller != "" {
+				ctx = auth.WithIdentity(ctx, &auth.Identity{Subject: test.caller})
+			}
+			resp, err := service.Check(ctx, connect.NewRequest(&authorize.CheckRequest{
+				Subject: test.subject,
+				Action:  test.action,
+			}))
+			if test.code != 0 {
+				if code := connect.CodeOf(err); code != test.code {
+					t.Fatalf("code = %v, want %v (%v)", code, test.code, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Msg.Allowed != test.allowed {
+				t.Fatalf("allowed = %v, want %v (%s)", resp.Msg.Allowed, test.allowed, resp.Msg.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/reconciler"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultPageSize is the number of items returned by list operations if the client specifies no page size.
+const defaultPageSize = 100
+
+// ClusterService implements the cluster api on top of the revision store.
+type ClusterService struct {
+	store      *store.Store
+	reconciler *reconciler.Reconciler
+}
+
+// NewClusterService creates a cluster service that hands deployments to the reconciler.
+func NewClusterService(store *store.Store, reconciler *reconciler.Reconciler) *ClusterService {
+	return &ClusterService{
+		store:      store,
+		reconciler: reconciler,
+	}
+}
+
+func (s *ClusterService) List(ctx context.Context, req *connect.Request[cluster.ListRequest]) (*connect.Response[cluster.ListResponse], error) {
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	clusters, next, err := s.store.ListClusters(ctx, store.ClusterFilter{
+		Tags:       req.Msg.GetTags(),
+		States:     req.Msg.GetStates(),
+		NamePrefix: req.Msg.GetNamePrefix(),
+		Descending: req.Msg.GetDescending(),
+	}, pageSize, req.Msg.GetPageToken())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&cluster.ListResponse{
+		Clusters:      clusters,
+		NextPageToken: next,
+	}), nil
+}
+
+func (s *ClusterService) Get(ctx context.Context, req *connect.Request[cluster.GetRequest]) (*connect.Response[cluster.GetResponse], error) {
+	revisions, err := s.store.GetCluster(ctx, req.Msg.GetName())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&cluster.GetResponse{Revisions: revisions}), nil
+}
+
+func (s *ClusterService) Describe(ctx context.Context, req *connect.Request[cluster.DescribeRequest]) (*connect.Response[cluster.DescribeResponse], error) {
+	_, config, err := s.store.DescribeCluster(ctx, req.Msg.GetName(), req.Msg.GetRevision())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&cluster.DescribeResponse{Config: config}), nil
+}
+
+func (s *ClusterService) Update(ctx context.Context, req *connect.Request[cluster.UpdateRequest]) (*connect.Response[cluster.UpdateResponse], error) {
+	config := req.Msg.GetConfig()
+	revision, err := s.deploy(ctx, config, reconciler.ActionUp, author(ctx, req), req.Msg.GetMessage())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&cluster.UpdateResponse{Revision: revision}), nil
+}
+
+func (s *ClusterService) Destroy(ctx context.Context, req *connect.Request[cluster.DestroyRequest]) (*connect.Response[cluster.DestroyResponse], error) {
+	_, config, err := s.store.DescribeCluster(ctx, req.Msg.GetName(), "")
+	if err != nil {
+		return nil, storeError(err)
+	}
+	revision, err := s.deploy(ctx, config, reconciler.ActionDestroy, author(ctx, req), req.Msg.GetMessage())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&cluster.DestroyResponse{Revision: revision}), nil
+}
+
+func (s *ClusterService) Cancel(ctx context.Context, req *connect.Request[cluster.CancelRequest]) (*connect.Response[cluster.CancelResponse], error) {
+	status, _, err := s.store.DescribeCluster(ctx, req.Msg.GetName(), req.Msg.GetRevision())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if status.GetState() != cluster.State_DEPLOYING {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf(
+			"revision is not deploying (state: %s)", status.GetState(),
+		))
+	}
+	job := reconciler.Job{
+		Target:   reconciler.TargetCluster,
+		Name:     req.Msg.GetName(),
+		Revision: req.Msg.GetRevision(),
+	}
+	if !s.reconciler.Cancel(job) {
+		// the revision is owned by another operator instance or was abandoned by a stopped one.
+		if err := s.reconciler.Release(ctx, job, req.Msg.GetForce()); err != nil {
+			return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf(
+				"failed to release the revision, it is left deploying: %v", err,
+			))
+		}
+	}
+	return connect.NewResponse(&cluster.CancelResponse{}), nil
+}
+
+func (s *ClusterService) Prune(ctx context.Context, req *connect.Request[cluster.PruneRequest]) (*connect.Response[cluster.PruneResponse], error) {
+	var (
+		pruned []*cluster.ClusterStatus
+		err    error
+	)
+	if req.Msg.GetName() == "" {
+		pruned, err = s.reconciler.PruneClusters(ctx)
+	} else {
+		pruned, err = s.reconciler.Prune(ctx, req.Msg.GetName())
+	}
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&cluster.PruneResponse{Revisions: pruned}), nil
+}
+
+// deploy records a new deploying revision of the cluster and enqueues it on the reconciler.
+func (s *ClusterService) deploy(ctx context.Context, config *cluster.ClusterConfig, action reconciler.Action, author, message string) (string, error) {
+	revision := newRevision()
+	now := timestamppb.Now()
+	status := &cluster.ClusterStatus{
+		Name:      config.GetName(),
+		Revision:  revision,
+		Tags:      config.GetTags(),
+		State:     cluster.State_DEPLOYING,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: author,
+		Message:   message,
+	}
+	if err := s.store.PutCluster(ctx, status, config); err != nil {
+		return "", storeError(err)
+	}
+	err := s.reconciler.Enqueue(ctx, reconciler.Job{
+		Target:   reconciler.TargetCluster,
+		Action:   action,
+		Name:     config.GetName(),
+		Revision: revision,
+	})
+	if err != nil {
+		if stateErr := s.store.SetClusterState(ctx, config.GetName(), revision, cluster.State_FAILED, err.Error()); stateErr != nil {
+			// the revision remains deploying and blocks new deployments until it is cancelled.
+			slog.ErrorContext(ctx, "failed to record rejected deployment", "revision", revision, "error", stateErr)
+		}
+		return "", connect.NewError(connect.CodeUnavailable, err)
+	}
+	return revision, nil
+}
+
+// storeError translates store errors into their connect equivalent.
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, store.ErrInvalidToken):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, store.ErrAlreadyExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, store.ErrConflict):
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("revision is still deploying: %v", err))
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
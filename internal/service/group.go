@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/iam/v1/group"
+)
+
+// GroupService implements the group api on top of the identity store.
+type GroupService struct {
+	store *store.Store
+}
+
+// NewGroupService creates a group service managing the groups in the store.
+func NewGroupService(store *store.Store) *GroupService {
+	return &GroupService{store: store}
+}
+
+func (s *GroupService) List(ctx context.Context, req *connect.Request[group.ListRequest]) (*connect.Response[group.ListResponse], error) {
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	groups, next, err := s.store.ListGroups(ctx, pageSize, req.Msg.GetPageToken())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&group.ListResponse{
+		Groups:        groups,
+		NextPageToken: next,
+	}), nil
+}
+
+func (s *GroupService) Get(ctx context.Context, req *connect.Request[group.GetRequest]) (*connect.Response[group.GetResponse], error) {
+	g, err := s.store.GetGroup(ctx, req.Msg.GetName())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&group.GetResponse{Group: g}), nil
+}
+
+func (s *GroupService) Create(ctx context.Context, req *connect.Request[group.CreateRequest]) (*connect.Response[group.CreateResponse], error) {
+	g, err := s.store.CreateGroup(ctx, req.Msg.GetGroup())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&group.CreateResponse{Group: g}), nil
+}
+
+func (s *GroupService) Update(ctx context.Context, req *connect.Request[group.UpdateRequest]) (*connect.Response[group.UpdateResponse], error) {
+	g, err := s.store.UpdateGroup(ctx, req.Msg.GetGroup())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&group.UpdateResponse{Group: g}), nil
+}
+
+func (s *GroupService) Delete(ctx context.Context, req *connect.Request[group.DeleteRequest]) (*connect.Response[group.DeleteResponse], error) {
+	if err := s.store.DeleteGroup(ctx, req.Msg.GetName()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&group.DeleteResponse{}), nil
+}
+
+func (s *GroupService) AddMember(ctx context.Context, req *connect.Request[group.AddMemberRequest]) (*connect.Response[group.AddMemberResponse], error) {
+	// members must be known users, otherwise typos would silently grant nothing.
+	if _, _, err := s.store.GetUser(ctx, req.Msg.GetUser()); err != nil {
+		return nil, storeError(err)
+	}
+	if err := s.store.AddGroupMember(ctx, req.Msg.GetGroup(), req.Msg.GetUser()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&group.AddMemberResponse{}), nil
+}
+
+func (s *GroupService) RemoveMember(ctx context.Context, req *connect.Request[group.RemoveMemberRequest]) (*connect.Response[group.RemoveMemberResponse], error) {
+	if err := s.store.RemoveGroupMember(ctx, req.Msg.GetGroup(), req.Msg.GetUser()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&group.RemoveMemberResponse{}), nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/reconciler"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaintenanceService implements the operator self-maintenance api on top of the revision store.
+type MaintenanceService struct {
+	store      *store.Store
+	reconciler *reconciler.Reconciler
+	source     string
+}
+
+// NewMaintenanceService creates a maintenance service that deploys the operator from source by default.
+func NewMaintenanceService(store *store.Store, reconciler *reconciler.Reconciler, source string) *MaintenanceService {
+	return &MaintenanceService{
+		store:      store,
+		reconciler: reconciler,
+		source:     source,
+	}
+}
+
+func (s *MaintenanceService) Get(ctx context.Context, req *connect.Request[operator.GetRequest]) (*connect.Response[operator.GetResponse], error) {
+	revisions, err := s.store.GetOperator(ctx)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&operator.GetResponse{Revisions: revisions}), nil
+}
+
+func (s *MaintenanceService) Describe(ctx context.Context, req *connect.Request[operator.DescribeRequest]) (*connect.Response[operator.DescribeResponse], error) {
+	_, config, err := s.store.DescribeOperator(ctx, req.Msg.GetRevision())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&operator.DescribeResponse{Config: config}), nil
+}
+
+func (s *MaintenanceService) Update(ctx context.Context, req *connect.Request[operator.UpdateRequest]) (*connect.Response[operator.UpdateResponse], error) {
+	config := req.Msg.GetConfig()
+	if config.GetRepoUrl() == "" {
+		config.RepoUrl = s.source
+	}
+	revision, err := s.deploy(ctx, config, reconciler.ActionUp, author(ctx, req), req.Msg.GetMessage())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&operator.UpdateResponse{Revision: revision}), nil
+}
+
+func (s *MaintenanceService) Destroy(ctx context.Context, req *connect.Request[operator.DestroyRequest]) (*connect.Response[operator.DestroyResponse], error) {
+	config := &operator.OperatorConfig{RepoUrl: s.source}
+	revision, err := s.deploy(ctx, config, reconciler.ActionDestroy, author(ctx, req), req.Msg.GetMessage())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&operator.DestroyResponse{Revision: revision}), nil
+}
+
+func (s *MaintenanceService) Cancel(ctx context.Context, req *connect.Request[operator.CancelRequest]) (*connect.Response[operator.CancelResponse], error) {
+	status, _, err := s.store.DescribeOperator(ctx, req.Msg.GetRevision())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if status.GetState() != operator.State_DEPLOYING {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf(
+			"revision is not deploying (state: %s)", status.GetState(),
+		))
+	}
+	job := reconciler.Job{
+		Target:   reconciler.TargetOperator,
+		Revision: req.Msg.GetRevision(),
+	}
+	if !s.reconciler.Cancel(job) {
+		// the revision is owned by another operator instance or was abandoned by a stopped one.
+		if err := s.reconciler.Release(ctx, job, req.Msg.GetForce()); err != nil {
+			return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf(
+				"failed to release the revision, it is left deploying: %v", err,
+			))
+		}
+	}
+	return connect.NewResponse(&operator.CancelResponse{}), nil
+}
+
+func (s *MaintenanceService) Prune(ctx context.Context, req *connect.Request[operator.PruneRequest]) (*connect.Response[operator.PruneResponse], error) {
+	pruned, err := s.reconciler.PruneOperator(ctx)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&operator.PruneResponse{Revisions: pruned}), nil
+}
+
+// deploy records a new deploying revision of the operator and enqueues it on the reconciler.
+func (s *MaintenanceService) deploy(ctx context.Context, config *operator.OperatorConfig, action reconciler.Action, author, message string) (string, error) {
+	revision := newRevision()
+	now := timestamppb.Now()
+	status := &operator.OperatorStatus{
+		Revision:  revision,
+		State:     operator.State_DEPLOYING,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: author,
+		Message:   message,
+	}
+	if err := s.store.PutOperator(ctx, status, config); err != nil {
+		return "", storeError(err)
+	}
+	err := s.reconciler.Enqueue(ctx, reconciler.Job{
+		Target:   reconciler.TargetOperator,
+		Action:   action,
+		Revision: revision,
+	})
+	if err != nil {
+		if stateErr := s.store.SetOperatorState(ctx, revision, operator.State_FAILED, err.Error()); stateErr != nil {
+			slog.ErrorContext(ctx, "failed to record rejected deployment", "revision", revision, "error", stateErr)
+		}
+		return "", connect.NewError(connect.CodeUnavailable, err)
+	}
+	return revision, nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/oklog/ulid/v2"
+)
+
+// newRevision generates a lexicographically sortable revision identifier (ulid).
+// Revisions generated by one process are strictly increasing, even within the same millisecond.
+func newRevision() string {
+	return ulid.Make().String()
+}
+
+// author returns the principal recorded as creator of the revisions requested by req, it matches the audit actor.
+// Unauthenticated requests are attributed to the peer address.
+func author(ctx context.Context, req connect.AnyRequest) string {
+	return auth.PrincipalFrom(ctx, req.Peer().Addr)
+}
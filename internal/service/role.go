@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/iam/v1/role"
+	"github.com/megakuul/miam/pkg/policy"
+)
+
+// RoleService implements the role and role binding api on top of the identity store.
+type RoleService struct {
+	store *store.Store
+}
+
+// NewRoleService creates a role service managing the custom roles and bindings in the store.
+func NewRoleService(store *store.Store) *RoleService {
+	return &RoleService{store: store}
+}
+
+// builtinRole returns the builtin role with the specified name, or nil if there is none.
+func builtinRole(name string) *role.Role {
+	document, ok := auth.BuiltinRoles[name]
+	if !ok {
+		return nil
+	}
+	return &role.Role{
+		Name:        name,
+		Description: fmt.Sprintf("builtin %s role", name),
+		Statements:  document.Proto(),
+		Builtin:     true,
+	}
+}
+
+func (s *RoleService) List(ctx context.Context, req *connect.Request[role.ListRequest]) (*connect.Response[role.ListResponse], error) {
+	roles, err := s.store.ListRoles(ctx)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	for name := range auth.BuiltinRoles {
+		roles = append(roles, builtinRole(name))
+	}
+	slices.SortFunc(roles, func(a, b *role.Role) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return connect.NewResponse(&role.ListResponse{Roles: roles}), nil
+}
+
+func (s *RoleService) Get(ctx context.Context, req *connect.Request[role.GetRequest]) (*connect.Response[role.GetResponse], error) {
+	if r := builtinRole(req.Msg.GetName()); r != nil {
+		return connect.NewResponse(&role.GetResponse{Role: r}), nil
+	}
+	r, err := s.store.GetRole(ctx, req.Msg.GetName())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&role.GetResponse{Role: r}), nil
+}
+
+func (s *RoleService) Create(ctx context.Context, req *connect.Request[role.CreateRequest]) (*connect.Response[role.CreateResponse], error) {
+	if builtinRole(req.Msg.GetRole().GetName()) != nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("role '%s' is builtin", req.Msg.GetRole().GetName()))
+	}
+	if err := validatePolicy(req.Msg.GetRole()); err != nil {
+		return nil, err
+	}
+	r, err := s.store.CreateRole(ctx, req.Msg.GetRole())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&role.CreateResponse{Role: r}), nil
+}
+
+func (s *RoleService) Update(ctx context.Context, req *connect.Request[role.UpdateRequest]) (*connect.Response[role.UpdateResponse], error) {
+	if builtinRole(req.Msg.GetRole().GetName()) != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("builtin role '%s' cannot be changed", req.Msg.GetRole().GetName()))
+	}
+	if err := validatePolicy(req.Msg.GetRole()); err != nil {
+		return nil, err
+	}
+	r, err := s.store.UpdateRole(ctx, req.Msg.GetRole())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&role.UpdateResponse{Role: r}), nil
+}
+
+func (s *RoleService) Delete(ctx context.Context, req *connect.Request[role.DeleteRequest]) (*connect.Response[role.DeleteResponse], error) {
+	if builtinRole(req.Msg.GetName()) != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("builtin role '%s' cannot be deleted", req.Msg.GetName()))
+	}
+	if err := s.store.DeleteRole(ctx, req.Msg.GetName()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&role.DeleteResponse{}), nil
+}
+
+func (s *RoleService) ListBindings(ctx context.Context, req *connect.Request[role.ListBindingsRequest]) (*connect.Response[role.ListBindingsResponse], error) {
+	bindings, err := s.store.ListBindings(ctx)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	bindings = slices.DeleteFunc(bindings, func(b *role.RoleBinding) bool {
+		return (req.Msg.GetRole() != "" && b.GetRole() != req.Msg.GetRole()) ||
+			(req.Msg.GetSubject() != "" && b.GetSubject() != req.Msg.GetSubject())
+	})
+	return connect.NewResponse(&role.ListBindingsResponse{Bindings: bindings}), nil
+}
+
+func (s *RoleService) CreateBinding(ctx context.Context, req *connect.Request[role.CreateBindingRequest]) (*connect.Response[role.CreateBindingResponse], error) {
+	if builtinRole(req.Msg.GetBinding().GetRole()) == nil {
+		if _, err := s.store.GetRole(ctx, req.Msg.GetBinding().GetRole()); err != nil {
+			return nil, storeError(err)
+		}
+	}
+	b, err := s.store.CreateBinding(ctx, req.Msg.GetBinding())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&role.CreateBindingResponse{Binding: b}), nil
+}
+
+func (s *RoleService) DeleteBinding(ctx context.Context, req *connect.Request[role.DeleteBindingRequest]) (*connect.Response[role.DeleteBindingResponse], error) {
+	if err := s.store.DeleteBinding(ctx, req.Msg.GetId()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&role.DeleteBindingResponse{}), nil
+}
+
+// validatePolicy checks the patterns of the role policy, which cannot be expressed as field rules of the api.
+func validatePolicy(r *role.Role) error {
+	if err := policy.FromProto(r.GetStatements()).Validate(); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("role.statements: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/iam/v1/serviceaccount"
+)
+
+// ServiceAccountService implements the service account api on top of the identity store.
+type ServiceAccountService struct {
+	store *store.Store
+}
+
+// NewServiceAccountService creates a service account service managing the accounts and api keys in the store.
+func NewServiceAccountService(store *store.Store) *ServiceAccountService {
+	return &ServiceAccountService{store: store}
+}
+
+func (s *ServiceAccountService) List(ctx context.Context, req *connect.Request[serviceaccount.ListRequest]) (*connect.Response[serviceaccount.ListResponse], error) {
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	accounts, next, err := s.store.ListServiceAccounts(ctx, pageSize, req.Msg.GetPageToken())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.ListResponse{
+		ServiceAccounts: accounts,
+		NextPageToken:   next,
+	}), nil
+}
+
+func (s *ServiceAccountService) Get(ctx context.Context, req *connect.Request[serviceaccount.GetRequest]) (*connect.Response[serviceaccount.GetResponse], error) {
+	account, err := s.store.GetServiceAccount(ctx, req.Msg.GetName())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.GetResponse{ServiceAccount: account}), nil
+}
+
+func (s *ServiceAccountService) Create(ctx context.Context, req *connect.Request[serviceaccount.CreateRequest]) (*connect.Response[serviceaccount.CreateResponse], error) {
+	account, err := s.store.CreateServiceAccount(ctx, req.Msg.GetServiceAccount())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.CreateResponse{ServiceAccount: account}), nil
+}
+
+func (s *ServiceAccountService) Update(ctx context.Context, req *connect.Request[serviceaccount.UpdateRequest]) (*connect.Response[serviceaccount.UpdateResponse], error) {
+	account, err := s.store.UpdateServiceAccount(ctx, req.Msg.GetServiceAccount())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.UpdateResponse{ServiceAccount: account}), nil
+}
+
+func (s *ServiceAccountService) Delete(ctx context.Context, req *connect.Request[serviceaccount.DeleteRequest]) (*connect.Response[serviceaccount.DeleteResponse], error) {
+	if err := s.store.DeleteServiceAccount(ctx, req.Msg.GetName()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.DeleteResponse{}), nil
+}
+
+func (s *ServiceAccountService) CreateKey(ctx context.Context, req *connect.Request[serviceaccount.CreateKeyRequest]) (*connect.Response[serviceaccount.CreateKeyResponse], error) {
+	if expires := req.Msg.GetKey().GetExpiresAt(); expires != nil && !expires.AsTime().After(time.Now()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("key.expires_at must be in the future"))
+	}
+	key, secret, err := s.store.CreateAPIKey(ctx, req.Msg.GetKey())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.CreateKeyResponse{Key: key, Secret: secret}), nil
+}
+
+func (s *ServiceAccountService) ListKeys(ctx context.Context, req *connect.Request[serviceaccount.ListKeysRequest]) (*connect.Response[serviceaccount.ListKeysResponse], error) {
+	keys, err := s.store.ListAPIKeys(ctx, req.Msg.GetServiceAccount())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.ListKeysResponse{Keys: keys}), nil
+}
+
+func (s *ServiceAccountService) RevokeKey(ctx context.Context, req *connect.Request[serviceaccount.RevokeKeyRequest]) (*connect.Response[serviceaccount.RevokeKeyResponse], error) {
+	if err := s.store.RevokeAPIKey(ctx, req.Msg.GetId()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&serviceaccount.RevokeKeyResponse{}), nil
+}
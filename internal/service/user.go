@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/megakuul/miam/internal/store"
+	"github.com/megakuul/miam/pkg/api/iam/v1/user"
+)
+
+// UserService implements the user api on top of the identity store.
+type UserService struct {
+	store *store.Store
+}
+
+// NewUserService creates a user service managing the users in the store.
+func NewUserService(store *store.Store) *UserService {
+	return &UserService{store: store}
+}
+
+func (s *UserService) List(ctx context.Context, req *connect.Request[user.ListRequest]) (*connect.Response[user.ListResponse], error) {
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	users, next, err := s.store.ListUsers(ctx, pageSize, req.Msg.GetPageToken())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&user.ListResponse{
+		Users:         users,
+		NextPageToken: next,
+	}), nil
+}
+
+func (s *UserService) Get(ctx context.Context, req *connect.Request[user.GetRequest]) (*connect.Response[user.GetResponse], error) {
+	u, groups, err := s.store.GetUser(ctx, req.Msg.GetName())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&user.GetResponse{User: u, Groups: groups}), nil
+}
+
+func (s *UserService) Create(ctx context.Context, req *connect.Request[user.CreateRequest]) (*connect.Response[user.CreateResponse], error) {
+	u, err := s.store.CreateUser(ctx, req.Msg.GetUser())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&user.CreateResponse{User: u}), nil
+}
+
+func (s *UserService) Update(ctx context.Context, req *connect.Request[user.UpdateRequest]) (*connect.Response[user.UpdateResponse], error) {
+	u, err := s.store.UpdateUser(ctx, req.Msg.GetUser())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&user.UpdateResponse{User: u}), nil
+}
+
+func (s *UserService) Delete(ctx context.Context, req *connect.Request[user.DeleteRequest]) (*connect.Response[user.DeleteResponse], error) {
+	if err := s.store.DeleteUser(ctx, req.Msg.GetName()); err != nil {
+		return nil, storeError(err)
+	}
+	return connect.NewResponse(&user.DeleteResponse{}), nil
+}
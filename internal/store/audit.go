@@ -0,0 +1,137 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit"
+	"github.com/oklog/ulid/v2"
+)
+
+// AuditActorIndex is the name of the gsi (partkey actor, sortkey id) on the audit table.
+const AuditActorIndex = "actor_index"
+
+func auditEntry(item map[string]types.AttributeValue) *audit.AuditEntry {
+	return &audit.AuditEntry{
+		Id:            readS(item, "id"),
+		Time:          readT(item, "time"),
+		Actor:         readS(item, "actor"),
+		Procedure:     readS(item, "procedure"),
+		RequestDigest: readS(item, "request_digest"),
+		Code:          readS(item, "code"),
+		Revision:      readS(item, "revision"),
+	}
+}
+
+// PutAudit appends an entry to the audit log. The entry id is derived from its time.
+// Entries are never updated or deleted.
+func (s *Store) PutAudit(ctx context.Context, entry *audit.AuditEntry) error {
+	id, err := ulid.New(ulid.Timestamp(entry.GetTime().AsTime()), ulid.DefaultEntropy())
+	if err != nil {
+		return err
+	}
+	entry.Id = id.String()
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.auditTable),
+		Item: map[string]types.AttributeValue{
+			"project":        attrS(s.project),
+			"id":             attrS(entry.GetId()),
+			"time":           attrT(entry.GetTime().AsTime()),
+			"actor":          attrS(entry.GetActor()),
+			"procedure":      attrS(entry.GetProcedure()),
+			"request_digest": attrS(entry.GetRequestDigest()),
+			"code":           attrS(entry.GetCode()),
+			"revision":       attrS(entry.GetRevision()),
+		},
+		ConditionExpression: aws.String("attribute_not_exists(#id)"),
+		ExpressionAttributeNames: map[string]string{
+			"#id": "id",
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// AuditFilter narrows down the entries returned by ListAudit. Zero values are ignored.
+type AuditFilter struct {
+	Actor string
+	Start time.Time // inclusive
+	End   time.Time // exclusive
+}
+
+// ListAudit returns one page of audit entries matching the filter, ordered from newest to oldest.
+// The returned token continues the listing on the next page and is empty on the last page.
+func (s *Store) ListAudit(ctx context.Context, filter AuditFilter, pageSize int, pageToken string) ([]*audit.AuditEntry, string, error) {
+	// ids are ulids, therefore the time range translates to a sortkey range.
+	from, to := ulid.ULID{}, ulid.ULID{}
+	if !filter.Start.IsZero() {
+		from.SetTime(ulid.Timestamp(filter.Start))
+	}
+	to.SetTime(ulid.MaxTime())
+	if !filter.End.IsZero() {
+		to.SetTime(ulid.Timestamp(filter.End) - 1)
+	}
+	to.SetEntropy(bytes.Repeat([]byte{0xff}, 10))
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.auditTable),
+		KeyConditionExpression: aws.String("#project = :project AND #id BETWEEN :from AND :to"),
+		ScanIndexForward:       aws.Bool(false),
+		Limit:                  aws.Int32(int32(pageSize)),
+		ExpressionAttributeNames: map[string]string{
+			"#project": "project",
+			"#id":      "id",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":project": attrS(s.project),
+			":from":    attrS(from.String()),
+			":to":      attrS(to.String()),
+		},
+	}
+	if filter.Actor != "" {
+		input.IndexName = aws.String(AuditActorIndex)
+		input.KeyConditionExpression = aws.String("#actor = :actor AND #id BETWEEN :from AND :to")
+		input.ExpressionAttributeNames = map[string]string{
+			"#actor": "actor",
+			"#id":    "id",
+		}
+		delete(input.ExpressionAttributeValues, ":project")
+		input.ExpressionAttributeValues[":actor"] = attrS(filter.Actor)
+	}
+	if pageToken != "" {
+		id, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil {
+			return nil, "", ErrInvalidToken
+		}
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"project": attrS(s.project),
+			"id":      attrS(string(id)),
+		}
+		if filter.Actor != "" {
+			input.ExclusiveStartKey["actor"] = attrS(filter.Actor)
+		}
+	}
+	resp, err := s.client.Query(ctx, input)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := []*audit.AuditEntry{}
+	for _, item := range resp.Items {
+		entries = append(entries, auditEntry(item))
+	}
+	if resp.LastEvaluatedKey == nil {
+		return entries, "", nil
+	}
+	next := readS(resp.LastEvaluatedKey, "id")
+	return entries, base64.RawURLEncoding.EncodeToString([]byte(next)), nil
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/megakuul/miam/pkg/api/operator/v1/audit"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// putItemInput is the part of a PutItem request of the dynamodb json protocol checked by the tests.
+type putItemInput struct {
+	TableName           string
+	Item                map[string]map[string]any
+	ConditionExpression string
+}
+
+func TestPutAudit(t *testing.T) {
+	entryTime := time.UnixMilli(1700000000000)
+	tests := []struct {
+		name     string
+		conflict bool
+		wantErr  error
+	}{
+		{name: "entry is appended"},
+		{name: "existing id is not overwritten", conflict: true, wantErr: ErrConflict},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var input putItemInput
+			dynamo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if target := r.Header.Get("X-Amz-Target"); target != "DynamoDB_20120810.PutItem" {
+					http.Error(w, "unsupported operation "+target, http.StatusBadRequest)
+					return
+				}
+				json.NewDecoder(r.Body).Decode(&input)
+				w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+				if test.conflict {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(map[string]string{
+						"__type":  "com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException",
+						"message": "The conditional request failed",
+					})
+					return
+				}
+				w.Write([]byte("{}"))
+			}))
+			defer dynamo.Close()
+			s := New(dynamodb.New(dynamodb.Options{
+				Region:           "us-east-1",
+				BaseEndpoint:     aws.String(dynamo.URL),
+				Credentials:      aws.AnonymousCredentials{},
+				RetryMaxAttempts: 1,
+			}), "test", Tables{Audit: "test-audit"})
+
+			entry := &audit.AuditEntry{
+				Time:          timestamppb.New(entryTime),
+				Actor:         "https://other.test#bob",
+				Procedure:     "/operator.v1.cluster.ClusterService/Update",
+				RequestDigest: "digest",
+				Code:          "ok",
+				Revision:      "rev-1",
+			}
+			err := s.PutAudit(context.Background(), entry)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("err = %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("put failed: %v", err)
+			}
+
+			// ids are ulids of the entry time, so that the time range of listings is a sortkey range.
+			id, err := ulid.ParseStrict(entry.GetId())
+			if err != nil {
+				t.Fatalf("id %q is no ulid: %v", entry.GetId(), err)
+			}
+			if id.Time() != uint64(entryTime.UnixMilli()) {
+				t.Fatalf("id time = %d, want %d", id.Time(), entryTime.UnixMilli())
+			}
+			if input.TableName != "test-audit" || input.ConditionExpression != "attribute_not_exists(#id)" {
+				t.Fatalf("put to %q with condition %q", input.TableName, input.ConditionExpression)
+			}
+			want := map[string]string{
+				"project":        "test",
+				"id":             entry.GetId(),
+				"actor":          "https://other.test#bob",
+				"procedure":      "/operator.v1.cluster.ClusterService/Update",
+				"request_digest": "digest",
+				"code":           "ok",
+				"revision":       "rev-1",
+			}
+			for attribute, value := range want {
+				if got := input.Item[attribute]["S"]; got != value {
+					t.Fatalf("%s = %v, want %q", attribute, got, value)
+				}
+			}
+			if got := input.Item["time"]["N"]; got != "1700000000000" {
+				t.Fatalf("time = %v, want 1700000000000", got)
+			}
+		})
+	}
+}
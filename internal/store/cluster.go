@@ -0,0 +1,387 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"google.golang.org/protobuf/proto"
+)
+
+// headRevision is the sortkey of the item mirroring the latest revision of a cluster.
+// Querying the revision index with this key lists the latest revision of all clusters.
+const headRevision = "HEAD"
+
+// ClusterRevisionIndex is the name of the gsi (partkey revision, sortkey name) on the cluster table.
+const ClusterRevisionIndex = "revision_index"
+
+// ExpiryAttribute is the ttl attribute of the tables; dynamodb deletes items once this unix time passed.
+const ExpiryAttribute = "expires_at"
+
+func clusterItem(status *cluster.ClusterStatus, config []byte) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"name":       attrS(status.GetName()),
+		"revision":   attrS(status.GetRevision()),
+		"tags":       attrL(status.GetTags()),
+		"state":      attrS(status.GetState().String()),
+		"error":      attrS(status.GetError()),
+		"config":     attrB(config),
+		"created_at": attrT(status.GetCreatedAt().AsTime()),
+		"updated_at": attrT(status.GetUpdatedAt().AsTime()),
+		"created_by": attrS(status.GetCreatedBy()),
+		"message":    attrS(status.GetMessage()),
+	}
+}
+
+func clusterStatus(item map[string]types.AttributeValue) *cluster.ClusterStatus {
+	revision := readS(item, "revision")
+	if revision == headRevision {
+		revision = readS(item, "ref")
+	}
+	return &cluster.ClusterStatus{
+		Name:      readS(item, "name"),
+		Revision:  revision,
+		Tags:      readL(item, "tags"),
+		State:     cluster.State(cluster.State_value[readS(item, "state")]),
+		Error:     readS(item, "error"),
+		CreatedAt: readT(item, "created_at"),
+		UpdatedAt: readT(item, "updated_at"),
+		CreatedBy: readS(item, "created_by"),
+		Message:   readS(item, "message"),
+	}
+}
+
+// PutCluster inserts a new cluster revision and promotes it to the latest revision.
+// Returns ErrConflict if the latest revision of the cluster is still deploying.
+func (s *Store) PutCluster(ctx context.Context, status *cluster.ClusterStatus, config *cluster.ClusterConfig) error {
+	rawConfig, err := proto.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to serialize config: %v", err)
+	}
+	item := clusterItem(status, rawConfig)
+	head := clusterItem(status, rawConfig)
+	head["revision"] = attrS(headRevision)
+	head["ref"] = attrS(status.GetRevision())
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{{
+			Put: &types.Put{
+				TableName:           aws.String(s.clusterTable),
+				Item:                item,
+				ConditionExpression: aws.String("attribute_not_exists(#revision)"),
+				ExpressionAttributeNames: map[string]string{
+					"#revision": "revision",
+				},
+			},
+		}, {
+			Put: &types.Put{
+				TableName:           aws.String(s.clusterTable),
+				Item:                head,
+				ConditionExpression: aws.String("attribute_not_exists(#revision) OR #state <> :deploying"),
+				ExpressionAttributeNames: map[string]string{
+					"#revision": "revision",
+					"#state":    "state",
+				},
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":deploying": attrS(cluster.State_DEPLOYING.String()),
+				},
+			},
+		}},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// ClusterFilter narrows down and orders the clusters returned by ListClusters.
+type ClusterFilter struct {
+	Tags       []string
+	States     []cluster.State
+	NamePrefix string
+	Descending bool
+}
+
+// ListClusters returns one page of the latest cluster revisions matching the filter, sorted by name.
+// The returned token continues the listing on the next page and is empty on the last page.
+func (s *Store) ListClusters(ctx context.Context, filter ClusterFilter, pageSize int, pageToken string) ([]*cluster.ClusterStatus, string, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.clusterTable),
+		IndexName:              aws.String(ClusterRevisionIndex),
+		KeyConditionExpression: aws.String("#revision = :head"),
+		ScanIndexForward:       aws.Bool(!filter.Descending),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":head": attrS(headRevision),
+		},
+	}
+	if filter.NamePrefix != "" {
+		input.KeyConditionExpression = aws.String("#revision = :head AND begins_with(#name, :prefix)")
+		input.ExpressionAttributeNames["#name"] = "name"
+		input.ExpressionAttributeValues[":prefix"] = attrS(filter.NamePrefix)
+	}
+	conditions := []string{}
+	for i, tag := range filter.Tags {
+		conditions = append(conditions, fmt.Sprintf("contains(#tags, :tag%d)", i))
+		input.ExpressionAttributeNames["#tags"] = "tags"
+		input.ExpressionAttributeValues[fmt.Sprintf(":tag%d", i)] = attrS(tag)
+	}
+	if len(filter.States) > 0 {
+		states := []string{}
+		for i, state := range filter.States {
+			states = append(states, fmt.Sprintf(":state%d", i))
+			input.ExpressionAttributeValues[fmt.Sprintf(":state%d", i)] = attrS(state.String())
+		}
+		conditions = append(conditions, fmt.Sprintf("#state IN (%s)", strings.Join(states, ", ")))
+		input.ExpressionAttributeNames["#state"] = "state"
+	}
+	if len(conditions) > 0 {
+		input.FilterExpression = aws.String(strings.Join(conditions, " AND "))
+	}
+	if pageToken != "" {
+		name, err := decodeClusterToken(pageToken, filter)
+		if err != nil {
+			return nil, "", err
+		}
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"name":     attrS(name),
+			"revision": attrS(headRevision),
+		}
+	}
+
+	// filters are applied after the query limit, therefore the query is repeated until the page is full.
+	clusters := []*cluster.ClusterStatus{}
+	for {
+		input.Limit = aws.Int32(int32(pageSize - len(clusters)))
+		resp, err := s.client.Query(ctx, input)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, item := range resp.Items {
+			clusters = append(clusters, clusterStatus(item))
+		}
+		if resp.LastEvaluatedKey == nil {
+			return clusters, "", nil
+		}
+		if len(clusters) >= pageSize {
+			next, err := encodeClusterToken(readS(resp.LastEvaluatedKey, "name"), filter)
+			if err != nil {
+				return nil, "", err
+			}
+			return clusters, next, nil
+		}
+		input.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+}
+
+// clusterToken is the page token of ListClusters. It is bound to the filter and direction of the listing,
+// because its position is meaningless in another listing.
+type clusterToken struct {
+	Name   string `json:"name"`
+	Filter string `json:"filter"`
+}
+
+// digest returns a hash of the filter that identifies the listing.
+func (f ClusterFilter) digest() (string, error) {
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize filter: %v", err)
+	}
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
+
+func encodeClusterToken(name string, filter ClusterFilter) (string, error) {
+	digest, err := filter.digest()
+	if err != nil {
+		return "", err
+	}
+	raw, err := json.Marshal(clusterToken{Name: name, Filter: digest})
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize page token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeClusterToken returns the name the listing continues after,
+// fails with ErrInvalidToken if the token is malformed or belongs to a listing with another filter.
+func decodeClusterToken(pageToken string, filter ClusterFilter) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	token := clusterToken{}
+	if err := json.Unmarshal(raw, &token); err != nil || token.Name == "" {
+		return "", ErrInvalidToken
+	}
+	digest, err := filter.digest()
+	if err != nil {
+		return "", err
+	}
+	if token.Filter != digest {
+		return "", ErrInvalidToken
+	}
+	return token.Name, nil
+}
+
+// GetCluster returns all revisions of one cluster ordered from newest to oldest.
+func (s *Store) GetCluster(ctx context.Context, name string) ([]*cluster.ClusterStatus, error) {
+	revisions, err := s.ClusterRevisions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) < 1 {
+		return nil, ErrNotFound
+	}
+	return revisions, nil
+}
+
+// ClusterRevisions returns all revisions of one cluster ordered from newest to oldest.
+// Unlike GetCluster, an unknown cluster results in an empty list.
+func (s *Store) ClusterRevisions(ctx context.Context, name string) ([]*cluster.ClusterStatus, error) {
+	revisions := []*cluster.ClusterStatus{}
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:              aws.String(s.clusterTable),
+		KeyConditionExpression: aws.String("#name = :name"),
+		FilterExpression:       aws.String("#revision <> :head"),
+		// revisions are ulids, therefore the sortkey order is the chronological order.
+		ScanIndexForward: aws.Bool(false),
+		ExpressionAttributeNames: map[string]string{
+			"#name":     "name",
+			"#revision": "revision",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name": attrS(name),
+			":head": attrS(headRevision),
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			revisions = append(revisions, clusterStatus(item))
+		}
+	}
+	return revisions, nil
+}
+
+// DeleteClusterRevisions removes the specified revisions of a cluster.
+// The latest revision mirror is never deleted by this operation.
+func (s *Store) DeleteClusterRevisions(ctx context.Context, name string, revisions []string) error {
+	keys := []map[string]types.AttributeValue{}
+	for _, revision := range revisions {
+		keys = append(keys, clusterKey(name, revision))
+	}
+	return s.deleteItems(ctx, s.clusterTable, keys)
+}
+
+// ExpireCluster sets the expiry of all items of a cluster, after which dynamodb removes its entire history.
+// The expiry is cleared from the latest revision mirror if the cluster is updated before it expires.
+func (s *Store) ExpireCluster(ctx context.Context, name string, expiry time.Time) error {
+	return s.expireItems(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.clusterTable),
+		KeyConditionExpression: aws.String("#name = :name"),
+		ProjectionExpression:   aws.String("#name, #revision"),
+		ExpressionAttributeNames: map[string]string{
+			"#name":     "name",
+			"#revision": "revision",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name": attrS(name),
+		},
+	}, expiry)
+}
+
+// DescribeCluster returns the status and config of a cluster revision.
+// If no revision is specified, the latest revision is returned.
+func (s *Store) DescribeCluster(ctx context.Context, name, revision string) (*cluster.ClusterStatus, *cluster.ClusterConfig, error) {
+	if revision == "" {
+		revision = headRevision
+	}
+	resp, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.clusterTable),
+		Key: map[string]types.AttributeValue{
+			"name":     attrS(name),
+			"revision": attrS(revision),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.Item == nil {
+		return nil, nil, ErrNotFound
+	}
+	config := &cluster.ClusterConfig{}
+	if err := proto.Unmarshal(readB(resp.Item, "config"), config); err != nil {
+		return nil, nil, fmt.Errorf("failed to deserialize config: %v", err)
+	}
+	return clusterStatus(resp.Item), config, nil
+}
+
+// ClusterTags returns the tags of the latest cluster revision, or nil if the cluster does not exist.
+func (s *Store) ClusterTags(ctx context.Context, name string) ([]string, error) {
+	status, _, err := s.DescribeCluster(ctx, name, "")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return status.GetTags(), nil
+}
+
+// SetClusterState updates the state of a cluster revision.
+// The latest revision mirror is updated too if it still points to this revision.
+func (s *Store) SetClusterState(ctx context.Context, name, revision string, state cluster.State, reason string) error {
+	update := &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.clusterTable),
+		Key: map[string]types.AttributeValue{
+			"name":     attrS(name),
+			"revision": attrS(revision),
+		},
+		UpdateExpression:    aws.String("SET #state = :state, #error = :error, #updated = :updated REMOVE #resume"),
+		ConditionExpression: aws.String("attribute_exists(#revision)"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+			"#state":    "state",
+			"#error":    "error",
+			"#updated":  "updated_at",
+			"#resume":   resumeAttribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":state":   attrS(state.String()),
+			":error":   attrS(reason),
+			":updated": attrT(time.Now()),
+		},
+	}
+	if _, err := s.client.UpdateItem(ctx, update); err != nil {
+		if isConditionFailure(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	update.Key["revision"] = attrS(headRevision)
+	update.ConditionExpression = aws.String("#ref = :ref")
+	update.ExpressionAttributeNames["#ref"] = "ref"
+	update.ExpressionAttributeValues[":ref"] = attrS(revision)
+	delete(update.ExpressionAttributeNames, "#revision")
+	if _, err := s.client.UpdateItem(ctx, update); err != nil && !isConditionFailure(err) {
+		return err
+	}
+	return nil
+}
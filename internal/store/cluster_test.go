@@ -0,0 +1,212 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+)
+
+// queryInput is the part of a Query request of the dynamodb json protocol evaluated by fakeClusterIndex.
+type queryInput struct {
+	IndexName                 string
+	ExpressionAttributeValues map[string]map[string]string
+	ExclusiveStartKey         map[string]map[string]string
+	ScanIndexForward          *bool
+	Limit                     int
+}
+
+// fakeClusterIndex answers queries on the revision index of the cluster table with the latest revisions.
+// It evaluates the name prefix, tag and state conditions by the placeholders ListClusters uses for them.
+type fakeClusterIndex struct {
+	heads []*cluster.ClusterStatus // ordered by name
+}
+
+func (f *fakeClusterIndex) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if target := r.Header.Get("X-Amz-Target"); target != "DynamoDB_20120810.Query" {
+		http.Error(w, "unsupported operation "+target, http.StatusBadRequest)
+		return
+	}
+	input := queryInput{}
+	json.NewDecoder(r.Body).Decode(&input)
+	if input.IndexName != ClusterRevisionIndex {
+		http.Error(w, "unexpected index "+input.IndexName, http.StatusBadRequest)
+		return
+	}
+	heads := slices.Clone(f.heads)
+	if input.ScanIndexForward != nil && !*input.ScanIndexForward {
+		slices.Reverse(heads)
+	}
+	if start, ok := input.ExclusiveStartKey["name"]; ok {
+		index := slices.IndexFunc(heads, func(head *cluster.ClusterStatus) bool { return head.GetName() == start["S"] })
+		heads = heads[index+1:]
+	}
+	items, evaluated := []map[string]any{}, 0
+	for _, head := range heads {
+		if input.Limit > 0 && evaluated >= input.Limit {
+			break
+		}
+		evaluated++
+		if matchesQuery(head, input.ExpressionAttributeValues) {
+			items = append(items, map[string]any{
+				"name":     map[string]string{"S": head.GetName()},
+				"revision": map[string]string{"S": headRevision},
+				"ref":      map[string]string{"S": head.GetRevision()},
+				"state":    map[string]string{"S": head.GetState().String()},
+			})
+		}
+	}
+	resp := map[string]any{"Items": items, "Count": len(items), "ScannedCount": evaluated}
+	if evaluated < len(heads) {
+		resp["LastEvaluatedKey"] = map[string]any{
+			"name":     map[string]string{"S": heads[evaluated-1].GetName()},
+			"revision": map[string]string{"S": headRevision},
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func matchesQuery(head *cluster.ClusterStatus, values map[string]map[string]string) bool {
+	stateFiltered, stateMatched := false, false
+	for placeholder, value := range values {
+		switch {
+		case placeholder == ":prefix" && !strings.HasPrefix(head.GetName(), value["S"]):
+			return false
+		case strings.HasPrefix(placeholder, ":tag") && !slices.Contains(head.GetTags(), value["S"]):
+			return false
+		case strings.HasPrefix(placeholder, ":state"):
+			stateFiltered = true
+			stateMatched = stateMatched || head.GetState().String() == value["S"]
+		}
+	}
+	return !stateFiltered || stateMatched
+}
+
+func TestListClusters(t *testing.T) {
+	index := &fakeClusterIndex{heads: []*cluster.ClusterStatus{
+		{Name: "dev-a", State: cluster.State_ACTIVE},
+		{Name: "dev-b", State: cluster.State_FAILED},
+		{Name: "prod-a", State: cluster.State_ACTIVE},
+		{Name: "prod-b", State: cluster.State_ACTIVE},
+		{Name: "prod-c", State: cluster.State_FAILED},
+		{Name: "test-a", State: cluster.State_ACTIVE},
+	}}
+	dynamo := httptest.NewServer(index)
+	defer dynamo.Close()
+	s := New(dynamodb.New(dynamodb.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(dynamo.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	}), "test", Tables{Cluster: "test-cluster"})
+
+	tests := []struct {
+		name      string
+		filter    ClusterFilter
+		pageSize  int
+		wantPages [][]string
+	}{
+		{
+			name:      "all clusters by name",
+			pageSize:  4,
+			wantPages: [][]string{{"dev-a", "dev-b", "prod-a", "prod-b"}, {"prod-c", "test-a"}},
+		},
+		{
+			name:      "descending",
+			filter:    ClusterFilter{Descending: true},
+			pageSize:  4,
+			wantPages: [][]string{{"test-a", "prod-c", "prod-b", "prod-a"}, {"dev-b", "dev-a"}},
+		},
+		{
+			name:      "name prefix",
+			filter:    ClusterFilter{NamePrefix: "prod-"},
+			pageSize:  2,
+			wantPages: [][]string{{"prod-a", "prod-b"}, {"prod-c"}},
+		},
+		{
+			name:      "filtered pages are full",
+			filter:    ClusterFilter{States: []cluster.State{cluster.State_ACTIVE}},
+			pageSize:  2,
+			wantPages: [][]string{{"dev-a", "prod-a"}, {"prod-b", "test-a"}},
+		},
+		{
+			name:     "filter with multiple states",
+			filter:   ClusterFilter{States: []cluster.State{cluster.State_FAILED, cluster.State_DEPLOYING}},
+			pageSize: 1,
+			// the remaining clusters are only known to not match once they were read.
+			wantPages: [][]string{{"dev-b"}, {"prod-c"}, {}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pages, token := [][]string{}, ""
+			for {
+				clusters, next, err := s.ListClusters(context.Background(), test.filter, test.pageSize, token)
+				if err != nil {
+					t.Fatalf("page %d: %v", len(pages), err)
+				}
+				page := []string{}
+				for _, c := range clusters {
+					page = append(page, c.GetName())
+				}
+				pages = append(pages, page)
+				if next == "" {
+					break
+				}
+				token = next
+			}
+			if !slices.EqualFunc(pages, test.wantPages, slices.Equal) {
+				t.Fatalf("pages = %v, want %v", pages, test.wantPages)
+			}
+		})
+	}
+}
+
+func TestListClustersRejectsForeignTokens(t *testing.T) {
+	dynamo := httptest.NewServer(&fakeClusterIndex{heads: []*cluster.ClusterStatus{
+		{Name: "a"}, {Name: "b"}, {Name: "c"},
+	}})
+	defer dynamo.Close()
+	s := New(dynamodb.New(dynamodb.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(dynamo.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	}), "test", Tables{Cluster: "test-cluster"})
+	_, token, err := s.ListClusters(context.Background(), ClusterFilter{}, 1, "")
+	if err != nil || token == "" {
+		t.Fatalf("failed to list first page: token %q (%v)", token, err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  ClusterFilter
+		token   string
+		wantErr error
+	}{
+		{name: "same listing", token: token},
+		{name: "other direction", filter: ClusterFilter{Descending: true}, token: token, wantErr: ErrInvalidToken},
+		{name: "other prefix", filter: ClusterFilter{NamePrefix: "b"}, token: token, wantErr: ErrInvalidToken},
+		{name: "other tags", filter: ClusterFilter{Tags: []string{"prod"}}, token: token, wantErr: ErrInvalidToken},
+		{name: "other states", filter: ClusterFilter{States: []cluster.State{cluster.State_ACTIVE}}, token: token, wantErr: ErrInvalidToken},
+		{name: "bare name", token: "Yg", wantErr: ErrInvalidToken},
+		{name: "malformed token", token: "%%%", wantErr: ErrInvalidToken},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := s.ListClusters(context.Background(), test.filter, 1, test.token)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("err = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,733 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/pkg/api/iam/v1/group"
+	"github.com/megakuul/miam/pkg/api/iam/v1/role"
+	"github.com/megakuul/miam/pkg/api/iam/v1/user"
+	"github.com/megakuul/miam/pkg/policy"
+	"github.com/oklog/ulid/v2"
+)
+
+// kinds of the items in the iam table (partkey).
+const (
+	kindUser    = "user"
+	kindGroup   = "group"
+	kindRole    = "role"
+	kindBinding = "binding"
+
+	kindServiceAccount = "serviceaccount"
+	kindAPIKey         = "apikey"
+
+	// memberships index the groups of a user as "<user>#<group>", group names cannot contain '#'.
+	kindMembership = "membership"
+	// the meta item "directory" counts the changes of the directory.
+	kindMeta = "meta"
+)
+
+// directoryMaxAge is the time after which the directory is reloaded even if its version did not change,
+// so that a lost version increment cannot keep a stale directory forever.
+const directoryMaxAge = 10 * time.Minute
+
+func iamKey(kind, name string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"kind": attrS(kind),
+		"name": attrS(name),
+	}
+}
+
+func membershipKey(user, group string) map[string]types.AttributeValue {
+	return iamKey(kindMembership, user+"#"+group)
+}
+
+// createIAM inserts a new item, returns ErrAlreadyExists if an item with this kind and name exists.
+func (s *Store) createIAM(ctx context.Context, item map[string]types.AttributeValue) error {
+	_, err := s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.iamTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(#name)"),
+		ExpressionAttributeNames: map[string]string{
+			"#name": "name",
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrAlreadyExists
+		}
+		return err
+	}
+	return s.touchDirectory(ctx)
+}
+
+// updateIAM applies the update expression to an existing item and returns the updated item.
+func (s *Store) updateIAM(ctx context.Context, kind, name, expression string, names map[string]string, values map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+	names["#name"] = "name"
+	resp, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(s.iamTable),
+		Key:                       iamKey(kind, name),
+		UpdateExpression:          aws.String(expression),
+		ConditionExpression:       aws.String("attribute_exists(#name)"),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if err := s.touchDirectory(ctx); err != nil {
+		return nil, err
+	}
+	return resp.Attributes, nil
+}
+
+func (s *Store) getIAM(ctx context.Context, kind, name string) (map[string]types.AttributeValue, error) {
+	resp, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.iamTable),
+		Key:       iamKey(kind, name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Item == nil {
+		return nil, ErrNotFound
+	}
+	return resp.Item, nil
+}
+
+// listIAM returns one page of items of a kind ordered by name. A page size of 0 returns all items.
+func (s *Store) listIAM(ctx context.Context, kind string, pageSize int, pageToken string) ([]map[string]types.AttributeValue, string, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.iamTable),
+		KeyConditionExpression: aws.String("#kind = :kind"),
+		ExpressionAttributeNames: map[string]string{
+			"#kind": "kind",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":kind": attrS(kind),
+		},
+	}
+	if pageToken != "" {
+		name, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil {
+			return nil, "", ErrInvalidToken
+		}
+		input.ExclusiveStartKey = iamKey(kind, string(name))
+	}
+	items := []map[string]types.AttributeValue{}
+	for {
+		if pageSize > 0 {
+			input.Limit = aws.Int32(int32(pageSize - len(items)))
+		}
+		resp, err := s.client.Query(ctx, input)
+		if err != nil {
+			return nil, "", err
+		}
+		items = append(items, resp.Items...)
+		if resp.LastEvaluatedKey == nil {
+			return items, "", nil
+		}
+		if pageSize > 0 && len(items) >= pageSize {
+			next := readS(resp.LastEvaluatedKey, "name")
+			return items, base64.RawURLEncoding.EncodeToString([]byte(next)), nil
+		}
+		input.ExclusiveStartKey = resp.LastEvaluatedKey
+	}
+}
+
+func (s *Store) deleteIAM(ctx context.Context, kind, name string) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String(s.iamTable),
+		Key:                 iamKey(kind, name),
+		ConditionExpression: aws.String("attribute_exists(#name)"),
+		ExpressionAttributeNames: map[string]string{
+			"#name": "name",
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return s.touchDirectory(ctx)
+}
+
+// transactIAM writes the items in transactions of up to maxTransactItems items in order,
+// returns ErrNotFound if a condition failed.
+func (s *Store) transactIAM(ctx context.Context, items []types.TransactWriteItem) error {
+	for chunk := range slices.Chunk(items, maxTransactItems) {
+		_, err := s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: chunk})
+		if err != nil {
+			if isConditionFailure(err) {
+				return ErrNotFound
+			}
+			return err
+		}
+	}
+	return s.touchDirectory(ctx)
+}
+
+// deleteItem is the transaction item deleting an item of the iam table, it fails if the item does not exist.
+func (s *Store) deleteItem(key map[string]types.AttributeValue) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName:           aws.String(s.iamTable),
+			Key:                 key,
+			ConditionExpression: aws.String("attribute_exists(#name)"),
+			ExpressionAttributeNames: map[string]string{
+				"#name": "name",
+			},
+		},
+	}
+}
+
+// memberUpdate is the transaction item adding the member to or deleting it from an existing group.
+func (s *Store) memberUpdate(group, member, operation string) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:           aws.String(s.iamTable),
+			Key:                 iamKey(kindGroup, group),
+			UpdateExpression:    aws.String(operation + " #members :member SET #updated_at = :updated_at"),
+			ConditionExpression: aws.String("attribute_exists(#name)"),
+			ExpressionAttributeNames: map[string]string{
+				"#name":       "name",
+				"#members":    "members",
+				"#updated_at": "updated_at",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":member":     &types.AttributeValueMemberSS{Value: []string{member}},
+				":updated_at": attrT(time.Now()),
+			},
+		},
+	}
+}
+
+// touchDirectory increments the version of the directory, so that the next refresh reloads it.
+func (s *Store) touchDirectory(ctx context.Context) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(s.iamTable),
+		Key:              iamKey(kindMeta, "directory"),
+		UpdateExpression: aws.String("ADD #version :one"),
+		ExpressionAttributeNames: map[string]string{
+			"#version": "version",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one": attrN(1),
+		},
+	})
+	return err
+}
+
+// readDirectoryVersion returns the current version of the directory.
+func (s *Store) readDirectoryVersion(ctx context.Context) (int64, error) {
+	resp, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.iamTable),
+		Key:            iamKey(kindMeta, "directory"),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return readN(resp.Item, "version"), nil
+}
+
+func userFromItem(item map[string]types.AttributeValue) *user.User {
+	return &user.User{
+		Name:        readS(item, "name"),
+		DisplayName: readS(item, "display_name"),
+		Email:       readS(item, "email"),
+		Disabled:    readBool(item, "disabled"),
+		Upstreams:   readL(item, "upstreams"),
+		CreatedAt:   readT(item, "created_at"),
+		UpdatedAt:   readT(item, "updated_at"),
+	}
+}
+
+// CreateUser inserts a new user.
+func (s *Store) CreateUser(ctx context.Context, u *user.User) (*user.User, error) {
+	now := time.Now()
+	item := iamKey(kindUser, u.GetName())
+	item["display_name"] = attrS(u.GetDisplayName())
+	item["email"] = attrS(u.GetEmail())
+	item["disabled"] = attrBool(u.GetDisabled())
+	item["upstreams"] = attrL(u.GetUpstreams())
+	item["created_at"] = attrT(now)
+	item["updated_at"] = attrT(now)
+	if err := s.createIAM(ctx, item); err != nil {
+		return nil, err
+	}
+	return userFromItem(item), nil
+}
+
+// UpdateUser replaces the attributes of an existing user.
+func (s *Store) UpdateUser(ctx context.Context, u *user.User) (*user.User, error) {
+	item, err := s.updateIAM(ctx, kindUser, u.GetName(),
+		"SET #display_name = :display_name, #email = :email, #disabled = :disabled, #upstreams = :upstreams, #updated_at = :updated_at",
+		map[string]string{
+			"#display_name": "display_name",
+			"#email":        "email",
+			"#disabled":     "disabled",
+			"#upstreams":    "upstreams",
+			"#updated_at":   "updated_at",
+		},
+		map[string]types.AttributeValue{
+			":display_name": attrS(u.GetDisplayName()),
+			":email":        attrS(u.GetEmail()),
+			":disabled":     attrBool(u.GetDisabled()),
+			":upstreams":    attrL(u.GetUpstreams()),
+			":updated_at":   attrT(time.Now()),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return userFromItem(item), nil
+}
+
+// GetUser returns a user and the names of the groups it is a member of.
+func (s *Store) GetUser(ctx context.Context, name string) (*user.User, []string, error) {
+	item, err := s.getIAM(ctx, kindUser, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := s.userGroups(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return userFromItem(item), memberships, nil
+}
+
+// userGroups returns the names of the groups the user is a member of ordered by name.
+func (s *Store) userGroups(ctx context.Context, name string) ([]string, error) {
+	groups := []string{}
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:              aws.String(s.iamTable),
+		KeyConditionExpression: aws.String("#kind = :kind AND begins_with(#name, :prefix)"),
+		ExpressionAttributeNames: map[string]string{
+			"#kind": "kind",
+			"#name": "name",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":kind":   attrS(kindMembership),
+			":prefix": attrS(name + "#"),
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			groups = append(groups, readS(item, "group"))
+		}
+	}
+	return groups, nil
+}
+
+// ListUsers returns one page of users ordered by name.
+func (s *Store) ListUsers(ctx context.Context, pageSize int, pageToken string) ([]*user.User, string, error) {
+	items, next, err := s.listIAM(ctx, kindUser, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	users := []*user.User{}
+	for _, item := range items {
+		users = append(users, userFromItem(item))
+	}
+	return users, next, nil
+}
+
+// DeleteUser removes a user, its group memberships and the role bindings of the user.
+// The user is removed last, so that a failed deletion can be repeated.
+func (s *Store) DeleteUser(ctx context.Context, name string) error {
+	_, groups, err := s.GetUser(ctx, name)
+	if err != nil {
+		return err
+	}
+	bindings, err := s.ListBindings(ctx)
+	if err != nil {
+		return err
+	}
+	items := []types.TransactWriteItem{}
+	for _, group := range groups {
+		items = append(items, types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName: aws.String(s.iamTable),
+				Key:       membershipKey(name, group),
+			},
+		})
+		items = append(items, s.memberUpdate(group, name, "DELETE"))
+	}
+	for _, binding := range bindings {
+		if binding.GetSubject() == name {
+			items = append(items, types.TransactWriteItem{
+				Delete: &types.Delete{
+					TableName: aws.String(s.iamTable),
+					Key:       iamKey(kindBinding, binding.GetId()),
+				},
+			})
+		}
+	}
+	items = append(items, s.deleteItem(iamKey(kindUser, name)))
+	return s.transactIAM(ctx, items)
+}
+
+func groupFromItem(item map[string]types.AttributeValue) *group.Group {
+	members := readSS(item, "members")
+	slices.Sort(members)
+	return &group.Group{
+		Name:        readS(item, "name"),
+		Description: readS(item, "description"),
+		Members:     members,
+		CreatedAt:   readT(item, "created_at"),
+		UpdatedAt:   readT(item, "updated_at"),
+	}
+}
+
+// CreateGroup inserts a new group without members.
+func (s *Store) CreateGroup(ctx context.Context, g *group.Group) (*group.Group, error) {
+	now := time.Now()
+	item := iamKey(kindGroup, g.GetName())
+	item["description"] = attrS(g.GetDescription())
+	item["created_at"] = attrT(now)
+	item["updated_at"] = attrT(now)
+	if err := s.createIAM(ctx, item); err != nil {
+		return nil, err
+	}
+	return groupFromItem(item), nil
+}
+
+// UpdateGroup replaces the description of an existing group.
+func (s *Store) UpdateGroup(ctx context.Context, g *group.Group) (*group.Group, error) {
+	item, err := s.updateIAM(ctx, kindGroup, g.GetName(),
+		"SET #description = :description, #updated_at = :updated_at",
+		map[string]string{
+			"#description": "description",
+			"#updated_at":  "updated_at",
+		},
+		map[string]types.AttributeValue{
+			":description": attrS(g.GetDescription()),
+			":updated_at":  attrT(time.Now()),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return groupFromItem(item), nil
+}
+
+// GetGroup returns a group with its members.
+func (s *Store) GetGroup(ctx context.Context, name string) (*group.Group, error) {
+	item, err := s.getIAM(ctx, kindGroup, name)
+	if err != nil {
+		return nil, err
+	}
+	return groupFromItem(item), nil
+}
+
+// ListGroups returns one page of groups ordered by name.
+func (s *Store) ListGroups(ctx context.Context, pageSize int, pageToken string) ([]*group.Group, string, error) {
+	items, next, err := s.listIAM(ctx, kindGroup, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	groups := []*group.Group{}
+	for _, item := range items {
+		groups = append(groups, groupFromItem(item))
+	}
+	return groups, next, nil
+}
+
+// DeleteGroup removes a group and the memberships of its members.
+func (s *Store) DeleteGroup(ctx context.Context, name string) error {
+	item, err := s.getIAM(ctx, kindGroup, name)
+	if err != nil {
+		return err
+	}
+	items := []types.TransactWriteItem{}
+	for _, member := range readSS(item, "members") {
+		items = append(items, types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName: aws.String(s.iamTable),
+				Key:       membershipKey(member, name),
+			},
+		})
+	}
+	items = append(items, s.deleteItem(iamKey(kindGroup, name)))
+	return s.transactIAM(ctx, items)
+}
+
+// AddGroupMember adds a user to an existing group.
+func (s *Store) AddGroupMember(ctx context.Context, group, member string) error {
+	membership := membershipKey(member, group)
+	membership["user"] = attrS(member)
+	membership["group"] = attrS(group)
+	return s.transactIAM(ctx, []types.TransactWriteItem{
+		s.memberUpdate(group, member, "ADD"),
+		{Put: &types.Put{
+			TableName: aws.String(s.iamTable),
+			Item:      membership,
+		}},
+	})
+}
+
+// RemoveGroupMember removes a user from an existing group.
+func (s *Store) RemoveGroupMember(ctx context.Context, group, member string) error {
+	return s.transactIAM(ctx, []types.TransactWriteItem{
+		s.memberUpdate(group, member, "DELETE"),
+		{Delete: &types.Delete{
+			TableName: aws.String(s.iamTable),
+			Key:       membershipKey(member, group),
+		}},
+	})
+}
+
+// IndexMemberships adds the missing membership items of all group members,
+// the groups of users were not indexed before memberships were introduced.
+func (s *Store) IndexMemberships(ctx context.Context) error {
+	groups, _, err := s.listIAM(ctx, kindGroup, 0, "")
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		for _, member := range readSS(group, "members") {
+			membership := membershipKey(member, readS(group, "name"))
+			membership["user"] = attrS(member)
+			membership["group"] = group["name"]
+			_, err := s.client.PutItem(ctx, &dynamodb.PutItemInput{
+				TableName: aws.String(s.iamTable),
+				Item:      membership,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func roleFromItem(item map[string]types.AttributeValue) (*role.Role, error) {
+	document, err := policy.Parse([]byte(readS(item, "policy")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy of role '%s': %v", readS(item, "name"), err)
+	}
+	return &role.Role{
+		Name:        readS(item, "name"),
+		Description: readS(item, "description"),
+		Statements:  document.Proto(),
+		CreatedAt:   readT(item, "created_at"),
+		UpdatedAt:   readT(item, "updated_at"),
+	}, nil
+}
+
+// rolePolicy serializes the statements of a role as json policy document.
+func rolePolicy(r *role.Role) (types.AttributeValue, error) {
+	raw, err := json.Marshal(policy.FromProto(r.GetStatements()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize policy: %v", err)
+	}
+	return attrS(string(raw)), nil
+}
+
+// CreateRole inserts a new custom role.
+func (s *Store) CreateRole(ctx context.Context, r *role.Role) (*role.Role, error) {
+	now := time.Now()
+	rawPolicy, err := rolePolicy(r)
+	if err != nil {
+		return nil, err
+	}
+	item := iamKey(kindRole, r.GetName())
+	item["description"] = attrS(r.GetDescription())
+	item["policy"] = rawPolicy
+	item["created_at"] = attrT(now)
+	item["updated_at"] = attrT(now)
+	if err := s.createIAM(ctx, item); err != nil {
+		return nil, err
+	}
+	return roleFromItem(item)
+}
+
+// UpdateRole replaces the description and policy of an existing custom role.
+func (s *Store) UpdateRole(ctx context.Context, r *role.Role) (*role.Role, error) {
+	rawPolicy, err := rolePolicy(r)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.updateIAM(ctx, kindRole, r.GetName(),
+		"SET #description = :description, #policy = :policy, #updated_at = :updated_at",
+		map[string]string{
+			"#description": "description",
+			"#policy":      "policy",
+			"#updated_at":  "updated_at",
+		},
+		map[string]types.AttributeValue{
+			":description": attrS(r.GetDescription()),
+			":policy":      rawPolicy,
+			":updated_at":  attrT(time.Now()),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return roleFromItem(item)
+}
+
+// GetRole returns a custom role.
+func (s *Store) GetRole(ctx context.Context, name string) (*role.Role, error) {
+	item, err := s.getIAM(ctx, kindRole, name)
+	if err != nil {
+		return nil, err
+	}
+	return roleFromItem(item)
+}
+
+// ListRoles returns all custom roles ordered by name.
+func (s *Store) ListRoles(ctx context.Context) ([]*role.Role, error) {
+	items, _, err := s.listIAM(ctx, kindRole, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	roles := []*role.Role{}
+	for _, item := range items {
+		r, err := roleFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, r)
+	}
+	return roles, nil
+}
+
+// DeleteRole removes a custom role. Bindings of the role remain but grant nothing.
+func (s *Store) DeleteRole(ctx context.Context, name string) error {
+	return s.deleteIAM(ctx, kindRole, name)
+}
+
+func bindingFromItem(item map[string]types.AttributeValue) *role.RoleBinding {
+	return &role.RoleBinding{
+		Id:        readS(item, "name"),
+		Role:      readS(item, "role"),
+		Subject:   readS(item, "subject"),
+		Clusters:  readL(item, "clusters"),
+		Tags:      readL(item, "tags"),
+		CreatedAt: readT(item, "created_at"),
+	}
+}
+
+// CreateBinding inserts a new role binding with a generated id.
+func (s *Store) CreateBinding(ctx context.Context, b *role.RoleBinding) (*role.RoleBinding, error) {
+	item := iamKey(kindBinding, ulid.Make().String())
+	item["role"] = attrS(b.GetRole())
+	item["subject"] = attrS(b.GetSubject())
+	item["clusters"] = attrL(b.GetClusters())
+	item["tags"] = attrL(b.GetTags())
+	item["created_at"] = attrT(time.Now())
+	if err := s.createIAM(ctx, item); err != nil {
+		return nil, err
+	}
+	return bindingFromItem(item), nil
+}
+
+// ListBindings returns all role bindings ordered by id.
+func (s *Store) ListBindings(ctx context.Context) ([]*role.RoleBinding, error) {
+	items, _, err := s.listIAM(ctx, kindBinding, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	bindings := []*role.RoleBinding{}
+	for _, item := range items {
+		bindings = append(bindings, bindingFromItem(item))
+	}
+	return bindings, nil
+}
+
+// DeleteBinding removes a role binding.
+func (s *Store) DeleteBinding(ctx context.Context, id string) error {
+	return s.deleteIAM(ctx, kindBinding, id)
+}
+
+// Directory returns the users, groups, roles and bindings evaluated by the authorizer.
+// The directory is only reloaded if it changed since it was loaded last or directoryMaxAge passed.
+func (s *Store) Directory(ctx context.Context) (*auth.Directory, error) {
+	version, err := s.readDirectoryVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.directoryLock.Lock()
+	defer s.directoryLock.Unlock()
+	if s.directory != nil && s.directoryVersion == version && time.Since(s.directoryLoaded) < directoryMaxAge {
+		return s.directory, nil
+	}
+	directory, err := s.loadDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.directory, s.directoryVersion, s.directoryLoaded = directory, version, time.Now()
+	return directory, nil
+}
+
+// loadDirectory reads the directory from the iam table.
+func (s *Store) loadDirectory(ctx context.Context) (*auth.Directory, error) {
+	directory := &auth.Directory{
+		Policies: []auth.Policy{},
+		Roles:    map[string]*policy.Document{},
+		Groups:   map[string][]string{},
+		Disabled: map[string]bool{},
+	}
+	users, _, err := s.listIAM(ctx, kindUser, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range users {
+		directory.Disabled[readS(item, "name")] = readBool(item, "disabled")
+	}
+	accounts, _, err := s.listIAM(ctx, kindServiceAccount, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range accounts {
+		directory.Disabled[auth.ServiceAccountPrefix+readS(item, "name")] = readBool(item, "disabled")
+	}
+	groups, _, err := s.listIAM(ctx, kindGroup, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range groups {
+		for _, member := range readSS(item, "members") {
+			directory.Groups[member] = append(directory.Groups[member], readS(item, "name"))
+		}
+	}
+	roles, err := s.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		directory.Roles[r.GetName()] = policy.FromProto(r.GetStatements())
+	}
+	bindings, err := s.ListBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bindings {
+		directory.Policies = append(directory.Policies, auth.Policy{
+			Subjects: []string{b.GetSubject()},
+			Role:     b.GetRole(),
+			Clusters: b.GetClusters(),
+			Tags:     b.GetTags(),
+		})
+	}
+	return directory, nil
+}
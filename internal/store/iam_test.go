@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// iamRequest is the part of the requests of the dynamodb json protocol checked by the iam tests.
+type iamRequest struct {
+	Key                       map[string]map[string]string
+	ExpressionAttributeValues map[string]map[string]any
+	TransactItems             []map[string]struct {
+		Key              map[string]map[string]string
+		UpdateExpression string
+	}
+}
+
+// fakeIAMTable answers GetItem and Query requests with the items of the table and records all writes,
+// updates of the meta item increment the directory version.
+type fakeIAMTable struct {
+	lock    sync.Mutex
+	items   map[string][]map[string]any // kind -> items ordered by name
+	version int
+	queries int
+	writes  []iamRequest
+}
+
+func (f *fakeIAMTable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	req := iamRequest{}
+	json.NewDecoder(r.Body).Decode(&req)
+	resp := map[string]any{}
+	switch target := r.Header.Get("X-Amz-Target"); target {
+	case "DynamoDB_20120810.GetItem":
+		kind, name := req.Key["kind"]["S"], req.Key["name"]["S"]
+		if kind == kindMeta {
+			resp["Item"] = map[string]any{"version": map[string]string{"N": strconv.Itoa(f.version)}}
+			break
+		}
+		for _, item := range f.items[kind] {
+			if item["name"].(map[string]string)["S"] == name {
+				resp["Item"] = item
+			}
+		}
+	case "DynamoDB_20120810.Query":
+		f.queries++
+		kind := req.ExpressionAttributeValues[":kind"]["S"].(string)
+		resp["Items"] = f.items[kind]
+	case "DynamoDB_20120810.TransactWriteItems", "DynamoDB_20120810.UpdateItem", "DynamoDB_20120810.DeleteItem":
+		if req.Key["kind"]["S"] == kindMeta {
+			f.version++
+			break
+		}
+		f.writes = append(f.writes, req)
+	default:
+		http.Error(w, "unsupported operation "+target, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func iamItem(kind, name string, attributes map[string]string) map[string]any {
+	item := map[string]any{
+		"kind": map[string]string{"S": kind},
+		"name": map[string]string{"S": name},
+	}
+	for attribute, value := range attributes {
+		item[attribute] = map[string]string{"S": value}
+	}
+	return item
+}
+
+func newIAMStore(t *testing.T, table *fakeIAMTable) *Store {
+	dynamo := httptest.NewServer(table)
+	t.Cleanup(dynamo.Close)
+	return New(dynamodb.New(dynamodb.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(dynamo.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	}), "test", Tables{IAM: "test-iam"})
+}
+
+func TestDeleteUser(t *testing.T) {
+	table := &fakeIAMTable{items: map[string][]map[string]any{
+		kindUser:       {iamItem(kindUser, "alice", nil)},
+		kindMembership: {iamItem(kindMembership, "alice#dev", map[string]string{"user": "alice", "group": "dev"})},
+		kindBinding: {
+			iamItem(kindBinding, "b1", map[string]string{"role": "admin", "subject": "alice"}),
+			iamItem(kindBinding, "b2", map[string]string{"role": "admin", "subject": "bob"}),
+		},
+	}}
+	s := newIAMStore(t, table)
+	if err := s.DeleteUser(context.Background(), "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if len(table.writes) != 1 {
+		t.Fatalf("deletion took %d writes, want a single transaction", len(table.writes))
+	}
+	written := []string{}
+	for _, item := range table.writes[0].TransactItems {
+		for operation, action := range item {
+			written = append(written, operation+" "+action.Key["kind"]["S"]+"/"+action.Key["name"]["S"])
+		}
+	}
+	want := []string{"Delete membership/alice#dev", "Update group/dev", "Delete binding/b1", "Delete user/alice"}
+	if !slices.Equal(written, want) {
+		t.Fatalf("transaction = %v, want %v", written, want)
+	}
+}
+
+func TestDirectoryIsReloadedOnChange(t *testing.T) {
+	table := &fakeIAMTable{items: map[string][]map[string]any{
+		kindUser: {iamItem(kindUser, "alice", nil)},
+	}}
+	s := newIAMStore(t, table)
+
+	tests := []struct {
+		name        string
+		change      bool
+		wantQueries int
+	}{
+		{name: "first load", wantQueries: 5},
+		{name: "unchanged directory is cached", wantQueries: 5},
+		{name: "changed directory is reloaded", change: true, wantQueries: 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.change {
+				if err := s.DeleteBinding(context.Background(), "b1"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			directory, err := s.Directory(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := directory.Disabled["alice"]; !ok {
+				t.Fatalf("directory = %+v, want user alice", directory)
+			}
+			table.lock.Lock()
+			defer table.lock.Unlock()
+			if table.queries != test.wantQueries {
+				t.Fatalf("queries = %d, want %d", table.queries, test.wantQueries)
+			}
+		})
+	}
+}
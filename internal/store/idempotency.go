@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// idempotency records live in the cluster table next to the revisions they produced, their partkey
+// is prefixed with idempotencyPrefix (no valid cluster name) and their sortkey is idempotencyRevision.
+// Expired records are removed by the dynamodb ttl.
+const (
+	idempotencyPrefix   = "#idempotency/"
+	idempotencyRevision = "IDEMPOTENCY"
+)
+
+// Idempotency is the record of a request sent with an idempotency key.
+type Idempotency struct {
+	// Digest identifies the request, a key must not be reused for a different request.
+	Digest string
+	// Completed reports whether the request finished, the response is empty while it is in progress.
+	Completed bool
+	Response  []byte
+}
+
+func idempotencyKey(key string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"name":     attrS(idempotencyPrefix + key),
+		"revision": attrS(idempotencyRevision),
+	}
+}
+
+// ClaimIdempotencyKey records that the request with the digest is in progress until the expiry.
+// The claim identifies the request in CompleteIdempotencyKey and ReleaseIdempotencyKey,
+// so that a request whose claim expired does not overwrite the claim of a retry.
+// Returns ErrAlreadyExists if the key is claimed by another request that did not expire.
+func (s *Store) ClaimIdempotencyKey(ctx context.Context, key, claim, digest string, expiry time.Time) error {
+	item := idempotencyKey(key)
+	item["claim"] = attrS(claim)
+	item["digest"] = attrS(digest)
+	item[ExpiryAttribute] = attrN(expiry.Unix())
+	_, err := s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.clusterTable),
+		Item:      item,
+		// the ttl deletes expired items only eventually, so they are overwritten.
+		ConditionExpression: aws.String("attribute_not_exists(#revision) OR #expiry < :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+			"#expiry":   ExpiryAttribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": attrN(time.Now().Unix()),
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetIdempotencyKey returns the record of the key. Returns ErrNotFound if the key is not claimed or expired.
+func (s *Store) GetIdempotencyKey(ctx context.Context, key string) (*Idempotency, error) {
+	resp, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.clusterTable),
+		Key:            idempotencyKey(key),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Item == nil || readN(resp.Item, ExpiryAttribute) < time.Now().Unix() {
+		return nil, ErrNotFound
+	}
+	return &Idempotency{
+		Digest:    readS(resp.Item, "digest"),
+		Completed: readBool(resp.Item, "completed"),
+		Response:  readB(resp.Item, "response"),
+	}, nil
+}
+
+// CompleteIdempotencyKey stores the response of the request that claimed the key and keeps it until the expiry.
+// Returns ErrNotFound if the claim was lost.
+func (s *Store) CompleteIdempotencyKey(ctx context.Context, key, claim string, response []byte, expiry time.Time) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(s.clusterTable),
+		Key:                 idempotencyKey(key),
+		UpdateExpression:    aws.String("SET #completed = :completed, #response = :response, #expiry = :expiry"),
+		ConditionExpression: aws.String("#claim = :claim"),
+		ExpressionAttributeNames: map[string]string{
+			"#claim":     "claim",
+			"#completed": "completed",
+			"#response":  "response",
+			"#expiry":    ExpiryAttribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":claim":     attrS(claim),
+			":completed": attrBool(true),
+			":response":  attrB(response),
+			":expiry":    attrN(expiry.Unix()),
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes the claim of a request that failed, so that the request can be retried.
+// A lost claim is left untouched.
+func (s *Store) ReleaseIdempotencyKey(ctx context.Context, key, claim string) error {
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String(s.clusterTable),
+		Key:                 idempotencyKey(key),
+		ConditionExpression: aws.String("#claim = :claim"),
+		ExpressionAttributeNames: map[string]string{
+			"#claim": "claim",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":claim": attrS(claim),
+		},
+	})
+	if err != nil && !isConditionFailure(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// idp state (authorization codes and pending logins) lives in the cluster table like the idempotency records,
+// its partkey is "<idpStatePrefix><kind>/<id>" (no valid cluster name) and its sortkey is idpStateRevision.
+// Expired state is removed by the dynamodb ttl.
+const (
+	idpStatePrefix   = "#idp/"
+	idpStateRevision = "IDP"
+)
+
+func idpStateKey(kind, id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"name":     attrS(idpStatePrefix + kind + "/" + id),
+		"revision": attrS(idpStateRevision),
+	}
+}
+
+// PutIDPState stores the state of the idp until the expiry. Returns ErrAlreadyExists if the id is taken.
+func (s *Store) PutIDPState(ctx context.Context, kind, id string, state []byte, expiry time.Time) error {
+	item := idpStateKey(kind, id)
+	item["state"] = attrB(state)
+	item[ExpiryAttribute] = attrN(expiry.Unix())
+	_, err := s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.clusterTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(#revision)"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// TakeIDPState removes the state of the idp and returns it, so that it can only be used once.
+// Returns ErrNotFound if the state does not exist or expired.
+func (s *Store) TakeIDPState(ctx context.Context, kind, id string) ([]byte, error) {
+	resp, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:    aws.String(s.clusterTable),
+		Key:          idpStateKey(kind, id),
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the ttl deletes expired items only eventually.
+	if resp.Attributes == nil || readN(resp.Attributes, ExpiryAttribute) < time.Now().Unix() {
+		return nil, ErrNotFound
+	}
+	return readB(resp.Attributes, "state"), nil
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// fakeStateTable answers PutItem and DeleteItem requests on items keyed by their name, without evaluating conditions.
+type fakeStateTable struct {
+	lock  sync.Mutex
+	items map[string]map[string]any
+}
+
+func (f *fakeStateTable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	req := struct {
+		Item map[string]any
+		Key  map[string]map[string]string
+	}{}
+	json.NewDecoder(r.Body).Decode(&req)
+	resp := map[string]any{}
+	switch target := r.Header.Get("X-Amz-Target"); target {
+	case "DynamoDB_20120810.PutItem":
+		f.items[req.Item["name"].(map[string]any)["S"].(string)] = req.Item
+	case "DynamoDB_20120810.DeleteItem":
+		name := req.Key["name"]["S"]
+		if item, ok := f.items[name]; ok {
+			resp["Attributes"] = item
+		}
+		delete(f.items, name)
+	default:
+		http.Error(w, "unsupported operation "+target, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestTakeIDPState(t *testing.T) {
+	dynamo := httptest.NewServer(&fakeStateTable{items: map[string]map[string]any{}})
+	defer dynamo.Close()
+	s := New(dynamodb.New(dynamodb.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(dynamo.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	}), "test", Tables{Cluster: "test-cluster"})
+	ctx := context.Background()
+	if err := s.PutIDPState(ctx, "code", "valid", []byte("grant"), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutIDPState(ctx, "code", "expired", []byte("grant"), time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		kind      string
+		id        string
+		wantState []byte
+		wantErr   error
+	}{
+		{name: "state of other kind", kind: "login", id: "valid", wantErr: ErrNotFound},
+		{name: "valid state", kind: "code", id: "valid", wantState: []byte("grant")},
+		{name: "state is taken once", kind: "code", id: "valid", wantErr: ErrNotFound},
+		{name: "expired state not yet deleted by the ttl", kind: "code", id: "expired", wantErr: ErrNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, err := s.TakeIDPState(ctx, test.kind, test.id)
+			if !errors.Is(err, test.wantErr) || !bytes.Equal(state, test.wantState) {
+				t.Fatalf("state = %q (%v), want %q (%v)", state, err, test.wantState, test.wantErr)
+			}
+		})
+	}
+}
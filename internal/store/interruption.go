@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// resumeAttribute marks deploying revisions that were interrupted by an operator shutdown,
+// it holds the action (up or destroy) that is resumed on the next start. Setting the state removes it.
+const resumeAttribute = "resume"
+
+const (
+	resumeUp      = "up"
+	resumeDestroy = "destroy"
+)
+
+// interruptedReason is recorded as error of interrupted revisions until they are resumed.
+const interruptedReason = "interrupted by operator shutdown, resumed on the next start"
+
+// Interruption is a deployment that was interrupted by an operator shutdown.
+type Interruption struct {
+	Operator bool
+	Name     string // cluster name, empty for operator revisions
+	Revision string
+	Destroy  bool
+}
+
+func resumeAction(destroy bool) string {
+	if destroy {
+		return resumeDestroy
+	}
+	return resumeUp
+}
+
+// MarkClusterInterrupted keeps the cluster revision deploying and marks it to be resumed.
+// The latest revision mirror is updated too if it still points to this revision.
+func (s *Store) MarkClusterInterrupted(ctx context.Context, name, revision string, destroy bool) error {
+	update := &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.clusterTable),
+		Key: map[string]types.AttributeValue{
+			"name":     attrS(name),
+			"revision": attrS(revision),
+		},
+		UpdateExpression:    aws.String("SET #resume = :resume, #error = :error, #updated = :updated"),
+		ConditionExpression: aws.String("attribute_exists(#revision)"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+			"#resume":   resumeAttribute,
+			"#error":    "error",
+			"#updated":  "updated_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":resume":  attrS(resumeAction(destroy)),
+			":error":   attrS(interruptedReason),
+			":updated": attrT(time.Now()),
+		},
+	}
+	if _, err := s.client.UpdateItem(ctx, update); err != nil {
+		if isConditionFailure(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	update.Key["revision"] = attrS(headRevision)
+	update.ConditionExpression = aws.String("#ref = :ref")
+	update.ExpressionAttributeNames["#ref"] = "ref"
+	update.ExpressionAttributeValues[":ref"] = attrS(revision)
+	delete(update.ExpressionAttributeNames, "#revision")
+	if _, err := s.client.UpdateItem(ctx, update); err != nil && !isConditionFailure(err) {
+		return err
+	}
+	return nil
+}
+
+// MarkOperatorInterrupted keeps the operator revision deploying and marks it to be resumed.
+func (s *Store) MarkOperatorInterrupted(ctx context.Context, revision string, destroy bool) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.operatorTable),
+		Key: map[string]types.AttributeValue{
+			"project":  attrS(s.project),
+			"revision": attrS(revision),
+		},
+		UpdateExpression:    aws.String("SET #resume = :resume, #error = :error, #updated = :updated"),
+		ConditionExpression: aws.String("attribute_exists(#revision)"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+			"#resume":   resumeAttribute,
+			"#error":    "error",
+			"#updated":  "updated_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":resume":  attrS(resumeAction(destroy)),
+			":error":   attrS(interruptedReason),
+			":updated": attrT(time.Now()),
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Interruptions returns the interrupted operator revisions and latest cluster revisions.
+func (s *Store) Interruptions(ctx context.Context) ([]Interruption, error) {
+	interruptions := []Interruption{}
+	operators := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:              aws.String(s.operatorTable),
+		KeyConditionExpression: aws.String("#project = :project"),
+		FilterExpression:       aws.String("attribute_exists(#resume)"),
+		ExpressionAttributeNames: map[string]string{
+			"#project": "project",
+			"#resume":  resumeAttribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":project": attrS(s.project),
+		},
+	})
+	for operators.HasMorePages() {
+		page, err := operators.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			interruptions = append(interruptions, Interruption{
+				Operator: true,
+				Revision: readS(item, "revision"),
+				Destroy:  readS(item, resumeAttribute) == resumeDestroy,
+			})
+		}
+	}
+	// only the latest revision of a cluster can be deploying, therefore the mirrors are sufficient.
+	clusters := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:              aws.String(s.clusterTable),
+		IndexName:              aws.String(ClusterRevisionIndex),
+		KeyConditionExpression: aws.String("#revision = :head"),
+		FilterExpression:       aws.String("attribute_exists(#resume)"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+			"#resume":   resumeAttribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":head": attrS(headRevision),
+		},
+	})
+	for clusters.HasMorePages() {
+		page, err := clusters.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			interruptions = append(interruptions, Interruption{
+				Name:     readS(item, "name"),
+				Revision: readS(item, "ref"),
+				Destroy:  readS(item, resumeAttribute) == resumeDestroy,
+			})
+		}
+	}
+	return interruptions, nil
+}
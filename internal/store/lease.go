@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/megakuul/miam/pkg/api/operator/v1/cluster"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+)
+
+const (
+	// heartbeatAttribute holds the last time the reconciler owning a deploying revision renewed its lease.
+	heartbeatAttribute = "heartbeat_at"
+	// stackProjectAttribute holds the pulumi project of the revision stack, it allows to release the stack lock without a checkout.
+	stackProjectAttribute = "stack_project"
+	// cancelAttribute asks the reconciler owning a deploying revision to cancel it on its next renewal.
+	cancelAttribute = "cancel_requested"
+)
+
+// Lease describes the ownership of a deploying revision.
+type Lease struct {
+	// Renewed is the last time the owner renewed the lease, or the creation of the revision if it never did.
+	Renewed time.Time
+	// Project is the pulumi project of the revision stack, empty if the stack was not checked out yet.
+	Project string
+}
+
+// RenewClusterLease records that the deploying cluster revision is still owned by a reconciler.
+// The pulumi project of the stack is recorded too if specified.
+// Returns whether the cancellation of the revision was requested. Fails with ErrConflict if it is no longer deploying.
+func (s *Store) RenewClusterLease(ctx context.Context, name, revision, project string) (bool, error) {
+	return s.renewLease(ctx, s.clusterTable, clusterKey(name, revision), cluster.State_DEPLOYING.String(), project)
+}
+
+// RenewOperatorLease records that the deploying operator revision is still owned by a reconciler.
+// The pulumi project of the stack is recorded too if specified.
+// Returns whether the cancellation of the revision was requested. Fails with ErrConflict if it is no longer deploying.
+func (s *Store) RenewOperatorLease(ctx context.Context, revision, project string) (bool, error) {
+	return s.renewLease(ctx, s.operatorTable, s.operatorKey(revision), operator.State_DEPLOYING.String(), project)
+}
+
+// ClusterLease returns the lease of a cluster revision.
+func (s *Store) ClusterLease(ctx context.Context, name, revision string) (Lease, error) {
+	return s.lease(ctx, s.clusterTable, clusterKey(name, revision))
+}
+
+// OperatorLease returns the lease of an operator revision.
+func (s *Store) OperatorLease(ctx context.Context, revision string) (Lease, error) {
+	return s.lease(ctx, s.operatorTable, s.operatorKey(revision))
+}
+
+// RequestClusterCancel asks the reconciler owning the deploying cluster revision to cancel it.
+// Fails with ErrConflict if the revision is no longer deploying.
+func (s *Store) RequestClusterCancel(ctx context.Context, name, revision string) error {
+	return s.requestCancel(ctx, s.clusterTable, clusterKey(name, revision), cluster.State_DEPLOYING.String())
+}
+
+// RequestOperatorCancel asks the reconciler owning the deploying operator revision to cancel it.
+// Fails with ErrConflict if the revision is no longer deploying.
+func (s *Store) RequestOperatorCancel(ctx context.Context, revision string) error {
+	return s.requestCancel(ctx, s.operatorTable, s.operatorKey(revision), operator.State_DEPLOYING.String())
+}
+
+func clusterKey(name, revision string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"name":     attrS(name),
+		"revision": attrS(revision),
+	}
+}
+
+func (s *Store) operatorKey(revision string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"project":  attrS(s.project),
+		"revision": attrS(revision),
+	}
+}
+
+func (s *Store) renewLease(ctx context.Context, table string, key map[string]types.AttributeValue, deploying, project string) (bool, error) {
+	update := &dynamodb.UpdateItemInput{
+		TableName:           aws.String(table),
+		Key:                 key,
+		UpdateExpression:    aws.String("SET #heartbeat = :heartbeat"),
+		ConditionExpression: aws.String("#state = :deploying"),
+		ExpressionAttributeNames: map[string]string{
+			"#heartbeat": heartbeatAttribute,
+			"#state":     "state",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":heartbeat": attrT(time.Now()),
+			":deploying": attrS(deploying),
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	}
+	if project != "" {
+		update.UpdateExpression = aws.String("SET #heartbeat = :heartbeat, #project = :project")
+		update.ExpressionAttributeNames["#project"] = stackProjectAttribute
+		update.ExpressionAttributeValues[":project"] = attrS(project)
+	}
+	resp, err := s.client.UpdateItem(ctx, update)
+	if err != nil {
+		if isConditionFailure(err) {
+			return false, ErrConflict
+		}
+		return false, err
+	}
+	return readBool(resp.Attributes, cancelAttribute), nil
+}
+
+func (s *Store) lease(ctx context.Context, table string, key map[string]types.AttributeValue) (Lease, error) {
+	resp, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(table),
+		Key:       key,
+	})
+	if err != nil {
+		return Lease{}, err
+	}
+	if resp.Item == nil {
+		return Lease{}, ErrNotFound
+	}
+	lease := Lease{
+		Renewed: readT(resp.Item, "created_at").AsTime(),
+		Project: readS(resp.Item, stackProjectAttribute),
+	}
+	if heartbeat := readT(resp.Item, heartbeatAttribute); heartbeat != nil && heartbeat.AsTime().After(lease.Renewed) {
+		lease.Renewed = heartbeat.AsTime()
+	}
+	return lease, nil
+}
+
+func (s *Store) requestCancel(ctx context.Context, table string, key map[string]types.AttributeValue, deploying string) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(table),
+		Key:                 key,
+		UpdateExpression:    aws.String("SET #cancel = :cancel"),
+		ConditionExpression: aws.String("#state = :deploying"),
+		ExpressionAttributeNames: map[string]string{
+			"#cancel": cancelAttribute,
+			"#state":  "state",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":cancel":    attrBool(true),
+			":deploying": attrS(deploying),
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrConflict
+		}
+		return err
+	}
+	return nil
+}
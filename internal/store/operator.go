@@ -0,0 +1,180 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/megakuul/miam/pkg/api/operator/v1/operator"
+	"google.golang.org/protobuf/proto"
+)
+
+func operatorStatus(item map[string]types.AttributeValue) *operator.OperatorStatus {
+	return &operator.OperatorStatus{
+		Revision:  readS(item, "revision"),
+		State:     operator.State(operator.State_value[readS(item, "state")]),
+		Error:     readS(item, "error"),
+		CreatedAt: readT(item, "created_at"),
+		UpdatedAt: readT(item, "updated_at"),
+		CreatedBy: readS(item, "created_by"),
+		Message:   readS(item, "message"),
+	}
+}
+
+// PutOperator inserts a new operator revision.
+func (s *Store) PutOperator(ctx context.Context, status *operator.OperatorStatus, config *operator.OperatorConfig) error {
+	rawConfig, err := proto.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to serialize config: %v", err)
+	}
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.operatorTable),
+		Item: map[string]types.AttributeValue{
+			"project":    attrS(s.project),
+			"revision":   attrS(status.GetRevision()),
+			"state":      attrS(status.GetState().String()),
+			"error":      attrS(status.GetError()),
+			"config":     attrB(rawConfig),
+			"created_at": attrT(status.GetCreatedAt().AsTime()),
+			"updated_at": attrT(status.GetUpdatedAt().AsTime()),
+			"created_by": attrS(status.GetCreatedBy()),
+			"message":    attrS(status.GetMessage()),
+		},
+		ConditionExpression: aws.String("attribute_not_exists(#revision)"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// GetOperator returns all revisions of the operator ordered from newest to oldest.
+func (s *Store) GetOperator(ctx context.Context) ([]*operator.OperatorStatus, error) {
+	revisions := []*operator.OperatorStatus{}
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:              aws.String(s.operatorTable),
+		KeyConditionExpression: aws.String("#project = :project"),
+		// revisions are ulids, therefore the sortkey order is the chronological order.
+		ScanIndexForward: aws.Bool(false),
+		ExpressionAttributeNames: map[string]string{
+			"#project": "project",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":project": attrS(s.project),
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			revisions = append(revisions, operatorStatus(item))
+		}
+	}
+	return revisions, nil
+}
+
+// DeleteOperatorRevisions removes the specified revisions of the operator.
+func (s *Store) DeleteOperatorRevisions(ctx context.Context, revisions []string) error {
+	keys := []map[string]types.AttributeValue{}
+	for _, revision := range revisions {
+		keys = append(keys, s.operatorKey(revision))
+	}
+	return s.deleteItems(ctx, s.operatorTable, keys)
+}
+
+// ExpireOperator sets the expiry of all operator revisions, after which dynamodb removes its entire history.
+func (s *Store) ExpireOperator(ctx context.Context, expiry time.Time) error {
+	return s.expireItems(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.operatorTable),
+		KeyConditionExpression: aws.String("#project = :project"),
+		ProjectionExpression:   aws.String("#project, #revision"),
+		ExpressionAttributeNames: map[string]string{
+			"#project":  "project",
+			"#revision": "revision",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":project": attrS(s.project),
+		},
+	}, expiry)
+}
+
+// Ping checks that the operator table is reachable with the credentials of the store.
+func (s *Store) Ping(ctx context.Context) error {
+	_, err := s.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.operatorTable),
+		KeyConditionExpression: aws.String("#project = :project"),
+		Limit:                  aws.Int32(1),
+		ExpressionAttributeNames: map[string]string{
+			"#project": "project",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":project": attrS(s.project),
+		},
+	})
+	return err
+}
+
+// DescribeOperator returns the status and config of an operator revision.
+func (s *Store) DescribeOperator(ctx context.Context, revision string) (*operator.OperatorStatus, *operator.OperatorConfig, error) {
+	resp, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.operatorTable),
+		Key: map[string]types.AttributeValue{
+			"project":  attrS(s.project),
+			"revision": attrS(revision),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.Item == nil {
+		return nil, nil, ErrNotFound
+	}
+	config := &operator.OperatorConfig{}
+	if err := proto.Unmarshal(readB(resp.Item, "config"), config); err != nil {
+		return nil, nil, fmt.Errorf("failed to deserialize config: %v", err)
+	}
+	return operatorStatus(resp.Item), config, nil
+}
+
+// SetOperatorState updates the state of an operator revision.
+func (s *Store) SetOperatorState(ctx context.Context, revision string, state operator.State, reason string) error {
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(s.operatorTable),
+		Key: map[string]types.AttributeValue{
+			"project":  attrS(s.project),
+			"revision": attrS(revision),
+		},
+		UpdateExpression:    aws.String("SET #state = :state, #error = :error, #updated = :updated REMOVE #resume"),
+		ConditionExpression: aws.String("attribute_exists(#revision)"),
+		ExpressionAttributeNames: map[string]string{
+			"#revision": "revision",
+			"#state":    "state",
+			"#error":    "error",
+			"#updated":  "updated_at",
+			"#resume":   resumeAttribute,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":state":   attrS(state.String()),
+			":error":   attrS(reason),
+			":updated": attrT(time.Now()),
+		},
+	})
+	if err != nil {
+		if isConditionFailure(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/megakuul/miam/internal/auth"
+	"github.com/megakuul/miam/pkg/api/iam/v1/serviceaccount"
+	"github.com/oklog/ulid/v2"
+)
+
+func serviceAccountFromItem(item map[string]types.AttributeValue) *serviceaccount.ServiceAccount {
+	return &serviceaccount.ServiceAccount{
+		Name:        readS(item, "name"),
+		Description: readS(item, "description"),
+		Disabled:    readBool(item, "disabled"),
+		CreatedAt:   readT(item, "created_at"),
+		UpdatedAt:   readT(item, "updated_at"),
+	}
+}
+
+// CreateServiceAccount inserts a new service account.
+func (s *Store) CreateServiceAccount(ctx context.Context, account *serviceaccount.ServiceAccount) (*serviceaccount.ServiceAccount, error) {
+	now := time.Now()
+	item := iamKey(kindServiceAccount, account.GetName())
+	// the uid distinguishes the account from accounts previously created with the same name, api keys are bound to it.
+	item["uid"] = attrS(ulid.Make().String())
+	item["description"] = attrS(account.GetDescription())
+	item["disabled"] = attrBool(account.GetDisabled())
+	item["created_at"] = attrT(now)
+	item["updated_at"] = attrT(now)
+	if err := s.createIAM(ctx, item); err != nil {
+		return nil, err
+	}
+	return serviceAccountFromItem(item), nil
+}
+
+// UpdateServiceAccount replaces the attributes of an existing service account.
+func (s *Store) UpdateServiceAccount(ctx context.Context, account *serviceaccount.ServiceAccount) (*serviceaccount.ServiceAccount, error) {
+	item, err := s.updateIAM(ctx, kindServiceAccount, account.GetName(),
+		"SET #description = :description, #disabled = :disabled, #updated_at = :updated_at",
+		map[string]string{
+			"#description": "description",
+			"#disabled":    "disabled",
+			"#updated_at":  "updated_at",
+		},
+		map[string]types.AttributeValue{
+			":description": attrS(account.GetDescription()),
+			":disabled":    attrBool(account.GetDisabled()),
+			":updated_at":  attrT(time.Now()),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return serviceAccountFromItem(item), nil
+}
+
+// GetServiceAccount returns a service account.
+func (s *Store) GetServiceAccount(ctx context.Context, name string) (*serviceaccount.ServiceAccount, error) {
+	item, err := s.getIAM(ctx, kindServiceAccount, name)
+	if err != nil {
+		return nil, err
+	}
+	return serviceAccountFromItem(item), nil
+}
+
+// ListServiceAccounts returns one page of service accounts ordered by name.
+func (s *Store) ListServiceAccounts(ctx context.Context, pageSize int, pageToken string) ([]*serviceaccount.ServiceAccount, string, error) {
+	items, next, err := s.listIAM(ctx, kindServiceAccount, pageSize, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	accounts := []*serviceaccount.ServiceAccount{}
+	for _, item := range items {
+		accounts = append(accounts, serviceAccountFromItem(item))
+	}
+	return accounts, next, nil
+}
+
+// DeleteServiceAccount removes a service account and revokes all of its api keys.
+// The account is removed together with its keys in transactions of up to maxTransactItems items,
+// keys of accounts with more keys are removed in subsequent transactions, they are rejected once the account is gone.
+func (s *Store) DeleteServiceAccount(ctx context.Context, name string) error {
+	if _, err := s.getIAM(ctx, kindServiceAccount, name); err != nil {
+		return err
+	}
+	keys, err := s.ListAPIKeys(ctx, name)
+	if err != nil {
+		return err
+	}
+	items := []types.TransactWriteItem{{
+		Delete: &types.Delete{
+			TableName:           aws.String(s.iamTable),
+			Key:                 iamKey(kindServiceAccount, name),
+			ConditionExpression: aws.String("attribute_exists(#name)"),
+			ExpressionAttributeNames: map[string]string{
+				"#name": "name",
+			},
+		},
+	}}
+	for _, key := range keys {
+		items = append(items, types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName: aws.String(s.iamTable),
+				Key:       iamKey(kindAPIKey, key.GetId()),
+			},
+		})
+	}
+	return s.transactIAM(ctx, items)
+}
+
+func apiKeyFromItem(item map[string]types.AttributeValue) *serviceaccount.ApiKey {
+	return &serviceaccount.ApiKey{
+		Id:             readS(item, "name"),
+		ServiceAccount: readS(item, "service_account"),
+		Description:    readS(item, "description"),
+		Procedures:     readL(item, "procedures"),
+		Clusters:       readL(item, "clusters"),
+		ExpiresAt:      readT(item, "expires_at"),
+		CreatedAt:      readT(item, "created_at"),
+	}
+}
+
+// CreateAPIKey inserts a new api key with a generated id and secret and returns the key with its full secret.
+// Only the hash of the secret is stored. The key is bound to the current instance of its service account,
+// fails with ErrNotFound if the account does not exist.
+func (s *Store) CreateAPIKey(ctx context.Context, key *serviceaccount.ApiKey) (*serviceaccount.ApiKey, string, error) {
+	account, err := s.getIAM(ctx, kindServiceAccount, key.GetServiceAccount())
+	if err != nil {
+		return nil, "", err
+	}
+	id, secret, full := auth.NewAPIKey()
+	item := iamKey(kindAPIKey, id)
+	item["service_account"] = attrS(key.GetServiceAccount())
+	item["service_account_uid"] = attrS(readS(account, "uid"))
+	item["description"] = attrS(key.GetDescription())
+	item["procedures"] = attrL(key.GetProcedures())
+	item["clusters"] = attrL(key.GetClusters())
+	item["secret_hash"] = attrS(auth.HashAPIKeySecret(secret))
+	item["created_at"] = attrT(time.Now())
+	if key.GetExpiresAt() != nil {
+		item["expires_at"] = attrT(key.GetExpiresAt().AsTime())
+	}
+	accountCheck := &types.ConditionCheck{
+		TableName:           aws.String(s.iamTable),
+		Key:                 iamKey(kindServiceAccount, key.GetServiceAccount()),
+		ConditionExpression: aws.String("attribute_exists(#name)"),
+		ExpressionAttributeNames: map[string]string{
+			"#name": "name",
+		},
+	}
+	// the account must not be replaced by an account with the same name between the read and the write.
+	if uid, ok := account["uid"]; ok {
+		accountCheck.ConditionExpression = aws.String("#uid = :uid")
+		accountCheck.ExpressionAttributeNames = map[string]string{"#uid": "uid"}
+		accountCheck.ExpressionAttributeValues = map[string]types.AttributeValue{":uid": uid}
+	}
+	_, err = s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{ConditionCheck: accountCheck},
+			{Put: &types.Put{
+				TableName:           aws.String(s.iamTable),
+				Item:                item,
+				ConditionExpression: aws.String("attribute_not_exists(#name)"),
+				ExpressionAttributeNames: map[string]string{
+					"#name": "name",
+				},
+			}},
+		},
+	})
+	if err != nil {
+		// the key id is generated, therefore only the account check can fail.
+		if isConditionFailure(err) {
+			return nil, "", ErrNotFound
+		}
+		return nil, "", err
+	}
+	return apiKeyFromItem(item), full, nil
+}
+
+// ListAPIKeys returns the api keys of a service account ordered by id.
+func (s *Store) ListAPIKeys(ctx context.Context, account string) ([]*serviceaccount.ApiKey, error) {
+	items, _, err := s.listIAM(ctx, kindAPIKey, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	keys := []*serviceaccount.ApiKey{}
+	for _, item := range items {
+		if readS(item, "service_account") == account {
+			keys = append(keys, apiKeyFromItem(item))
+		}
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey removes an api key.
+func (s *Store) RevokeAPIKey(ctx context.Context, id string) error {
+	return s.deleteIAM(ctx, kindAPIKey, id)
+}
+
+// LookupAPIKey returns the stored part of an api key (see auth.APIKeySource).
+func (s *Store) LookupAPIKey(ctx context.Context, id string) (*auth.APIKey, error) {
+	item, err := s.getIAM(ctx, kindAPIKey, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, auth.ErrUnknownAPIKey
+		}
+		return nil, err
+	}
+	key := &auth.APIKey{
+		ServiceAccount:    readS(item, "service_account"),
+		ServiceAccountUID: readS(item, "service_account_uid"),
+		SecretHash:        readS(item, "secret_hash"),
+		Procedures:        readL(item, "procedures"),
+		Clusters:          readL(item, "clusters"),
+	}
+	if expires := readT(item, "expires_at"); expires != nil {
+		key.ExpiresAt = expires.AsTime()
+	}
+	return key, nil
+}
+
+// LookupServiceAccount returns the state of a service account relevant to authentication (see auth.ServiceAccountSource).
+func (s *Store) LookupServiceAccount(ctx context.Context, name string) (*auth.ServiceAccount, error) {
+	item, err := s.getIAM(ctx, kindServiceAccount, name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, auth.ErrUnknownServiceAccount
+		}
+		return nil, err
+	}
+	return &auth.ServiceAccount{
+		UID:      readS(item, "uid"),
+		Disabled: readBool(item, "disabled"),
+	}, nil
+}
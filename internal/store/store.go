@@ -0,0 +1,258 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/megakuul/miam/internal/auth"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// ErrNotFound is returned if the requested item does not exist.
+	ErrNotFound = errors.New("item not found")
+	// ErrConflict is returned if a write was rejected because the item is in a conflicting state.
+	ErrConflict = errors.New("item is in a conflicting state")
+	// ErrInvalidToken is returned if a page token cannot be decoded or belongs to another listing.
+	ErrInvalidToken = errors.New("invalid page token")
+	// ErrAlreadyExists is returned if an item that is created already exists.
+	ErrAlreadyExists = errors.New("item already exists")
+)
+
+// Store persists the revisions of clusters and the operator in dynamodb.
+type Store struct {
+	client        *dynamodb.Client
+	project       string
+	clusterTable  string
+	operatorTable string
+	auditTable    string
+	iamTable      string
+
+	// the last loaded directory, see Directory.
+	directoryLock    sync.Mutex
+	directory        *auth.Directory
+	directoryVersion int64
+	directoryLoaded  time.Time
+}
+
+// Tables names the dynamodb tables of a store.
+type Tables struct {
+	Cluster  string
+	Operator string
+	Audit    string
+	IAM      string
+}
+
+// DefaultTables returns the names of the tables provisioned for the specified project.
+func DefaultTables(project string) Tables {
+	return Tables{
+		Cluster:  ClusterTable(project),
+		Operator: OperatorTable(project),
+		Audit:    AuditTable(project),
+		IAM:      IAMTable(project),
+	}
+}
+
+// New creates a store operating on the tables of the specified project.
+func New(client *dynamodb.Client, project string, tables Tables) *Store {
+	return &Store{
+		client:        client,
+		project:       project,
+		clusterTable:  tables.Cluster,
+		operatorTable: tables.Operator,
+		auditTable:    tables.Audit,
+		iamTable:      tables.IAM,
+	}
+}
+
+// ClusterTable returns the name of the cluster revision table of a project.
+func ClusterTable(project string) string {
+	return fmt.Sprintf("%s-cluster", project)
+}
+
+// OperatorTable returns the name of the operator revision table of a project.
+func OperatorTable(project string) string {
+	return fmt.Sprintf("%s-operator", project)
+}
+
+// AuditTable returns the name of the audit log table of a project.
+func AuditTable(project string) string {
+	return fmt.Sprintf("%s-audit", project)
+}
+
+// IAMTable returns the name of the identity table of a project.
+func IAMTable(project string) string {
+	return fmt.Sprintf("%s-iam", project)
+}
+
+func attrS(value string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: value}
+}
+
+func attrN(value int64) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(value, 10)}
+}
+
+// attrT stores timestamps as unix milliseconds.
+func attrT(value time.Time) types.AttributeValue {
+	return attrN(value.UnixMilli())
+}
+
+func attrB(value []byte) types.AttributeValue {
+	return &types.AttributeValueMemberB{Value: value}
+}
+
+func attrBool(value bool) types.AttributeValue {
+	return &types.AttributeValueMemberBOOL{Value: value}
+}
+
+func attrL(values []string) types.AttributeValue {
+	list := &types.AttributeValueMemberL{Value: []types.AttributeValue{}}
+	for _, value := range values {
+		list.Value = append(list.Value, attrS(value))
+	}
+	return list
+}
+
+func readS(item map[string]types.AttributeValue, key string) string {
+	if value, ok := item[key].(*types.AttributeValueMemberS); ok {
+		return value.Value
+	}
+	return ""
+}
+
+func readN(item map[string]types.AttributeValue, key string) int64 {
+	if value, ok := item[key].(*types.AttributeValueMemberN); ok {
+		n, _ := strconv.ParseInt(value.Value, 10, 64)
+		return n
+	}
+	return 0
+}
+
+func readT(item map[string]types.AttributeValue, key string) *timestamppb.Timestamp {
+	if _, ok := item[key]; !ok {
+		return nil
+	}
+	return timestamppb.New(time.UnixMilli(readN(item, key)))
+}
+
+func readB(item map[string]types.AttributeValue, key string) []byte {
+	if value, ok := item[key].(*types.AttributeValueMemberB); ok {
+		return value.Value
+	}
+	return nil
+}
+
+func readBool(item map[string]types.AttributeValue, key string) bool {
+	if value, ok := item[key].(*types.AttributeValueMemberBOOL); ok {
+		return value.Value
+	}
+	return false
+}
+
+// readSS reads a string set, sets are used where elements are added and removed atomically.
+func readSS(item map[string]types.AttributeValue, key string) []string {
+	if value, ok := item[key].(*types.AttributeValueMemberSS); ok {
+		return value.Value
+	}
+	return []string{}
+}
+
+func readL(item map[string]types.AttributeValue, key string) []string {
+	values := []string{}
+	if list, ok := item[key].(*types.AttributeValueMemberL); ok {
+		for _, value := range list.Value {
+			if s, ok := value.(*types.AttributeValueMemberS); ok {
+				values = append(values, s.Value)
+			}
+		}
+	}
+	return values
+}
+
+// maxTransactItems is the maximum number of items dynamodb accepts in a single transaction.
+const maxTransactItems = 100
+
+// deleteItems removes the items with the specified keys from a table.
+func (s *Store) deleteItems(ctx context.Context, table string, keys []map[string]types.AttributeValue) error {
+	// batch writes are limited to 25 items per request.
+	for chunk := range slices.Chunk(keys, 25) {
+		requests := []types.WriteRequest{}
+		for _, key := range chunk {
+			requests = append(requests, types.WriteRequest{
+				DeleteRequest: &types.DeleteRequest{Key: key},
+			})
+		}
+		pending := map[string][]types.WriteRequest{table: requests}
+		for len(pending) > 0 {
+			resp, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: pending,
+			})
+			if err != nil {
+				return err
+			}
+			pending = resp.UnprocessedItems
+		}
+	}
+	return nil
+}
+
+// expireItems sets the expiry of all items returned by the query, the query must project the key attributes only.
+// Every page is updated in transactions of up to maxTransactItems items.
+func (s *Store) expireItems(ctx context.Context, query *dynamodb.QueryInput, expiry time.Time) error {
+	paginator := dynamodb.NewQueryPaginator(s.client, query)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for chunk := range slices.Chunk(page.Items, maxTransactItems) {
+			items := []types.TransactWriteItem{}
+			for _, key := range chunk {
+				items = append(items, types.TransactWriteItem{
+					Update: &types.Update{
+						TableName:        query.TableName,
+						Key:              key,
+						UpdateExpression: aws.String("SET #expiry = :expiry"),
+						ExpressionAttributeNames: map[string]string{
+							"#expiry": ExpiryAttribute,
+						},
+						ExpressionAttributeValues: map[string]types.AttributeValue{
+							":expiry": attrN(expiry.Unix()),
+						},
+					},
+				})
+			}
+			_, err := s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isConditionFailure reports whether the error was caused by a failed write condition.
+func isConditionFailure(err error) bool {
+	var condErr *types.ConditionalCheckFailedException
+	if errors.As(err, &condErr) {
+		return true
+	}
+	var txErr *types.TransactionCanceledException
+	if errors.As(err, &txErr) {
+		for _, reason := range txErr.CancellationReasons {
+			if reason.Code != nil && *reason.Code == "ConditionalCheckFailed" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Package tracing sets up the opentelemetry tracer provider and instruments the store client.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the service reported by the spans of the operator.
+const ServiceName = "miam-operator"
+
+// Setup installs a global tracer provider exporting spans via otlp/http to the endpoint (e.g. http://localhost:4318).
+// Without endpoint tracing stays disabled. The returned function flushes the pending spans on shutdown.
+func Setup(ctx context.Context, endpoint string, sampleRatio float64) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp exporter: %v", err)
+	}
+	provider := NewProvider(sdktrace.NewBatchSpanProcessor(exporter), sampleRatio)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// NewProvider creates a tracer provider for the operator passing its spans to the processor
+// (e.g. a simple span processor with an in-memory exporter).
+// Traces are sampled with the ratio unless the parent span was sampled.
+func NewProvider(processor sdktrace.SpanProcessor, sampleRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	)
+}
+
+// StoreOption creates a span for every dynamodb operation of the store client.
+// The middleware is added after the service metadata, which provides the operation name.
+func StoreOption() func(*dynamodb.Options) {
+	tracer := otel.Tracer("github.com/megakuul/miam/internal/store")
+	return func(o *dynamodb.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("StoreTracing", func(
+				ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+			) (middleware.InitializeOutput, middleware.Metadata, error) {
+				operation := awsmiddleware.GetOperationName(ctx)
+				ctx, span := tracer.Start(ctx, "DynamoDB."+operation,
+					trace.WithSpanKind(trace.SpanKindClient),
+					trace.WithAttributes(
+						semconv.RPCSystemKey.String("aws-api"),
+						semconv.RPCService("DynamoDB"),
+						semconv.RPCMethod(operation),
+					),
+				)
+				defer span.End()
+				out, metadata, err := next.HandleInitialize(ctx, in)
+				RecordError(span, err)
+				return out, metadata, err
+			}), middleware.After)
+		})
+	}
+}
+
+// RecordError marks the span as failed if err is not nil.
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewProvider(t *testing.T) {
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	tests := []struct {
+		name        string
+		sampleRatio float64
+		parent      trace.SpanContext
+		sampled     bool
+	}{
+		{name: "root span sampled", sampleRatio: 1, sampled: true},
+		{name: "root span not sampled", sampleRatio: 0, sampled: false},
+		{name: "sampled parent overrides ratio", sampleRatio: 0, parent: parent, sampled: true},
+		{name: "unsampled parent overrides ratio", sampleRatio: 1, parent: parent.WithTraceFlags(0), sampled: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := NewProvider(sdktrace.NewSimpleSpanProcessor(exporter), test.sampleRatio)
+			defer provider.Shutdown(context.Background())
+			ctx := trace.ContextWithRemoteSpanContext(context.Background(), test.parent)
+			_, span := provider.Tracer("test").Start(ctx, "test")
+			span.End()
+			spans := exporter.GetSpans()
+			if sampled := len(spans) > 0; sampled != test.sampled {
+				t.Fatalf("sampled = %v, want %v", sampled, test.sampled)
+			}
+			if test.sampled && test.parent.IsValid() && spans[0].Parent.TraceID() != test.parent.TraceID() {
+				t.Fatalf("trace = %s, want %s", spans[0].Parent.TraceID(), test.parent.TraceID())
+			}
+		})
+	}
+}
+
+func TestStoreOption(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewProvider(sdktrace.NewSimpleSpanProcessor(exporter), 1)
+	defer provider.Shutdown(context.Background())
+	otel.SetTracerProvider(provider)
+
+	dynamo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		if r.Header.Get("X-Amz-Target") == "DynamoDB_20120810.DeleteItem" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"__type":  "com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException",
+				"message": "The conditional request failed",
+			})
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer dynamo.Close()
+	client := dynamodb.New(dynamodb.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(dynamo.URL),
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	}, StoreOption())
+	key := map[string]types.AttributeValue{"name": &types.AttributeValueMemberS{Value: "test"}}
+
+	tests := []struct {
+		name   string
+		call   func(ctx context.Context) error
+		span   string
+		status codes.Code
+	}{
+		{
+			name: "successful operation",
+			call: func(ctx context.Context) error {
+				_, err := client.GetItem(ctx, &dynamodb.GetItemInput{TableName: aws.String("test"), Key: key})
+				return err
+			},
+			span:   "DynamoDB.GetItem",
+			status: codes.Unset,
+		},
+		{
+			name: "failed operation",
+			call: func(ctx context.Context) error {
+				_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{TableName: aws.String("test"), Key: key})
+				if err == nil {
+					t.Fatal("delete did not fail")
+				}
+				return nil
+			},
+			span:   "DynamoDB.DeleteItem",
+			status: codes.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exporter.Reset()
+			ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+			if err := test.call(ctx); err != nil {
+				t.Fatal(err)
+			}
+			parent.End()
+			spans := exporter.GetSpans()
+			if len(spans) != 2 {
+				t.Fatalf("recorded %d spans, want 2", len(spans))
+			}
+			span := spans[0]
+			if span.Name != test.span {
+				t.Fatalf("span = %q, want %q", span.Name, test.span)
+			}
+			if span.SpanKind != trace.SpanKindClient {
+				t.Fatalf("span kind = %v, want %v", span.SpanKind, trace.SpanKindClient)
+			}
+			if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+				t.Fatal("span is not a child of the request span")
+			}
+			if span.Status.Code != test.status {
+				t.Fatalf("status = %v, want %v", span.Status.Code, test.status)
+			}
+		})
+	}
+}
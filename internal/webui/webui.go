@@ -0,0 +1,130 @@
+// Package webui serves the static build of the web ui as single page application.
+package webui
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// indexFile is the fallback page rendering all routes that are not a static file.
+	indexFile = "index.html"
+	// immutablePrefix contains the assets with content hashed names, they never change.
+	immutablePrefix = "_app/immutable/"
+	// configElement is the id of the json script element carrying the runtime config in the index page.
+	configElement = "miam-config"
+)
+
+// Config is the runtime config of the ui, it is injected into the index page.
+type Config struct {
+	// APIURL is the base url of the operator api, the ui uses its own origin if empty.
+	APIURL string     `json:"apiUrl,omitempty"`
+	OIDC   OIDCConfig `json:"oidc"`
+}
+
+// OIDCConfig describes how the ui signs in users, sign-in is disabled if the issuer is empty.
+type OIDCConfig struct {
+	Issuer   string   `json:"issuer,omitempty"`
+	ClientID string   `json:"clientId,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+}
+
+type file struct {
+	content []byte
+	etag    string
+}
+
+// Server serves the files of the ui, they are loaded into memory once.
+type Server struct {
+	files map[string]*file
+}
+
+// New loads the ui files and injects the config into the index page.
+func New(files fs.FS, config Config) (*Server, error) {
+	s := &Server{files: map[string]*file{}}
+	err := fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return err
+		}
+		if name == indexFile {
+			if content, err = inject(content, config); err != nil {
+				return err
+			}
+		}
+		digest := sha256.Sum256(content)
+		s.files[name] = &file{content: content, etag: `"` + hex.EncodeToString(digest[:16]) + `"`}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ui files: %v", err)
+	}
+	if _, ok := s.files[indexFile]; !ok {
+		return nil, fmt.Errorf("ui build does not contain %s", indexFile)
+	}
+	return s, nil
+}
+
+// inject adds the config as json script element to the head of the page.
+// json encoding escapes '<', so the config cannot close the element.
+func inject(page []byte, config Config) ([]byte, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	head := bytes.Index(page, []byte("</head>"))
+	if head < 0 {
+		return nil, fmt.Errorf("%s has no head element", indexFile)
+	}
+	element := fmt.Sprintf(`<script id="%s" type="application/json">%s</script>`, configElement, raw)
+	return append(append(append([]byte{}, page[:head]...), element...), page[head:]...), nil
+}
+
+// Register serves the ui at / next to the other handlers of the mux, more specific patterns take precedence.
+func (s *Server) Register(mux *http.ServeMux) {
+	// the pattern has no method, "GET /" would conflict with the service patterns which match all methods.
+	mux.HandleFunc("/", s.handle)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	f, ok := s.files[name]
+	switch {
+	case ok && name != indexFile:
+	case name != "" && name != indexFile && path.Ext(name) != "":
+		// missing assets are not rendered by the index page, the route of a page has no extension.
+		http.NotFound(w, r)
+		return
+	default:
+		name, f = indexFile, s.files[indexFile]
+	}
+	w.Header().Set("ETag", f.etag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	switch {
+	case strings.HasPrefix(name, immutablePrefix):
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	case name == indexFile:
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Frame-Options", "DENY")
+	default:
+		w.Header().Set("Cache-Control", "public, max-age=3600, must-revalidate")
+	}
+	// ServeContent derives the content type from the name and answers conditional and range requests.
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(f.content))
+}
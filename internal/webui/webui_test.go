@@ -0,0 +1,123 @@
+package webui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestServer(t *testing.T) {
+	server, err := New(fstest.MapFS{
+		"index.html":                {Data: []byte("<html><head><title>miam</title></head><body></body></html>")},
+		"favicon.png":               {Data: []byte("png")},
+		"_app/immutable/app.123.js": {Data: []byte("console.log(1)")},
+	}, Config{OIDC: OIDCConfig{Issuer: "https://miam.test", ClientID: "web", Scopes: []string{"openid"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	server.Register(mux)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		status       int
+		index        bool
+		cacheControl string
+	}{
+		{name: "root renders index", path: "/", status: http.StatusOK, index: true, cacheControl: "no-cache"},
+		{name: "page route falls back to index", path: "/clusters/prod-1", status: http.StatusOK, index: true, cacheControl: "no-cache"},
+		{name: "index by name", path: "/index.html", status: http.StatusOK, index: true, cacheControl: "no-cache"},
+		{name: "immutable asset", path: "/_app/immutable/app.123.js", status: http.StatusOK, cacheControl: "public, max-age=31536000, immutable"},
+		{name: "static file", path: "/favicon.png", status: http.StatusOK, cacheControl: "public, max-age=3600, must-revalidate"},
+		{name: "missing asset", path: "/_app/immutable/app.456.js", status: http.StatusNotFound},
+		{name: "head request", method: http.MethodHead, path: "/", status: http.StatusOK, cacheControl: "no-cache"},
+		{name: "other method", method: http.MethodPost, path: "/", status: http.StatusMethodNotAllowed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(method, test.path, nil))
+			if recorder.Code != test.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, test.status)
+			}
+			if got := recorder.Header().Get("Cache-Control"); got != test.cacheControl {
+				t.Fatalf("Cache-Control = %q, want %q", got, test.cacheControl)
+			}
+			if index := strings.Contains(recorder.Body.String(), `id="miam-config"`); index != test.index {
+				t.Fatalf("index rendered = %v, want %v", index, test.index)
+			}
+		})
+	}
+}
+
+func TestServerConditionalRequest(t *testing.T) {
+	server, err := New(fstest.MapFS{"index.html": {Data: []byte("<head></head>")}}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	server.handle(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	etag := recorder.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("index has no etag")
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	server.handle(recorder, req)
+	if recorder.Code != http.StatusNotModified {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusNotModified)
+	}
+}
+
+func TestInject(t *testing.T) {
+	element := regexp.MustCompile(`<script id="miam-config" type="application/json">(.*)</script></head>`)
+	tests := []struct {
+		name    string
+		page    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "config", page: "<html><head></head></html>", config: Config{APIURL: "https://api.miam.test"}},
+		{name: "markup is escaped", page: "<head></head>", config: Config{APIURL: "</script><script>alert(1)</script>"}},
+		{name: "page without head", page: "<html></html>", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page, err := inject([]byte(test.page), test.config)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("config was injected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			match := element.FindSubmatch(page)
+			if match == nil {
+				t.Fatalf("page has no config element: %s", page)
+			}
+			if strings.Contains(string(match[1]), "<") {
+				t.Fatalf("config contains markup: %s", match[1])
+			}
+			config := Config{}
+			if err := json.Unmarshal(match[1], &config); err != nil {
+				t.Fatal(err)
+			}
+			if config.APIURL != test.config.APIURL {
+				t.Fatalf("api url = %q, want %q", config.APIURL, test.config.APIURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: iam/v1/authorize/service.proto
+
+package authorize
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_iam_v1_authorize_service_proto protoreflect.FileDescriptor
+
+const file_iam_v1_authorize_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1eiam/v1/authorize/service.proto\x12\x10iam.v1.authorize\x1a\x1eiam/v1/authorize/message.proto2^\n" +
+	"\x10AuthorizeService\x12J\n" +
+	"\x05Check\x12\x1e.iam.v1.authorize.CheckRequest\x1a\x1f.iam.v1.authorize.CheckResponse\"\x00B3Z1github.com/megakuul/miam/pkg/api/iam/v1/authorizeb\x06proto3"
+
+var file_iam_v1_authorize_service_proto_goTypes = []any{
+	(*CheckRequest)(nil),  // 0: iam.v1.authorize.CheckRequest
+	(*CheckResponse)(nil), // 1: iam.v1.authorize.CheckResponse
+}
+var file_iam_v1_authorize_service_proto_depIdxs = []int32{
+	0, // 0: iam.v1.authorize.AuthorizeService.Check:input_type -> iam.v1.authorize.CheckRequest
+	1, // 1: iam.v1.authorize.AuthorizeService.Check:output_type -> iam.v1.authorize.CheckResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_iam_v1_authorize_service_proto_init() }
+func file_iam_v1_authorize_service_proto_init() {
+	if File_iam_v1_authorize_service_proto != nil {
+		return
+	}
+	file_iam_v1_authorize_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_iam_v1_authorize_service_proto_rawDesc), len(file_iam_v1_authorize_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_iam_v1_authorize_service_proto_goTypes,
+		DependencyIndexes: file_iam_v1_authorize_service_proto_depIdxs,
+	}.Build()
+	File_iam_v1_authorize_service_proto = out.File
+	file_iam_v1_authorize_service_proto_goTypes = nil
+	file_iam_v1_authorize_service_proto_depIdxs = nil
+}
@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: iam/v1/group/service.proto
+
+package groupconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	group "github.com/megakuul/miam/pkg/api/iam/v1/group"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// GroupServiceName is the fully-qualified name of the GroupService service.
+	GroupServiceName = "iam.v1.group.GroupService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// GroupServiceListProcedure is the fully-qualified name of the GroupService's List RPC.
+	GroupServiceListProcedure = "/iam.v1.group.GroupService/List"
+	// GroupServiceGetProcedure is the fully-qualified name of the GroupService's Get RPC.
+	GroupServiceGetProcedure = "/iam.v1.group.GroupService/Get"
+	// GroupServiceCreateProcedure is the fully-qualified name of the GroupService's Create RPC.
+	GroupServiceCreateProcedure = "/iam.v1.group.GroupService/Create"
+	// GroupServiceUpdateProcedure is the fully-qualified name of the GroupService's Update RPC.
+	GroupServiceUpdateProcedure = "/iam.v1.group.GroupService/Update"
+	// GroupServiceDeleteProcedure is the fully-qualified name of the GroupService's Delete RPC.
+	GroupServiceDeleteProcedure = "/iam.v1.group.GroupService/Delete"
+	// GroupServiceAddMemberProcedure is the fully-qualified name of the GroupService's AddMember RPC.
+	GroupServiceAddMemberProcedure = "/iam.v1.group.GroupService/AddMember"
+	// GroupServiceRemoveMemberProcedure is the fully-qualified name of the GroupService's RemoveMember
+	// RPC.
+	GroupServiceRemoveMemberProcedure = "/iam.v1.group.GroupService/RemoveMember"
+)
+
+// GroupServiceClient is a client for the iam.v1.group.GroupService service.
+type GroupServiceClient interface {
+	List(context.Context, *connect.Request[group.ListRequest]) (*connect.Response[group.ListResponse], error)
+	Get(context.Context, *connect.Request[group.GetRequest]) (*connect.Response[group.GetResponse], error)
+	Create(context.Context, *connect.Request[group.CreateRequest]) (*connect.Response[group.CreateResponse], error)
+	Update(context.Context, *connect.Request[group.UpdateRequest]) (*connect.Response[group.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[group.DeleteRequest]) (*connect.Response[group.DeleteResponse], error)
+	AddMember(context.Context, *connect.Request[group.AddMemberRequest]) (*connect.Response[group.AddMemberResponse], error)
+	RemoveMember(context.Context, *connect.Request[group.RemoveMemberRequest]) (*connect.Response[group.RemoveMemberResponse], error)
+}
+
+// NewGroupServiceClient constructs a client for the iam.v1.group.GroupService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewGroupServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) GroupServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	groupServiceMethods := group.File_iam_v1_group_service_proto.Services().ByName("GroupService").Methods()
+	return &groupServiceClient{
+		list: connect.NewClient[group.ListRequest, group.ListResponse](
+			httpClient,
+			baseURL+GroupServiceListProcedure,
+			connect.WithSchema(groupServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[group.GetRequest, group.GetResponse](
+			httpClient,
+			baseURL+GroupServiceGetProcedure,
+			connect.WithSchema(groupServiceMethods.ByName("Get")),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[group.CreateRequest, group.CreateResponse](
+			httpClient,
+			baseURL+GroupServiceCreateProcedure,
+			connect.WithSchema(groupServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[group.UpdateRequest, group.UpdateResponse](
+			httpClient,
+			baseURL+GroupServiceUpdateProcedure,
+			connect.WithSchema(groupServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[group.DeleteRequest, group.DeleteResponse](
+			httpClient,
+			baseURL+GroupServiceDeleteProcedure,
+			connect.WithSchema(groupServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+		addMember: connect.NewClient[group.AddMemberRequest, group.AddMemberResponse](
+			httpClient,
+			baseURL+GroupServiceAddMemberProcedure,
+			connect.WithSchema(groupServiceMethods.ByName("AddMember")),
+			connect.WithClientOptions(opts...),
+		),
+		removeMember: connect.NewClient[group.RemoveMemberRequest, group.RemoveMemberResponse](
+			httpClient,
+			baseURL+GroupServiceRemoveMemberProcedure,
+			connect.WithSchema(groupServiceMethods.ByName("RemoveMember")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// groupServiceClient implements GroupServiceClient.
+type groupServiceClient struct {
+	list         *connect.Client[group.ListRequest, group.ListResponse]
+	get          *connect.Client[group.GetRequest, group.GetResponse]
+	create       *connect.Client[group.CreateRequest, group.CreateResponse]
+	update       *connect.Client[group.UpdateRequest, group.UpdateResponse]
+	delete       *connect.Client[group.DeleteRequest, group.DeleteResponse]
+	addMember    *connect.Client[group.AddMemberRequest, group.AddMemberResponse]
+	removeMember *connect.Client[group.RemoveMemberRequest, group.RemoveMemberResponse]
+}
+
+// List calls iam.v1.group.GroupService.List.
+func (c *groupServiceClient) List(ctx context.Context, req *connect.Request[group.ListRequest]) (*connect.Response[group.ListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Get calls iam.v1.group.GroupService.Get.
+func (c *groupServiceClient) Get(ctx context.Context, req *connect.Request[group.GetRequest]) (*connect.Response[group.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Create calls iam.v1.group.GroupService.Create.
+func (c *groupServiceClient) Create(ctx context.Context, req *connect.Request[group.CreateRequest]) (*connect.Response[group.CreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls iam.v1.group.GroupService.Update.
+func (c *groupServiceClient) Update(ctx context.Context, req *connect.Request[group.UpdateRequest]) (*connect.Response[group.UpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls iam.v1.group.GroupService.Delete.
+func (c *groupServiceClient) Delete(ctx context.Context, req *connect.Request[group.DeleteRequest]) (*connect.Response[group.DeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// AddMember calls iam.v1.group.GroupService.AddMember.
+func (c *groupServiceClient) AddMember(ctx context.Context, req *connect.Request[group.AddMemberRequest]) (*connect.Response[group.AddMemberResponse], error) {
+	return c.addMember.CallUnary(ctx, req)
+}
+
+// RemoveMember calls iam.v1.group.GroupService.RemoveMember.
+func (c *groupServiceClient) RemoveMember(ctx context.Context, req *connect.Request[group.RemoveMemberRequest]) (*connect.Response[group.RemoveMemberResponse], error) {
+	return c.removeMember.CallUnary(ctx, req)
+}
+
+// GroupServiceHandler is an implementation of the iam.v1.group.GroupService service.
+type GroupServiceHandler interface {
+	List(context.Context, *connect.Request[group.ListRequest]) (*connect.Response[group.ListResponse], error)
+	Get(context.Context, *connect.Request[group.GetRequest]) (*connect.Response[group.GetResponse], error)
+	Create(context.Context, *connect.Request[group.CreateRequest]) (*connect.Response[group.CreateResponse], error)
+	Update(context.Context, *connect.Request[group.UpdateRequest]) (*connect.Response[group.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[group.DeleteRequest]) (*connect.Response[group.DeleteResponse], error)
+	AddMember(context.Context, *connect.Request[group.AddMemberRequest]) (*connect.Response[group.AddMemberResponse], error)
+	RemoveMember(context.Context, *connect.Request[group.RemoveMemberRequest]) (*connect.Response[group.RemoveMemberResponse], error)
+}
+
+// NewGroupServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewGroupServiceHandler(svc GroupServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	groupServiceMethods := group.File_iam_v1_group_service_proto.Services().ByName("GroupService").Methods()
+	groupServiceListHandler := connect.NewUnaryHandler(
+		GroupServiceListProcedure,
+		svc.List,
+		connect.WithSchema(groupServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupServiceGetHandler := connect.NewUnaryHandler(
+		GroupServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(groupServiceMethods.ByName("Get")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupServiceCreateHandler := connect.NewUnaryHandler(
+		GroupServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(groupServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupServiceUpdateHandler := connect.NewUnaryHandler(
+		GroupServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(groupServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupServiceDeleteHandler := connect.NewUnaryHandler(
+		GroupServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(groupServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupServiceAddMemberHandler := connect.NewUnaryHandler(
+		GroupServiceAddMemberProcedure,
+		svc.AddMember,
+		connect.WithSchema(groupServiceMethods.ByName("AddMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupServiceRemoveMemberHandler := connect.NewUnaryHandler(
+		GroupServiceRemoveMemberProcedure,
+		svc.RemoveMember,
+		connect.WithSchema(groupServiceMethods.ByName("RemoveMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/iam.v1.group.GroupService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GroupServiceListProcedure:
+			groupServiceListHandler.ServeHTTP(w, r)
+		case GroupServiceGetProcedure:
+			groupServiceGetHandler.ServeHTTP(w, r)
+		case GroupServiceCreateProcedure:
+			groupServiceCreateHandler.ServeHTTP(w, r)
+		case GroupServiceUpdateProcedure:
+			groupServiceUpdateHandler.ServeHTTP(w, r)
+		case GroupServiceDeleteProcedure:
+			groupServiceDeleteHandler.ServeHTTP(w, r)
+		case GroupServiceAddMemberProcedure:
+			groupServiceAddMemberHandler.ServeHTTP(w, r)
+		case GroupServiceRemoveMemberProcedure:
+			groupServiceRemoveMemberHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedGroupServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedGroupServiceHandler struct{}
+
+func (UnimplementedGroupServiceHandler) List(context.Context, *connect.Request[group.ListRequest]) (*connect.Response[group.ListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.group.GroupService.List is not implemented"))
+}
+
+func (UnimplementedGroupServiceHandler) Get(context.Context, *connect.Request[group.GetRequest]) (*connect.Response[group.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.group.GroupService.Get is not implemented"))
+}
+
+func (UnimplementedGroupServiceHandler) Create(context.Context, *connect.Request[group.CreateRequest]) (*connect.Response[group.CreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.group.GroupService.Create is not implemented"))
+}
+
+func (UnimplementedGroupServiceHandler) Update(context.Context, *connect.Request[group.UpdateRequest]) (*connect.Response[group.UpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.group.GroupService.Update is not implemented"))
+}
+
+func (UnimplementedGroupServiceHandler) Delete(context.Context, *connect.Request[group.DeleteRequest]) (*connect.Response[group.DeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.group.GroupService.Delete is not implemented"))
+}
+
+func (UnimplementedGroupServiceHandler) AddMember(context.Context, *connect.Request[group.AddMemberRequest]) (*connect.Response[group.AddMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.group.GroupService.AddMember is not implemented"))
+}
+
+func (UnimplementedGroupServiceHandler) RemoveMember(context.Context, *connect.Request[group.RemoveMemberRequest]) (*connect.Response[group.RemoveMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.group.GroupService.RemoveMember is not implemented"))
+}
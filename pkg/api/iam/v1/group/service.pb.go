@@ -0,0 +1,97 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: iam/v1/group/service.proto
+
+package group
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_iam_v1_group_service_proto protoreflect.FileDescriptor
+
+const file_iam_v1_group_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1aiam/v1/group/service.proto\x12\fiam.v1.group\x1a\x1aiam/v1/group/message.proto2\x8b\x04\n" +
+	"\fGroupService\x12?\n" +
+	"\x04List\x12\x19.iam.v1.group.ListRequest\x1a\x1a.iam.v1.group.ListResponse\"\x00\x12<\n" +
+	"\x03Get\x12\x18.iam.v1.group.GetRequest\x1a\x19.iam.v1.group.GetResponse\"\x00\x12E\n" +
+	"\x06Create\x12\x1b.iam.v1.group.CreateRequest\x1a\x1c.iam.v1.group.CreateResponse\"\x00\x12E\n" +
+	"\x06Update\x12\x1b.iam.v1.group.UpdateRequest\x1a\x1c.iam.v1.group.UpdateResponse\"\x00\x12E\n" +
+	"\x06Delete\x12\x1b.iam.v1.group.DeleteRequest\x1a\x1c.iam.v1.group.DeleteResponse\"\x00\x12N\n" +
+	"\tAddMember\x12\x1e.iam.v1.group.AddMemberRequest\x1a\x1f.iam.v1.group.AddMemberResponse\"\x00\x12W\n" +
+	"\fRemoveMember\x12!.iam.v1.group.RemoveMemberRequest\x1a\".iam.v1.group.RemoveMemberResponse\"\x00B/Z-github.com/megakuul/miam/pkg/api/iam/v1/groupb\x06proto3"
+
+var file_iam_v1_group_service_proto_goTypes = []any{
+	(*ListRequest)(nil),          // 0: iam.v1.group.ListRequest
+	(*GetRequest)(nil),           // 1: iam.v1.group.GetRequest
+	(*CreateRequest)(nil),        // 2: iam.v1.group.CreateRequest
+	(*UpdateRequest)(nil),        // 3: iam.v1.group.UpdateRequest
+	(*DeleteRequest)(nil),        // 4: iam.v1.group.DeleteRequest
+	(*AddMemberRequest)(nil),     // 5: iam.v1.group.AddMemberRequest
+	(*RemoveMemberRequest)(nil),  // 6: iam.v1.group.RemoveMemberRequest
+	(*ListResponse)(nil),         // 7: iam.v1.group.ListResponse
+	(*GetResponse)(nil),          // 8: iam.v1.group.GetResponse
+	(*CreateResponse)(nil),       // 9: iam.v1.group.CreateResponse
+	(*UpdateResponse)(nil),       // 10: iam.v1.group.UpdateResponse
+	(*DeleteResponse)(nil),       // 11: iam.v1.group.DeleteResponse
+	(*AddMemberResponse)(nil),    // 12: iam.v1.group.AddMemberResponse
+	(*RemoveMemberResponse)(nil), // 13: iam.v1.group.RemoveMemberResponse
+}
+var file_iam_v1_group_service_proto_depIdxs = []int32{
+	0,  // 0: iam.v1.group.GroupService.List:input_type -> iam.v1.group.ListRequest
+	1,  // 1: iam.v1.group.GroupService.Get:input_type -> iam.v1.group.GetRequest
+	2,  // 2: iam.v1.group.GroupService.Create:input_type -> iam.v1.group.CreateRequest
+	3,  // 3: iam.v1.group.GroupService.Update:input_type -> iam.v1.group.UpdateRequest
+	4,  // 4: iam.v1.group.GroupService.Delete:input_type -> iam.v1.group.DeleteRequest
+	5,  // 5: iam.v1.group.GroupService.AddMember:input_type -> iam.v1.group.AddMemberRequest
+	6,  // 6: iam.v1.group.GroupService.RemoveMember:input_type -> iam.v1.group.RemoveMemberRequest
+	7,  // 7: iam.v1.group.GroupService.List:output_type -> iam.v1.group.ListResponse
+	8,  // 8: iam.v1.group.GroupService.Get:output_type -> iam.v1.group.GetResponse
+	9,  // 9: iam.v1.group.GroupService.Create:output_type -> iam.v1.group.CreateResponse
+	10, // 10: iam.v1.group.GroupService.Update:output_type -> iam.v1.group.UpdateResponse
+	11, // 11: iam.v1.group.GroupService.Delete:output_type -> iam.v1.group.DeleteResponse
+	12, // 12: iam.v1.group.GroupService.AddMember:output_type -> iam.v1.group.AddMemberResponse
+	13, // 13: iam.v1.group.GroupService.RemoveMember:output_type -> iam.v1.group.RemoveMemberResponse
+	7,  // [7:14] is the sub-list for method output_type
+	0,  // [0:7] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_iam_v1_group_service_proto_init() }
+func file_iam_v1_group_service_proto_init() {
+	if File_iam_v1_group_service_proto != nil {
+		return
+	}
+	file_iam_v1_group_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_iam_v1_group_service_proto_rawDesc), len(file_iam_v1_group_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_iam_v1_group_service_proto_goTypes,
+		DependencyIndexes: file_iam_v1_group_service_proto_depIdxs,
+	}.Build()
+	File_iam_v1_group_service_proto = out.File
+	file_iam_v1_group_service_proto_goTypes = nil
+	file_iam_v1_group_service_proto_depIdxs = nil
+}
@@ -0,0 +1,307 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: iam/v1/role/service.proto
+
+package roleconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	role "github.com/megakuul/miam/pkg/api/iam/v1/role"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// RoleServiceName is the fully-qualified name of the RoleService service.
+	RoleServiceName = "iam.v1.role.RoleService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// RoleServiceListProcedure is the fully-qualified name of the RoleService's List RPC.
+	RoleServiceListProcedure = "/iam.v1.role.RoleService/List"
+	// RoleServiceGetProcedure is the fully-qualified name of the RoleService's Get RPC.
+	RoleServiceGetProcedure = "/iam.v1.role.RoleService/Get"
+	// RoleServiceCreateProcedure is the fully-qualified name of the RoleService's Create RPC.
+	RoleServiceCreateProcedure = "/iam.v1.role.RoleService/Create"
+	// RoleServiceUpdateProcedure is the fully-qualified name of the RoleService's Update RPC.
+	RoleServiceUpdateProcedure = "/iam.v1.role.RoleService/Update"
+	// RoleServiceDeleteProcedure is the fully-qualified name of the RoleService's Delete RPC.
+	RoleServiceDeleteProcedure = "/iam.v1.role.RoleService/Delete"
+	// RoleServiceListBindingsProcedure is the fully-qualified name of the RoleService's ListBindings
+	// RPC.
+	RoleServiceListBindingsProcedure = "/iam.v1.role.RoleService/ListBindings"
+	// RoleServiceCreateBindingProcedure is the fully-qualified name of the RoleService's CreateBinding
+	// RPC.
+	RoleServiceCreateBindingProcedure = "/iam.v1.role.RoleService/CreateBinding"
+	// RoleServiceDeleteBindingProcedure is the fully-qualified name of the RoleService's DeleteBinding
+	// RPC.
+	RoleServiceDeleteBindingProcedure = "/iam.v1.role.RoleService/DeleteBinding"
+)
+
+// RoleServiceClient is a client for the iam.v1.role.RoleService service.
+type RoleServiceClient interface {
+	List(context.Context, *connect.Request[role.ListRequest]) (*connect.Response[role.ListResponse], error)
+	Get(context.Context, *connect.Request[role.GetRequest]) (*connect.Response[role.GetResponse], error)
+	Create(context.Context, *connect.Request[role.CreateRequest]) (*connect.Response[role.CreateResponse], error)
+	Update(context.Context, *connect.Request[role.UpdateRequest]) (*connect.Response[role.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[role.DeleteRequest]) (*connect.Response[role.DeleteResponse], error)
+	ListBindings(context.Context, *connect.Request[role.ListBindingsRequest]) (*connect.Response[role.ListBindingsResponse], error)
+	CreateBinding(context.Context, *connect.Request[role.CreateBindingRequest]) (*connect.Response[role.CreateBindingResponse], error)
+	DeleteBinding(context.Context, *connect.Request[role.DeleteBindingRequest]) (*connect.Response[role.DeleteBindingResponse], error)
+}
+
+// NewRoleServiceClient constructs a client for the iam.v1.role.RoleService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewRoleServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) RoleServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	roleServiceMethods := role.File_iam_v1_role_service_proto.Services().ByName("RoleService").Methods()
+	return &roleServiceClient{
+		list: connect.NewClient[role.ListRequest, role.ListResponse](
+			httpClient,
+			baseURL+RoleServiceListProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[role.GetRequest, role.GetResponse](
+			httpClient,
+			baseURL+RoleServiceGetProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("Get")),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[role.CreateRequest, role.CreateResponse](
+			httpClient,
+			baseURL+RoleServiceCreateProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[role.UpdateRequest, role.UpdateResponse](
+			httpClient,
+			baseURL+RoleServiceUpdateProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[role.DeleteRequest, role.DeleteResponse](
+			httpClient,
+			baseURL+RoleServiceDeleteProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+		listBindings: connect.NewClient[role.ListBindingsRequest, role.ListBindingsResponse](
+			httpClient,
+			baseURL+RoleServiceListBindingsProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("ListBindings")),
+			connect.WithClientOptions(opts...),
+		),
+		createBinding: connect.NewClient[role.CreateBindingRequest, role.CreateBindingResponse](
+			httpClient,
+			baseURL+RoleServiceCreateBindingProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("CreateBinding")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteBinding: connect.NewClient[role.DeleteBindingRequest, role.DeleteBindingResponse](
+			httpClient,
+			baseURL+RoleServiceDeleteBindingProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("DeleteBinding")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// roleServiceClient implements RoleServiceClient.
+type roleServiceClient struct {
+	list          *connect.Client[role.ListRequest, role.ListResponse]
+	get           *connect.Client[role.GetRequest, role.GetResponse]
+	create        *connect.Client[role.CreateRequest, role.CreateResponse]
+	update        *connect.Client[role.UpdateRequest, role.UpdateResponse]
+	delete        *connect.Client[role.DeleteRequest, role.DeleteResponse]
+	listBindings  *connect.Client[role.ListBindingsRequest, role.ListBindingsResponse]
+	createBinding *connect.Client[role.CreateBindingRequest, role.CreateBindingResponse]
+	deleteBinding *connect.Client[role.DeleteBindingRequest, role.DeleteBindingResponse]
+}
+
+// List calls iam.v1.role.RoleService.List.
+func (c *roleServiceClient) List(ctx context.Context, req *connect.Request[role.ListRequest]) (*connect.Response[role.ListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Get calls iam.v1.role.RoleService.Get.
+func (c *roleServiceClient) Get(ctx context.Context, req *connect.Request[role.GetRequest]) (*connect.Response[role.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Create calls iam.v1.role.RoleService.Create.
+func (c *roleServiceClient) Create(ctx context.Context, req *connect.Request[role.CreateRequest]) (*connect.Response[role.CreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls iam.v1.role.RoleService.Update.
+func (c *roleServiceClient) Update(ctx context.Context, req *connect.Request[role.UpdateRequest]) (*connect.Response[role.UpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls iam.v1.role.RoleService.Delete.
+func (c *roleServiceClient) Delete(ctx context.Context, req *connect.Request[role.DeleteRequest]) (*connect.Response[role.DeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// ListBindings calls iam.v1.role.RoleService.ListBindings.
+func (c *roleServiceClient) ListBindings(ctx context.Context, req *connect.Request[role.ListBindingsRequest]) (*connect.Response[role.ListBindingsResponse], error) {
+	return c.listBindings.CallUnary(ctx, req)
+}
+
+// CreateBinding calls iam.v1.role.RoleService.CreateBinding.
+func (c *roleServiceClient) CreateBinding(ctx context.Context, req *connect.Request[role.CreateBindingRequest]) (*connect.Response[role.CreateBindingResponse], error) {
+	return c.createBinding.CallUnary(ctx, req)
+}
+
+// DeleteBinding calls iam.v1.role.RoleService.DeleteBinding.
+func (c *roleServiceClient) DeleteBinding(ctx context.Context, req *connect.Request[role.DeleteBindingRequest]) (*connect.Response[role.DeleteBindingResponse], error) {
+	return c.deleteBinding.CallUnary(ctx, req)
+}
+
+// RoleServiceHandler is an implementation of the iam.v1.role.RoleService service.
+type RoleServiceHandler interface {
+	List(context.Context, *connect.Request[role.ListRequest]) (*connect.Response[role.ListResponse], error)
+	Get(context.Context, *connect.Request[role.GetRequest]) (*connect.Response[role.GetResponse], error)
+	Create(context.Context, *connect.Request[role.CreateRequest]) (*connect.Response[role.CreateResponse], error)
+	Update(context.Context, *connect.Request[role.UpdateRequest]) (*connect.Response[role.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[role.DeleteRequest]) (*connect.Response[role.DeleteResponse], error)
+	ListBindings(context.Context, *connect.Request[role.ListBindingsRequest]) (*connect.Response[role.ListBindingsResponse], error)
+	CreateBinding(context.Context, *connect.Request[role.CreateBindingRequest]) (*connect.Response[role.CreateBindingResponse], error)
+	DeleteBinding(context.Context, *connect.Request[role.DeleteBindingRequest]) (*connect.Response[role.DeleteBindingResponse], error)
+}
+
+// NewRoleServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewRoleServiceHandler(svc RoleServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	roleServiceMethods := role.File_iam_v1_role_service_proto.Services().ByName("RoleService").Methods()
+	roleServiceListHandler := connect.NewUnaryHandler(
+		RoleServiceListProcedure,
+		svc.List,
+		connect.WithSchema(roleServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceGetHandler := connect.NewUnaryHandler(
+		RoleServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(roleServiceMethods.ByName("Get")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceCreateHandler := connect.NewUnaryHandler(
+		RoleServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(roleServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceUpdateHandler := connect.NewUnaryHandler(
+		RoleServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(roleServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceDeleteHandler := connect.NewUnaryHandler(
+		RoleServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(roleServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceListBindingsHandler := connect.NewUnaryHandler(
+		RoleServiceListBindingsProcedure,
+		svc.ListBindings,
+		connect.WithSchema(roleServiceMethods.ByName("ListBindings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceCreateBindingHandler := connect.NewUnaryHandler(
+		RoleServiceCreateBindingProcedure,
+		svc.CreateBinding,
+		connect.WithSchema(roleServiceMethods.ByName("CreateBinding")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceDeleteBindingHandler := connect.NewUnaryHandler(
+		RoleServiceDeleteBindingProcedure,
+		svc.DeleteBinding,
+		connect.WithSchema(roleServiceMethods.ByName("DeleteBinding")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/iam.v1.role.RoleService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case RoleServiceListProcedure:
+			roleServiceListHandler.ServeHTTP(w, r)
+		case RoleServiceGetProcedure:
+			roleServiceGetHandler.ServeHTTP(w, r)
+		case RoleServiceCreateProcedure:
+			roleServiceCreateHandler.ServeHTTP(w, r)
+		case RoleServiceUpdateProcedure:
+			roleServiceUpdateHandler.ServeHTTP(w, r)
+		case RoleServiceDeleteProcedure:
+			roleServiceDeleteHandler.ServeHTTP(w, r)
+		case RoleServiceListBindingsProcedure:
+			roleServiceListBindingsHandler.ServeHTTP(w, r)
+		case RoleServiceCreateBindingProcedure:
+			roleServiceCreateBindingHandler.ServeHTTP(w, r)
+		case RoleServiceDeleteBindingProcedure:
+			roleServiceDeleteBindingHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedRoleServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedRoleServiceHandler struct{}
+
+func (UnimplementedRoleServiceHandler) List(context.Context, *connect.Request[role.ListRequest]) (*connect.Response[role.ListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.List is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) Get(context.Context, *connect.Request[role.GetRequest]) (*connect.Response[role.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.Get is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) Create(context.Context, *connect.Request[role.CreateRequest]) (*connect.Response[role.CreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.Create is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) Update(context.Context, *connect.Request[role.UpdateRequest]) (*connect.Response[role.UpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.Update is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) Delete(context.Context, *connect.Request[role.DeleteRequest]) (*connect.Response[role.DeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.Delete is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) ListBindings(context.Context, *connect.Request[role.ListBindingsRequest]) (*connect.Response[role.ListBindingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.ListBindings is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) CreateBinding(context.Context, *connect.Request[role.CreateBindingRequest]) (*connect.Response[role.CreateBindingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.CreateBinding is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) DeleteBinding(context.Context, *connect.Request[role.DeleteBindingRequest]) (*connect.Response[role.DeleteBindingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.role.RoleService.DeleteBinding is not implemented"))
+}
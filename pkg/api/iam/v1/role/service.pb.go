@@ -0,0 +1,102 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: iam/v1/role/service.proto
+
+package role
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_iam_v1_role_service_proto protoreflect.FileDescriptor
+
+const file_iam_v1_role_service_proto_rawDesc = "" +
+	"\n" +
+	"\x19iam/v1/role/service.proto\x12\viam.v1.role\x1a\x19iam/v1/role/message.proto2\xe2\x04\n" +
+	"\vRoleService\x12=\n" +
+	"\x04List\x12\x18.iam.v1.role.ListRequest\x1a\x19.iam.v1.role.ListResponse\"\x00\x12:\n" +
+	"\x03Get\x12\x17.iam.v1.role.GetRequest\x1a\x18.iam.v1.role.GetResponse\"\x00\x12C\n" +
+	"\x06Create\x12\x1a.iam.v1.role.CreateRequest\x1a\x1b.iam.v1.role.CreateResponse\"\x00\x12C\n" +
+	"\x06Update\x12\x1a.iam.v1.role.UpdateRequest\x1a\x1b.iam.v1.role.UpdateResponse\"\x00\x12C\n" +
+	"\x06Delete\x12\x1a.iam.v1.role.DeleteRequest\x1a\x1b.iam.v1.role.DeleteResponse\"\x00\x12U\n" +
+	"\fListBindings\x12 .iam.v1.role.ListBindingsRequest\x1a!.iam.v1.role.ListBindingsResponse\"\x00\x12X\n" +
+	"\rCreateBinding\x12!.iam.v1.role.CreateBindingRequest\x1a\".iam.v1.role.CreateBindingResponse\"\x00\x12X\n" +
+	"\rDeleteBinding\x12!.iam.v1.role.DeleteBindingRequest\x1a\".iam.v1.role.DeleteBindingResponse\"\x00B.Z,github.com/megakuul/miam/pkg/api/iam/v1/roleb\x06proto3"
+
+var file_iam_v1_role_service_proto_goTypes = []any{
+	(*ListRequest)(nil),           // 0: iam.v1.role.ListRequest
+	(*GetRequest)(nil),            // 1: iam.v1.role.GetRequest
+	(*CreateRequest)(nil),         // 2: iam.v1.role.CreateRequest
+	(*UpdateRequest)(nil),         // 3: iam.v1.role.UpdateRequest
+	(*DeleteRequest)(nil),         // 4: iam.v1.role.DeleteRequest
+	(*ListBindingsRequest)(nil),   // 5: iam.v1.role.ListBindingsRequest
+	(*CreateBindingRequest)(nil),  // 6: iam.v1.role.CreateBindingRequest
+	(*DeleteBindingRequest)(nil),  // 7: iam.v1.role.DeleteBindingRequest
+	(*ListResponse)(nil),          // 8: iam.v1.role.ListResponse
+	(*GetResponse)(nil),           // 9: iam.v1.role.GetResponse
+	(*CreateResponse)(nil),        // 10: iam.v1.role.CreateResponse
+	(*UpdateResponse)(nil),        // 11: iam.v1.role.UpdateResponse
+	(*DeleteResponse)(nil),        // 12: iam.v1.role.DeleteResponse
+	(*ListBindingsResponse)(nil),  // 13: iam.v1.role.ListBindingsResponse
+	(*CreateBindingResponse)(nil), // 14: iam.v1.role.CreateBindingResponse
+	(*DeleteBindingResponse)(nil), // 15: iam.v1.role.DeleteBindingResponse
+}
+var file_iam_v1_role_service_proto_depIdxs = []int32{
+	0,  // 0: iam.v1.role.RoleService.List:input_type -> iam.v1.role.ListRequest
+	1,  // 1: iam.v1.role.RoleService.Get:input_type -> iam.v1.role.GetRequest
+	2,  // 2: iam.v1.role.RoleService.Create:input_type -> iam.v1.role.CreateRequest
+	3,  // 3: iam.v1.role.RoleService.Update:input_type -> iam.v1.role.UpdateRequest
+	4,  // 4: iam.v1.role.RoleService.Delete:input_type -> iam.v1.role.DeleteRequest
+	5,  // 5: iam.v1.role.RoleService.ListBindings:input_type -> iam.v1.role.ListBindingsRequest
+	6,  // 6: iam.v1.role.RoleService.CreateBinding:input_type -> iam.v1.role.CreateBindingRequest
+	7,  // 7: iam.v1.role.RoleService.DeleteBinding:input_type -> iam.v1.role.DeleteBindingRequest
+	8,  // 8: iam.v1.role.RoleService.List:output_type -> iam.v1.role.ListResponse
+	9,  // 9: iam.v1.role.RoleService.Get:output_type -> iam.v1.role.GetResponse
+	10, // 10: iam.v1.role.RoleService.Create:output_type -> iam.v1.role.CreateResponse
+	11, // 11: iam.v1.role.RoleService.Update:output_type -> iam.v1.role.UpdateResponse
+	12, // 12: iam.v1.role.RoleService.Delete:output_type -> iam.v1.role.DeleteResponse
+	13, // 13: iam.v1.role.RoleService.ListBindings:output_type -> iam.v1.role.ListBindingsResponse
+	14, // 14: iam.v1.role.RoleService.CreateBinding:output_type -> iam.v1.role.CreateBindingResponse
+	15, // 15: iam.v1.role.RoleService.DeleteBinding:output_type -> iam.v1.role.DeleteBindingResponse
+	8,  // [8:16] is the sub-list for method output_type
+	0,  // [0:8] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_iam_v1_role_service_proto_init() }
+func file_iam_v1_role_service_proto_init() {
+	if File_iam_v1_role_service_proto != nil {
+		return
+	}
+	file_iam_v1_role_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_iam_v1_role_service_proto_rawDesc), len(file_iam_v1_role_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_iam_v1_role_service_proto_goTypes,
+		DependencyIndexes: file_iam_v1_role_service_proto_depIdxs,
+	}.Build()
+	File_iam_v1_role_service_proto = out.File
+	file_iam_v1_role_service_proto_goTypes = nil
+	file_iam_v1_role_service_proto_depIdxs = nil
+}
@@ -0,0 +1,102 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: iam/v1/serviceaccount/service.proto
+
+package serviceaccount
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_iam_v1_serviceaccount_service_proto protoreflect.FileDescriptor
+
+const file_iam_v1_serviceaccount_service_proto_rawDesc = "" +
+	"\n" +
+	"#iam/v1/serviceaccount/service.proto\x12\x15iam.v1.serviceaccount\x1a#iam/v1/serviceaccount/message.proto2\xe8\x05\n" +
+	"\x15ServiceAccountService\x12Q\n" +
+	"\x04List\x12\".iam.v1.serviceaccount.ListRequest\x1a#.iam.v1.serviceaccount.ListResponse\"\x00\x12N\n" +
+	"\x03Get\x12!.iam.v1.serviceaccount.GetRequest\x1a\".iam.v1.serviceaccount.GetResponse\"\x00\x12W\n" +
+	"\x06Create\x12$.iam.v1.serviceaccount.CreateRequest\x1a%.iam.v1.serviceaccount.CreateResponse\"\x00\x12W\n" +
+	"\x06Update\x12$.iam.v1.serviceaccount.UpdateRequest\x1a%.iam.v1.serviceaccount.UpdateResponse\"\x00\x12W\n" +
+	"\x06Delete\x12$.iam.v1.serviceaccount.DeleteRequest\x1a%.iam.v1.serviceaccount.DeleteResponse\"\x00\x12`\n" +
+	"\tCreateKey\x12'.iam.v1.serviceaccount.CreateKeyRequest\x1a(.iam.v1.serviceaccount.CreateKeyResponse\"\x00\x12]\n" +
+	"\bListKeys\x12&.iam.v1.serviceaccount.ListKeysRequest\x1a'.iam.v1.serviceaccount.ListKeysResponse\"\x00\x12`\n" +
+	"\tRevokeKey\x12'.iam.v1.serviceaccount.RevokeKeyRequest\x1a(.iam.v1.serviceaccount.RevokeKeyResponse\"\x00B8Z6github.com/megakuul/miam/pkg/api/iam/v1/serviceaccountb\x06proto3"
+
+var file_iam_v1_serviceaccount_service_proto_goTypes = []any{
+	(*ListRequest)(nil),       // 0: iam.v1.serviceaccount.ListRequest
+	(*GetRequest)(nil),        // 1: iam.v1.serviceaccount.GetRequest
+	(*CreateRequest)(nil),     // 2: iam.v1.serviceaccount.CreateRequest
+	(*UpdateRequest)(nil),     // 3: iam.v1.serviceaccount.UpdateRequest
+	(*DeleteRequest)(nil),     // 4: iam.v1.serviceaccount.DeleteRequest
+	(*CreateKeyRequest)(nil),  // 5: iam.v1.serviceaccount.CreateKeyRequest
+	(*ListKeysRequest)(nil),   // 6: iam.v1.serviceaccount.ListKeysRequest
+	(*RevokeKeyRequest)(nil),  // 7: iam.v1.serviceaccount.RevokeKeyRequest
+	(*ListResponse)(nil),      // 8: iam.v1.serviceaccount.ListResponse
+	(*GetResponse)(nil),       // 9: iam.v1.serviceaccount.GetResponse
+	(*CreateResponse)(nil),    // 10: iam.v1.serviceaccount.CreateResponse
+	(*UpdateResponse)(nil),    // 11: iam.v1.serviceaccount.UpdateResponse
+	(*DeleteResponse)(nil),    // 12: iam.v1.serviceaccount.DeleteResponse
+	(*CreateKeyResponse)(nil), // 13: iam.v1.serviceaccount.CreateKeyResponse
+	(*ListKeysResponse)(nil),  // 14: iam.v1.serviceaccount.ListKeysResponse
+	(*RevokeKeyResponse)(nil), // 15: iam.v1.serviceaccount.RevokeKeyResponse
+}
+var file_iam_v1_serviceaccount_service_proto_depIdxs = []int32{
+	0,  // 0: iam.v1.serviceaccount.ServiceAccountService.List:input_type -> iam.v1.serviceaccount.ListRequest
+	1,  // 1: iam.v1.serviceaccount.ServiceAccountService.Get:input_type -> iam.v1.serviceaccount.GetRequest
+	2,  // 2: iam.v1.serviceaccount.ServiceAccountService.Create:input_type -> iam.v1.serviceaccount.CreateRequest
+	3,  // 3: iam.v1.serviceaccount.ServiceAccountService.Update:input_type -> iam.v1.serviceaccount.UpdateRequest
+	4,  // 4: iam.v1.serviceaccount.ServiceAccountService.Delete:input_type -> iam.v1.serviceaccount.DeleteRequest
+	5,  // 5: iam.v1.serviceaccount.ServiceAccountService.CreateKey:input_type -> iam.v1.serviceaccount.CreateKeyRequest
+	6,  // 6: iam.v1.serviceaccount.ServiceAccountService.ListKeys:input_type -> iam.v1.serviceaccount.ListKeysRequest
+	7,  // 7: iam.v1.serviceaccount.ServiceAccountService.RevokeKey:input_type -> iam.v1.serviceaccount.RevokeKeyRequest
+	8,  // 8: iam.v1.serviceaccount.ServiceAccountService.List:output_type -> iam.v1.serviceaccount.ListResponse
+	9,  // 9: iam.v1.serviceaccount.ServiceAccountService.Get:output_type -> iam.v1.serviceaccount.GetResponse
+	10, // 10: iam.v1.serviceaccount.ServiceAccountService.Create:output_type -> iam.v1.serviceaccount.CreateResponse
+	11, // 11: iam.v1.serviceaccount.ServiceAccountService.Update:output_type -> iam.v1.serviceaccount.UpdateResponse
+	12, // 12: iam.v1.serviceaccount.ServiceAccountService.Delete:output_type -> iam.v1.serviceaccount.DeleteResponse
+	13, // 13: iam.v1.serviceaccount.ServiceAccountService.CreateKey:output_type -> iam.v1.serviceaccount.CreateKeyResponse
+	14, // 14: iam.v1.serviceaccount.ServiceAccountService.ListKeys:output_type -> iam.v1.serviceaccount.ListKeysResponse
+	15, // 15: iam.v1.serviceaccount.ServiceAccountService.RevokeKey:output_type -> iam.v1.serviceaccount.RevokeKeyResponse
+	8,  // [8:16] is the sub-list for method output_type
+	0,  // [0:8] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_iam_v1_serviceaccount_service_proto_init() }
+func file_iam_v1_serviceaccount_service_proto_init() {
+	if File_iam_v1_serviceaccount_service_proto != nil {
+		return
+	}
+	file_iam_v1_serviceaccount_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_iam_v1_serviceaccount_service_proto_rawDesc), len(file_iam_v1_serviceaccount_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_iam_v1_serviceaccount_service_proto_goTypes,
+		DependencyIndexes: file_iam_v1_serviceaccount_service_proto_depIdxs,
+	}.Build()
+	File_iam_v1_serviceaccount_service_proto = out.File
+	file_iam_v1_serviceaccount_service_proto_goTypes = nil
+	file_iam_v1_serviceaccount_service_proto_depIdxs = nil
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: iam/v1/serviceaccount/service.proto
+
+package serviceaccountconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	serviceaccount "github.com/megakuul/miam/pkg/api/iam/v1/serviceaccount"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ServiceAccountServiceName is the fully-qualified name of the ServiceAccountService service.
+	ServiceAccountServiceName = "iam.v1.serviceaccount.ServiceAccountService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ServiceAccountServiceListProcedure is the fully-qualified name of the ServiceAccountService's
+	// List RPC.
+	ServiceAccountServiceListProcedure = "/iam.v1.serviceaccount.ServiceAccountService/List"
+	// ServiceAccountServiceGetProcedure is the fully-qualified name of the ServiceAccountService's Get
+	// RPC.
+	ServiceAccountServiceGetProcedure = "/iam.v1.serviceaccount.ServiceAccountService/Get"
+	// ServiceAccountServiceCreateProcedure is the fully-qualified name of the ServiceAccountService's
+	// Create RPC.
+	ServiceAccountServiceCreateProcedure = "/iam.v1.serviceaccount.ServiceAccountService/Create"
+	// ServiceAccountServiceUpdateProcedure is the fully-qualified name of the ServiceAccountService's
+	// Update RPC.
+	ServiceAccountServiceUpdateProcedure = "/iam.v1.serviceaccount.ServiceAccountService/Update"
+	// ServiceAccountServiceDeleteProcedure is the fully-qualified name of the ServiceAccountService's
+	// Delete RPC.
+	ServiceAccountServiceDeleteProcedure = "/iam.v1.serviceaccount.ServiceAccountService/Delete"
+	// ServiceAccountServiceCreateKeyProcedure is the fully-qualified name of the
+	// ServiceAccountService's CreateKey RPC.
+	ServiceAccountServiceCreateKeyProcedure = "/iam.v1.serviceaccount.ServiceAccountService/CreateKey"
+	// ServiceAccountServiceListKeysProcedure is the fully-qualified name of the ServiceAccountService's
+	// ListKeys RPC.
+	ServiceAccountServiceListKeysProcedure = "/iam.v1.serviceaccount.ServiceAccountService/ListKeys"
+	// ServiceAccountServiceRevokeKeyProcedure is the fully-qualified name of the
+	// ServiceAccountService's RevokeKey RPC.
+	ServiceAccountServiceRevokeKeyProcedure = "/iam.v1.serviceaccount.ServiceAccountService/RevokeKey"
+)
+
+// ServiceAccountServiceClient is a client for the iam.v1.serviceaccount.ServiceAccountService
+// service.
+type ServiceAccountServiceClient interface {
+	List(context.Context, *connect.Request[serviceaccount.ListRequest]) (*connect.Response[serviceaccount.ListResponse], error)
+	Get(context.Context, *connect.Request[serviceaccount.GetRequest]) (*connect.Response[serviceaccount.GetResponse], error)
+	Create(context.Context, *connect.Request[serviceaccount.CreateRequest]) (*connect.Response[serviceaccount.CreateResponse], error)
+	Update(context.Context, *connect.Request[serviceaccount.UpdateRequest]) (*connect.Response[serviceaccount.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[serviceaccount.DeleteRequest]) (*connect.Response[serviceaccount.DeleteResponse], error)
+	CreateKey(context.Context, *connect.Request[serviceaccount.CreateKeyRequest]) (*connect.Response[serviceaccount.CreateKeyResponse], error)
+	ListKeys(context.Context, *connect.Request[serviceaccount.ListKeysRequest]) (*connect.Response[serviceaccount.ListKeysResponse], error)
+	RevokeKey(context.Context, *connect.Request[serviceaccount.RevokeKeyRequest]) (*connect.Response[serviceaccount.RevokeKeyResponse], error)
+}
+
+// NewServiceAccountServiceClient constructs a client for the
+// iam.v1.serviceaccount.ServiceAccountService service. By default, it uses the Connect protocol
+// with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed requests. To
+// use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or connect.WithGRPCWeb()
+// options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewServiceAccountServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ServiceAccountServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	serviceAccountServiceMethods := serviceaccount.File_iam_v1_serviceaccount_service_proto.Services().ByName("ServiceAccountService").Methods()
+	return &serviceAccountServiceClient{
+		list: connect.NewClient[serviceaccount.ListRequest, serviceaccount.ListResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceListProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[serviceaccount.GetRequest, serviceaccount.GetResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceGetProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("Get")),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[serviceaccount.CreateRequest, serviceaccount.CreateResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceCreateProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[serviceaccount.UpdateRequest, serviceaccount.UpdateResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceUpdateProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[serviceaccount.DeleteRequest, serviceaccount.DeleteResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceDeleteProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+		createKey: connect.NewClient[serviceaccount.CreateKeyRequest, serviceaccount.CreateKeyResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceCreateKeyProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("CreateKey")),
+			connect.WithClientOptions(opts...),
+		),
+		listKeys: connect.NewClient[serviceaccount.ListKeysRequest, serviceaccount.ListKeysResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceListKeysProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("ListKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeKey: connect.NewClient[serviceaccount.RevokeKeyRequest, serviceaccount.RevokeKeyResponse](
+			httpClient,
+			baseURL+ServiceAccountServiceRevokeKeyProcedure,
+			connect.WithSchema(serviceAccountServiceMethods.ByName("RevokeKey")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// serviceAccountServiceClient implements ServiceAccountServiceClient.
+type serviceAccountServiceClient struct {
+	list      *connect.Client[serviceaccount.ListRequest, serviceaccount.ListResponse]
+	get       *connect.Client[serviceaccount.GetRequest, serviceaccount.GetResponse]
+	create    *connect.Client[serviceaccount.CreateRequest, serviceaccount.CreateResponse]
+	update    *connect.Client[serviceaccount.UpdateRequest, serviceaccount.UpdateResponse]
+	delete    *connect.Client[serviceaccount.DeleteRequest, serviceaccount.DeleteResponse]
+	createKey *connect.Client[serviceaccount.CreateKeyRequest, serviceaccount.CreateKeyResponse]
+	listKeys  *connect.Client[serviceaccount.ListKeysRequest, serviceaccount.ListKeysResponse]
+	revokeKey *connect.Client[serviceaccount.RevokeKeyRequest, serviceaccount.RevokeKeyResponse]
+}
+
+// List calls iam.v1.serviceaccount.ServiceAccountService.List.
+func (c *serviceAccountServiceClient) List(ctx context.Context, req *connect.Request[serviceaccount.ListRequest]) (*connect.Response[serviceaccount.ListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Get calls iam.v1.serviceaccount.ServiceAccountService.Get.
+func (c *serviceAccountServiceClient) Get(ctx context.Context, req *connect.Request[serviceaccount.GetRequest]) (*connect.Response[serviceaccount.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Create calls iam.v1.serviceaccount.ServiceAccountService.Create.
+func (c *serviceAccountServiceClient) Create(ctx context.Context, req *connect.Request[serviceaccount.CreateRequest]) (*connect.Response[serviceaccount.CreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls iam.v1.serviceaccount.ServiceAccountService.Update.
+func (c *serviceAccountServiceClient) Update(ctx context.Context, req *connect.Request[serviceaccount.UpdateRequest]) (*connect.Response[serviceaccount.UpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls iam.v1.serviceaccount.ServiceAccountService.Delete.
+func (c *serviceAccountServiceClient) Delete(ctx context.Context, req *connect.Request[serviceaccount.DeleteRequest]) (*connect.Response[serviceaccount.DeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// CreateKey calls iam.v1.serviceaccount.ServiceAccountService.CreateKey.
+func (c *serviceAccountServiceClient) CreateKey(ctx context.Context, req *connect.Request[serviceaccount.CreateKeyRequest]) (*connect.Response[serviceaccount.CreateKeyResponse], error) {
+	return c.createKey.CallUnary(ctx, req)
+}
+
+// ListKeys calls iam.v1.serviceaccount.ServiceAccountService.ListKeys.
+func (c *serviceAccountServiceClient) ListKeys(ctx context.Context, req *connect.Request[serviceaccount.ListKeysRequest]) (*connect.Response[serviceaccount.ListKeysResponse], error) {
+	return c.listKeys.CallUnary(ctx, req)
+}
+
+// RevokeKey calls iam.v1.serviceaccount.ServiceAccountService.RevokeKey.
+func (c *serviceAccountServiceClient) RevokeKey(ctx context.Context, req *connect.Request[serviceaccount.RevokeKeyRequest]) (*connect.Response[serviceaccount.RevokeKeyResponse], error) {
+	return c.revokeKey.CallUnary(ctx, req)
+}
+
+// ServiceAccountServiceHandler is an implementation of the
+// iam.v1.serviceaccount.ServiceAccountService service.
+type ServiceAccountServiceHandler interface {
+	List(context.Context, *connect.Request[serviceaccount.ListRequest]) (*connect.Response[serviceaccount.ListResponse], error)
+	Get(context.Context, *connect.Request[serviceaccount.GetRequest]) (*connect.Response[serviceaccount.GetResponse], error)
+	Create(context.Context, *connect.Request[serviceaccount.CreateRequest]) (*connect.Response[serviceaccount.CreateResponse], error)
+	Update(context.Context, *connect.Request[serviceaccount.UpdateRequest]) (*connect.Response[serviceaccount.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[serviceaccount.DeleteRequest]) (*connect.Response[serviceaccount.DeleteResponse], error)
+	CreateKey(context.Context, *connect.Request[serviceaccount.CreateKeyRequest]) (*connect.Response[serviceaccount.CreateKeyResponse], error)
+	ListKeys(context.Context, *connect.Request[serviceaccount.ListKeysRequest]) (*connect.Response[serviceaccount.ListKeysResponse], error)
+	RevokeKey(context.Context, *connect.Request[serviceaccount.RevokeKeyRequest]) (*connect.Response[serviceaccount.RevokeKeyResponse], error)
+}
+
+// NewServiceAccountServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewServiceAccountServiceHandler(svc ServiceAccountServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	serviceAccountServiceMethods := serviceaccount.File_iam_v1_serviceaccount_service_proto.Services().ByName("ServiceAccountService").Methods()
+	serviceAccountServiceListHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceListProcedure,
+		svc.List,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceAccountServiceGetHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("Get")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceAccountServiceCreateHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceAccountServiceUpdateHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceAccountServiceDeleteHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceAccountServiceCreateKeyHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceCreateKeyProcedure,
+		svc.CreateKey,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("CreateKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceAccountServiceListKeysHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceListKeysProcedure,
+		svc.ListKeys,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("ListKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	serviceAccountServiceRevokeKeyHandler := connect.NewUnaryHandler(
+		ServiceAccountServiceRevokeKeyProcedure,
+		svc.RevokeKey,
+		connect.WithSchema(serviceAccountServiceMethods.ByName("RevokeKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/iam.v1.serviceaccount.ServiceAccountService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ServiceAccountServiceListProcedure:
+			serviceAccountServiceListHandler.ServeHTTP(w, r)
+		case ServiceAccountServiceGetProcedure:
+			serviceAccountServiceGetHandler.ServeHTTP(w, r)
+		case ServiceAccountServiceCreateProcedure:
+			serviceAccountServiceCreateHandler.ServeHTTP(w, r)
+		case ServiceAccountServiceUpdateProcedure:
+			serviceAccountServiceUpdateHandler.ServeHTTP(w, r)
+		case ServiceAccountServiceDeleteProcedure:
+			serviceAccountServiceDeleteHandler.ServeHTTP(w, r)
+		case ServiceAccountServiceCreateKeyProcedure:
+			serviceAccountServiceCreateKeyHandler.ServeHTTP(w, r)
+		case ServiceAccountServiceListKeysProcedure:
+			serviceAccountServiceListKeysHandler.ServeHTTP(w, r)
+		case ServiceAccountServiceRevokeKeyProcedure:
+			serviceAccountServiceRevokeKeyHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedServiceAccountServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedServiceAccountServiceHandler struct{}
+
+func (UnimplementedServiceAccountServiceHandler) List(context.Context, *connect.Request[serviceaccount.ListRequest]) (*connect.Response[serviceaccount.ListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.List is not implemented"))
+}
+
+func (UnimplementedServiceAccountServiceHandler) Get(context.Context, *connect.Request[serviceaccount.GetRequest]) (*connect.Response[serviceaccount.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.Get is not implemented"))
+}
+
+func (UnimplementedServiceAccountServiceHandler) Create(context.Context, *connect.Request[serviceaccount.CreateRequest]) (*connect.Response[serviceaccount.CreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.Create is not implemented"))
+}
+
+func (UnimplementedServiceAccountServiceHandler) Update(context.Context, *connect.Request[serviceaccount.UpdateRequest]) (*connect.Response[serviceaccount.UpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.Update is not implemented"))
+}
+
+func (UnimplementedServiceAccountServiceHandler) Delete(context.Context, *connect.Request[serviceaccount.DeleteRequest]) (*connect.Response[serviceaccount.DeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.Delete is not implemented"))
+}
+
+func (UnimplementedServiceAccountServiceHandler) CreateKey(context.Context, *connect.Request[serviceaccount.CreateKeyRequest]) (*connect.Response[serviceaccount.CreateKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.CreateKey is not implemented"))
+}
+
+func (UnimplementedServiceAccountServiceHandler) ListKeys(context.Context, *connect.Request[serviceaccount.ListKeysRequest]) (*connect.Response[serviceaccount.ListKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.ListKeys is not implemented"))
+}
+
+func (UnimplementedServiceAccountServiceHandler) RevokeKey(context.Context, *connect.Request[serviceaccount.RevokeKeyRequest]) (*connect.Response[serviceaccount.RevokeKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.serviceaccount.ServiceAccountService.RevokeKey is not implemented"))
+}
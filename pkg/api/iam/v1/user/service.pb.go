@@ -0,0 +1,87 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: iam/v1/user/service.proto
+
+package user
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_iam_v1_user_service_proto protoreflect.FileDescriptor
+
+const file_iam_v1_user_service_proto_rawDesc = "" +
+	"\n" +
+	"\x19iam/v1/user/service.proto\x12\viam.v1.user\x1a\x19iam/v1/user/message.proto2\xd7\x02\n" +
+	"\vUserService\x12=\n" +
+	"\x04List\x12\x18.iam.v1.user.ListRequest\x1a\x19.iam.v1.user.ListResponse\"\x00\x12:\n" +
+	"\x03Get\x12\x17.iam.v1.user.GetRequest\x1a\x18.iam.v1.user.GetResponse\"\x00\x12C\n" +
+	"\x06Create\x12\x1a.iam.v1.user.CreateRequest\x1a\x1b.iam.v1.user.CreateResponse\"\x00\x12C\n" +
+	"\x06Update\x12\x1a.iam.v1.user.UpdateRequest\x1a\x1b.iam.v1.user.UpdateResponse\"\x00\x12C\n" +
+	"\x06Delete\x12\x1a.iam.v1.user.DeleteRequest\x1a\x1b.iam.v1.user.DeleteResponse\"\x00B.Z,github.com/megakuul/miam/pkg/api/iam/v1/userb\x06proto3"
+
+var file_iam_v1_user_service_proto_goTypes = []any{
+	(*ListRequest)(nil),    // 0: iam.v1.user.ListRequest
+	(*GetRequest)(nil),     // 1: iam.v1.user.GetRequest
+	(*CreateRequest)(nil),  // 2: iam.v1.user.CreateRequest
+	(*UpdateRequest)(nil),  // 3: iam.v1.user.UpdateRequest
+	(*DeleteRequest)(nil),  // 4: iam.v1.user.DeleteRequest
+	(*ListResponse)(nil),   // 5: iam.v1.user.ListResponse
+	(*GetResponse)(nil),    // 6: iam.v1.user.GetResponse
+	(*CreateResponse)(nil), // 7: iam.v1.user.CreateResponse
+	(*UpdateResponse)(nil), // 8: iam.v1.user.UpdateResponse
+	(*DeleteResponse)(nil), // 9: iam.v1.user.DeleteResponse
+}
+var file_iam_v1_user_service_proto_depIdxs = []int32{
+	0, // 0: iam.v1.user.UserService.List:input_type -> iam.v1.user.ListRequest
+	1, // 1: iam.v1.user.UserService.Get:input_type -> iam.v1.user.GetRequest
+	2, // 2: iam.v1.user.UserService.Create:input_type -> iam.v1.user.CreateRequest
+	3, // 3: iam.v1.user.UserService.Update:input_type -> iam.v1.user.UpdateRequest
+	4, // 4: iam.v1.user.UserService.Delete:input_type -> iam.v1.user.DeleteRequest
+	5, // 5: iam.v1.user.UserService.List:output_type -> iam.v1.user.ListResponse
+	6, // 6: iam.v1.user.UserService.Get:output_type -> iam.v1.user.GetResponse
+	7, // 7: iam.v1.user.UserService.Create:output_type -> iam.v1.user.CreateResponse
+	8, // 8: iam.v1.user.UserService.Update:output_type -> iam.v1.user.UpdateResponse
+	9, // 9: iam.v1.user.UserService.Delete:output_type -> iam.v1.user.DeleteResponse
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_iam_v1_user_service_proto_init() }
+func file_iam_v1_user_service_proto_init() {
+	if File_iam_v1_user_service_proto != nil {
+		return
+	}
+	file_iam_v1_user_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_iam_v1_user_service_proto_rawDesc), len(file_iam_v1_user_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_iam_v1_user_service_proto_goTypes,
+		DependencyIndexes: file_iam_v1_user_service_proto_depIdxs,
+	}.Build()
+	File_iam_v1_user_service_proto = out.File
+	file_iam_v1_user_service_proto_goTypes = nil
+	file_iam_v1_user_service_proto_depIdxs = nil
+}
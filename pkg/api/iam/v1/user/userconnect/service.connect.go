@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: iam/v1/user/service.proto
+
+package userconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	user "github.com/megakuul/miam/pkg/api/iam/v1/user"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// UserServiceName is the fully-qualified name of the UserService service.
+	UserServiceName = "iam.v1.user.UserService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// UserServiceListProcedure is the fully-qualified name of the UserService's List RPC.
+	UserServiceListProcedure = "/iam.v1.user.UserService/List"
+	// UserServiceGetProcedure is the fully-qualified name of the UserService's Get RPC.
+	UserServiceGetProcedure = "/iam.v1.user.UserService/Get"
+	// UserServiceCreateProcedure is the fully-qualified name of the UserService's Create RPC.
+	UserServiceCreateProcedure = "/iam.v1.user.UserService/Create"
+	// UserServiceUpdateProcedure is the fully-qualified name of the UserService's Update RPC.
+	UserServiceUpdateProcedure = "/iam.v1.user.UserService/Update"
+	// UserServiceDeleteProcedure is the fully-qualified name of the UserService's Delete RPC.
+	UserServiceDeleteProcedure = "/iam.v1.user.UserService/Delete"
+)
+
+// UserServiceClient is a client for the iam.v1.user.UserService service.
+type UserServiceClient interface {
+	List(context.Context, *connect.Request[user.ListRequest]) (*connect.Response[user.ListResponse], error)
+	Get(context.Context, *connect.Request[user.GetRequest]) (*connect.Response[user.GetResponse], error)
+	Create(context.Context, *connect.Request[user.CreateRequest]) (*connect.Response[user.CreateResponse], error)
+	Update(context.Context, *connect.Request[user.UpdateRequest]) (*connect.Response[user.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[user.DeleteRequest]) (*connect.Response[user.DeleteResponse], error)
+}
+
+// NewUserServiceClient constructs a client for the iam.v1.user.UserService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) UserServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	userServiceMethods := user.File_iam_v1_user_service_proto.Services().ByName("UserService").Methods()
+	return &userServiceClient{
+		list: connect.NewClient[user.ListRequest, user.ListResponse](
+			httpClient,
+			baseURL+UserServiceListProcedure,
+			connect.WithSchema(userServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[user.GetRequest, user.GetResponse](
+			httpClient,
+			baseURL+UserServiceGetProcedure,
+			connect.WithSchema(userServiceMethods.ByName("Get")),
+			connect.WithClientOptions(opts...),
+		),
+		create: connect.NewClient[user.CreateRequest, user.CreateResponse](
+			httpClient,
+			baseURL+UserServiceCreateProcedure,
+			connect.WithSchema(userServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[user.UpdateRequest, user.UpdateResponse](
+			httpClient,
+			baseURL+UserServiceUpdateProcedure,
+			connect.WithSchema(userServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[user.DeleteRequest, user.DeleteResponse](
+			httpClient,
+			baseURL+UserServiceDeleteProcedure,
+			connect.WithSchema(userServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// userServiceClient implements UserServiceClient.
+type userServiceClient struct {
+	list   *connect.Client[user.ListRequest, user.ListResponse]
+	get    *connect.Client[user.GetRequest, user.GetResponse]
+	create *connect.Client[user.CreateRequest, user.CreateResponse]
+	update *connect.Client[user.UpdateRequest, user.UpdateResponse]
+	delete *connect.Client[user.DeleteRequest, user.DeleteResponse]
+}
+
+// List calls iam.v1.user.UserService.List.
+func (c *userServiceClient) List(ctx context.Context, req *connect.Request[user.ListRequest]) (*connect.Response[user.ListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Get calls iam.v1.user.UserService.Get.
+func (c *userServiceClient) Get(ctx context.Context, req *connect.Request[user.GetRequest]) (*connect.Response[user.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Create calls iam.v1.user.UserService.Create.
+func (c *userServiceClient) Create(ctx context.Context, req *connect.Request[user.CreateRequest]) (*connect.Response[user.CreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Update calls iam.v1.user.UserService.Update.
+func (c *userServiceClient) Update(ctx context.Context, req *connect.Request[user.UpdateRequest]) (*connect.Response[user.UpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls iam.v1.user.UserService.Delete.
+func (c *userServiceClient) Delete(ctx context.Context, req *connect.Request[user.DeleteRequest]) (*connect.Response[user.DeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// UserServiceHandler is an implementation of the iam.v1.user.UserService service.
+type UserServiceHandler interface {
+	List(context.Context, *connect.Request[user.ListRequest]) (*connect.Response[user.ListResponse], error)
+	Get(context.Context, *connect.Request[user.GetRequest]) (*connect.Response[user.GetResponse], error)
+	Create(context.Context, *connect.Request[user.CreateRequest]) (*connect.Response[user.CreateResponse], error)
+	Update(context.Context, *connect.Request[user.UpdateRequest]) (*connect.Response[user.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[user.DeleteRequest]) (*connect.Response[user.DeleteResponse], error)
+}
+
+// NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	userServiceMethods := user.File_iam_v1_user_service_proto.Services().ByName("UserService").Methods()
+	userServiceListHandler := connect.NewUnaryHandler(
+		UserServiceListProcedure,
+		svc.List,
+		connect.WithSchema(userServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceGetHandler := connect.NewUnaryHandler(
+		UserServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(userServiceMethods.ByName("Get")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceCreateHandler := connect.NewUnaryHandler(
+		UserServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(userServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUpdateHandler := connect.NewUnaryHandler(
+		UserServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(userServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDeleteHandler := connect.NewUnaryHandler(
+		UserServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(userServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/iam.v1.user.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case UserServiceListProcedure:
+			userServiceListHandler.ServeHTTP(w, r)
+		case UserServiceGetProcedure:
+			userServiceGetHandler.ServeHTTP(w, r)
+		case UserServiceCreateProcedure:
+			userServiceCreateHandler.ServeHTTP(w, r)
+		case UserServiceUpdateProcedure:
+			userServiceUpdateHandler.ServeHTTP(w, r)
+		case UserServiceDeleteProcedure:
+			userServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedUserServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedUserServiceHandler struct{}
+
+func (UnimplementedUserServiceHandler) List(context.Context, *connect.Request[user.ListRequest]) (*connect.Response[user.ListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.user.UserService.List is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) Get(context.Context, *connect.Request[user.GetRequest]) (*connect.Response[user.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.user.UserService.Get is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) Create(context.Context, *connect.Request[user.CreateRequest]) (*connect.Response[user.CreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.user.UserService.Create is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) Update(context.Context, *connect.Request[user.UpdateRequest]) (*connect.Response[user.UpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.user.UserService.Update is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) Delete(context.Context, *connect.Request[user.DeleteRequest]) (*connect.Response[user.DeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("iam.v1.user.UserService.Delete is not implemented"))
+}
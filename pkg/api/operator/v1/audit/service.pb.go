@@ -0,0 +1,67 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: operator/v1/audit/service.proto
+
+package audit
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_operator_v1_audit_service_proto protoreflect.FileDescriptor
+
+const file_operator_v1_audit_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1foperator/v1/audit/service.proto\x12\x11operator.v1.audit\x1a\x1foperator/v1/audit/message.proto2Y\n" +
+	"\fAuditService\x12I\n" +
+	"\x04List\x12\x1e.operator.v1.audit.ListRequest\x1a\x1f.operator.v1.audit.ListResponse\"\x00B4Z2github.com/megakuul/miam/pkg/api/operator/v1/auditb\x06proto3"
+
+var file_operator_v1_audit_service_proto_goTypes = []any{
+	(*ListRequest)(nil),  // 0: operator.v1.audit.ListRequest
+	(*ListResponse)(nil), // 1: operator.v1.audit.ListResponse
+}
+var file_operator_v1_audit_service_proto_depIdxs = []int32{
+	0, // 0: operator.v1.audit.AuditService.List:input_type -> operator.v1.audit.ListRequest
+	1, // 1: operator.v1.audit.AuditService.List:output_type -> operator.v1.audit.ListResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_operator_v1_audit_service_proto_init() }
+func file_operator_v1_audit_service_proto_init() {
+	if File_operator_v1_audit_service_proto != nil {
+		return
+	}
+	file_operator_v1_audit_message_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_operator_v1_audit_service_proto_rawDesc), len(file_operator_v1_audit_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_operator_v1_audit_service_proto_goTypes,
+		DependencyIndexes: file_operator_v1_audit_service_proto_depIdxs,
+	}.Build()
+	File_operator_v1_audit_service_proto = out.File
+	file_operator_v1_audit_service_proto_goTypes = nil
+	file_operator_v1_audit_service_proto_depIdxs = nil
+}
@@ -43,6 +43,10 @@ const (
 	ClusterServiceUpdateProcedure = "/operator.v1.cluster.ClusterService/Update"
 	// ClusterServiceDestroyProcedure is the fully-qualified name of the ClusterService's Destroy RPC.
 	ClusterServiceDestroyProcedure = "/operator.v1.cluster.ClusterService/Destroy"
+	// ClusterServiceCancelProcedure is the fully-qualified name of the ClusterService's Cancel RPC.
+	ClusterServiceCancelProcedure = "/operator.v1.cluster.ClusterService/Cancel"
+	// ClusterServicePruneProcedure is the fully-qualified name of the ClusterService's Prune RPC.
+	ClusterServicePruneProcedure = "/operator.v1.cluster.ClusterService/Prune"
 )
 
 // ClusterServiceClient is a client for the operator.v1.cluster.ClusterService service.
@@ -52,6 +56,8 @@ type ClusterServiceClient interface {
 	Describe(context.Context, *connect.Request[cluster.DescribeRequest]) (*connect.Response[cluster.DescribeResponse], error)
 	Update(context.Context, *connect.Request[cluster.UpdateRequest]) (*connect.Response[cluster.UpdateResponse], error)
 	Destroy(context.Context, *connect.Request[cluster.DestroyRequest]) (*connect.Response[cluster.DestroyResponse], error)
+	Cancel(context.Context, *connect.Request[cluster.CancelRequest]) (*connect.Response[cluster.CancelResponse], error)
+	Prune(context.Context, *connect.Request[cluster.PruneRequest]) (*connect.Response[cluster.PruneResponse], error)
 }
 
 // NewClusterServiceClient constructs a client for the operator.v1.cluster.ClusterService service.
@@ -95,6 +101,18 @@ func NewClusterServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(clusterServiceMethods.ByName("Destroy")),
 			connect.WithClientOptions(opts...),
 		),
+		cancel: connect.NewClient[cluster.CancelRequest, cluster.CancelResponse](
+			httpClient,
+			baseURL+ClusterServiceCancelProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("Cancel")),
+			connect.WithClientOptions(opts...),
+		),
+		prune: connect.NewClient[cluster.PruneRequest, cluster.PruneResponse](
+			httpClient,
+			baseURL+ClusterServicePruneProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("Prune")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -105,6 +123,8 @@ type clusterServiceClient struct {
 	describe *connect.Client[cluster.DescribeRequest, cluster.DescribeResponse]
 	update   *connect.Client[cluster.UpdateRequest, cluster.UpdateResponse]
 	destroy  *connect.Client[cluster.DestroyRequest, cluster.DestroyResponse]
+	cancel   *connect.Client[cluster.CancelRequest, cluster.CancelResponse]
+	prune    *connect.Client[cluster.PruneRequest, cluster.PruneResponse]
 }
 
 // List calls operator.v1.cluster.ClusterService.List.
@@ -132,6 +152,16 @@ func (c *clusterServiceClient) Destroy(ctx context.Context, req *connect.Request
 	return c.destroy.CallUnary(ctx, req)
 }
 
+// Cancel calls operator.v1.cluster.ClusterService.Cancel.
+func (c *clusterServiceClient) Cancel(ctx context.Context, req *connect.Request[cluster.CancelRequest]) (*connect.Response[cluster.CancelResponse], error) {
+	return c.cancel.CallUnary(ctx, req)
+}
+
+// Prune calls operator.v1.cluster.ClusterService.Prune.
+func (c *clusterServiceClient) Prune(ctx context.Context, req *connect.Request[cluster.PruneRequest]) (*connect.Response[cluster.PruneResponse], error) {
+	return c.prune.CallUnary(ctx, req)
+}
+
 // ClusterServiceHandler is an implementation of the operator.v1.cluster.ClusterService service.
 type ClusterServiceHandler interface {
 	List(context.Context, *connect.Request[cluster.ListRequest]) (*connect.Response[cluster.ListResponse], error)
@@ -139,6 +169,8 @@ type ClusterServiceHandler interface {
 	Describe(context.Context, *connect.Request[cluster.DescribeRequest]) (*connect.Response[cluster.DescribeResponse], error)
 	Update(context.Context, *connect.Request[cluster.UpdateRequest]) (*connect.Response[cluster.UpdateResponse], error)
 	Destroy(context.Context, *connect.Request[cluster.DestroyRequest]) (*connect.Response[cluster.DestroyResponse], error)
+	Cancel(context.Context, *connect.Request[cluster.CancelRequest]) (*connect.Response[cluster.CancelResponse], error)
+	Prune(context.Context, *connect.Request[cluster.PruneRequest]) (*connect.Response[cluster.PruneResponse], error)
 }
 
 // NewClusterServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -178,6 +210,18 @@ func NewClusterServiceHandler(svc ClusterServiceHandler, opts ...connect.Handler
 		connect.WithSchema(clusterServiceMethods.ByName("Destroy")),
 		connect.WithHandlerOptions(opts...),
 	)
+	clusterServiceCancelHandler := connect.NewUnaryHandler(
+		ClusterServiceCancelProcedure,
+		svc.Cancel,
+		connect.WithSchema(clusterServiceMethods.ByName("Cancel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServicePruneHandler := connect.NewUnaryHandler(
+		ClusterServicePruneProcedure,
+		svc.Prune,
+		connect.WithSchema(clusterServiceMethods.ByName("Prune")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/operator.v1.cluster.ClusterService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ClusterServiceListProcedure:
@@ -190,6 +234,10 @@ func NewClusterServiceHandler(svc ClusterServiceHandler, opts ...connect.Handler
 			clusterServiceUpdateHandler.ServeHTTP(w, r)
 		case ClusterServiceDestroyProcedure:
 			clusterServiceDestroyHandler.ServeHTTP(w, r)
+		case ClusterServiceCancelProcedure:
+			clusterServiceCancelHandler.ServeHTTP(w, r)
+		case ClusterServicePruneProcedure:
+			clusterServicePruneHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -218,3 +266,11 @@ func (UnimplementedClusterServiceHandler) Update(context.Context, *connect.Reque
 func (UnimplementedClusterServiceHandler) Destroy(context.Context, *connect.Request[cluster.DestroyRequest]) (*connect.Response[cluster.DestroyResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("operator.v1.cluster.ClusterService.Destroy is not implemented"))
 }
+
+func (UnimplementedClusterServiceHandler) Cancel(context.Context, *connect.Request[cluster.CancelRequest]) (*connect.Response[cluster.CancelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("operator.v1.cluster.ClusterService.Cancel is not implemented"))
+}
+
+func (UnimplementedClusterServiceHandler) Prune(context.Context, *connect.Request[cluster.PruneRequest]) (*connect.Response[cluster.PruneResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("operator.v1.cluster.ClusterService.Prune is not implemented"))
+}
@@ -7,8 +7,10 @@
 package cluster
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -80,6 +82,10 @@ type ClusterStatus struct {
 	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
 	State         State                  `protobuf:"varint,4,opt,name=state,proto3,enum=operator.v1.cluster.State" json:"state,omitempty"`
 	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // time of the last state change
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // identity that requested the revision
+	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`                      // change message provided by the author
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -149,12 +155,42 @@ func (x *ClusterStatus) GetError() string {
 	return ""
 }
 
+func (x *ClusterStatus) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ClusterStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ClusterStatus) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ClusterStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type ClusterConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // unique cluster identity, used as stack name
 	RepoUrl       string                 `protobuf:"bytes,2,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
 	RepoRef       string                 `protobuf:"bytes,3,opt,name=repo_ref,json=repoRef,proto3" json:"repo_ref,omitempty"`
 	ControlConfig *InstanceConfig        `protobuf:"bytes,4,opt,name=control_config,json=controlConfig,proto3" json:"control_config,omitempty"`
 	WorkerConfig  *InstanceConfig        `protobuf:"bytes,5,opt,name=worker_config,json=workerConfig,proto3" json:"worker_config,omitempty"`
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -189,6 +225,13 @@ func (*ClusterConfig) Descriptor() ([]byte, []int) {
 	return file_operator_v1_cluster_message_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *ClusterConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 func (x *ClusterConfig) GetRepoUrl() string {
 	if x != nil {
 		return x.RepoUrl
@@ -217,6 +260,13 @@ func (x *ClusterConfig) GetWorkerConfig() *InstanceConfig {
 	return nil
 }
 
+func (x *ClusterConfig) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type InstanceConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -279,6 +329,12 @@ func (x *InstanceConfig) GetMaxScale() int64 {
 
 type ListRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`                                            // only returns clusters carrying all of these tags
+	States        []State                `protobuf:"varint,2,rep,packed,name=states,proto3,enum=operator.v1.cluster.State" json:"states,omitempty"` // only returns clusters in one of these states
+	NamePrefix    string                 `protobuf:"bytes,3,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`              // only returns clusters whose name starts with this prefix
+	Descending    bool                   `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`                               // sorts clusters by name in descending instead of ascending order
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                   // maximum number of clusters returned (defaults to 100)
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                 // next_page_token of the previous page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -313,9 +369,52 @@ func (*ListRequest) Descriptor() ([]byte, []int) {
 	return file_operator_v1_cluster_message_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *ListRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListRequest) GetStates() []State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type ListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Clusters      []*ClusterStatus       `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"` // returns latest revision of all clusters
+	Clusters      []*ClusterStatus       `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`                                  // returns latest revision of all matching clusters
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty if there are no more pages
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -357,6 +456,13 @@ func (x *ListResponse) GetClusters() []*ClusterStatus {
 	return nil
 }
 
+func (x *ListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type GetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -403,7 +509,7 @@ func (x *GetRequest) GetName() string {
 
 type GetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Revisions     []*ClusterStatus       `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // returns all revisions of one cluster
+	Revisions     []*ClusterStatus       `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // returns all revisions of one cluster (newest first)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -544,6 +650,7 @@ func (x *DescribeResponse) GetConfig() *ClusterConfig {
 type UpdateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Config        *ClusterConfig         `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // describes the change, recorded on the revision
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -585,6 +692,13 @@ func (x *UpdateRequest) GetConfig() *ClusterConfig {
 	return nil
 }
 
+func (x *UpdateRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type UpdateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Revision      string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
@@ -632,6 +746,7 @@ func (x *UpdateResponse) GetRevision() string {
 type DestroyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // describes the change, recorded on the revision
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -673,8 +788,16 @@ func (x *DestroyRequest) GetName() string {
 	return ""
 }
 
+func (x *DestroyRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type DestroyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -709,46 +832,271 @@ func (*DestroyResponse) Descriptor() ([]byte, []int) {
 	return file_operator_v1_cluster_message_proto_rawDescGZIP(), []int{12}
 }
 
+func (x *DestroyResponse) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type CancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Revision      string                 `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"` // revision that is currently deploying
+	Force         bool                   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`      // releases the revision even if another operator instance still holds its lease
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_operator_v1_cluster_message_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CancelRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *CancelRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type CancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResponse) ProtoMessage() {}
+
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_operator_v1_cluster_message_proto_rawDescGZIP(), []int{14}
+}
+
+type PruneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // prunes all clusters if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRequest) Reset() {
+	*x = PruneRequest{}
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRequest) ProtoMessage() {}
+
+func (x *PruneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRequest.ProtoReflect.Descriptor instead.
+func (*PruneRequest) Descriptor() ([]byte, []int) {
+	return file_operator_v1_cluster_message_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PruneRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PruneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*ClusterStatus       `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // returns the pruned revisions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneResponse) Reset() {
+	*x = PruneResponse{}
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneResponse) ProtoMessage() {}
+
+func (x *PruneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_cluster_message_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneResponse.ProtoReflect.Descriptor instead.
+func (*PruneResponse) Descriptor() ([]byte, []int) {
+	return file_operator_v1_cluster_message_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PruneResponse) GetRevisions() []*ClusterStatus {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
 var File_operator_v1_cluster_message_proto protoreflect.FileDescriptor
 
 const file_operator_v1_cluster_message_proto_rawDesc = "" +
 	"\n" +
-	"!operator/v1/cluster/message.proto\x12\x13operator.v1.cluster\"\x9b\x01\n" +
+	"!operator/v1/cluster/message.proto\x12\x13operator.v1.cluster\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x02\n" +
 	"\rClusterStatus\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\brevision\x18\x02 \x01(\tR\brevision\x12\x12\n" +
 	"\x04tags\x18\x03 \x03(\tR\x04tags\x120\n" +
 	"\x05state\x18\x04 \x01(\x0e2\x1a.operator.v1.cluster.StateR\x05state\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"\xdb\x01\n" +
-	"\rClusterConfig\x12\x19\n" +
-	"\brepo_url\x18\x02 \x01(\tR\arepoUrl\x12\x19\n" +
-	"\brepo_ref\x18\x03 \x01(\tR\arepoRef\x12J\n" +
-	"\x0econtrol_config\x18\x04 \x01(\v2#.operator.v1.cluster.InstanceConfigR\rcontrolConfig\x12H\n" +
-	"\rworker_config\x18\x05 \x01(\v2#.operator.v1.cluster.InstanceConfigR\fworkerConfig\"^\n" +
-	"\x0eInstanceConfig\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
-	"\tmin_scale\x18\x02 \x01(\x03R\bminScale\x12\x1b\n" +
-	"\tmax_scale\x18\x03 \x01(\x03R\bmaxScale\"\r\n" +
-	"\vListRequest\"N\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\"\xcb\x03\n" +
+	"\rClusterConfig\x12B\n" +
+	"\x04name\x18\x01 \x01(\tB.\xbaH+\xc8\x01\x01r&2$^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$R\x04name\x12w\n" +
+	"\brepo_url\x18\x02 \x01(\tB\\\xbaHY\xc8\x01\x01rT2R^((https?|ssh|git)://[^\\s/?#]+([/?#]\\S*)?|[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/]\\S*)$R\arepoUrl\x12\x19\n" +
+	"\brepo_ref\x18\x03 \x01(\tR\arepoRef\x12R\n" +
+	"\x0econtrol_config\x18\x04 \x01(\v2#.operator.v1.cluster.InstanceConfigB\x06\xbaH\x03\xc8\x01\x01R\rcontrolConfig\x12P\n" +
+	"\rworker_config\x18\x05 \x01(\v2#.operator.v1.cluster.InstanceConfigB\x06\xbaH\x03\xc8\x01\x01R\fworkerConfig\x12<\n" +
+	"\x04tags\x18\x06 \x03(\tB(\xbaH%\x92\x01\"\" r\x1e2\x1c^[A-Za-z0-9_.:/=+@-]{1,128}$R\x04tags\"\xdf\x01\n" +
+	"\x0eInstanceConfig\x12\x1a\n" +
+	"\x04type\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04type\x12$\n" +
+	"\tmin_scale\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bminScale\x12$\n" +
+	"\tmax_scale\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x01R\bmaxScale:e\xbaHb\x1a`\n" +
+	"\x17min_scale_lte_max_scale\x12#min_scale must not exceed max_scale\x1a this.min_scale <= this.max_scale\"\xa5\x02\n" +
+	"\vListRequest\x12<\n" +
+	"\x04tags\x18\x01 \x03(\tB(\xbaH%\x92\x01\"\" r\x1e2\x1c^[A-Za-z0-9_.:/=+@-]{1,128}$R\x04tags\x122\n" +
+	"\x06states\x18\x02 \x03(\x0e2\x1a.operator.v1.cluster.StateR\x06states\x12<\n" +
+	"\vname_prefix\x18\x03 \x01(\tB\x1b\xbaH\x18\xd8\x01\x01r\x132\x11^[-a-z0-9]{1,63}$R\n" +
+	"namePrefix\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x04 \x01(\bR\n" +
+	"descending\x12'\n" +
+	"\tpage_size\x18\x05 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"v\n" +
 	"\fListResponse\x12>\n" +
-	"\bclusters\x18\x01 \x03(\v2\".operator.v1.cluster.ClusterStatusR\bclusters\" \n" +
+	"\bclusters\x18\x01 \x03(\v2\".operator.v1.cluster.ClusterStatusR\bclusters\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"P\n" +
 	"\n" +
-	"GetRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"O\n" +
+	"GetRequest\x12B\n" +
+	"\x04name\x18\x01 \x01(\tB.\xbaH+\xc8\x01\x01r&2$^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$R\x04name\"O\n" +
 	"\vGetResponse\x12@\n" +
-	"\trevisions\x18\x01 \x03(\v2\".operator.v1.cluster.ClusterStatusR\trevisions\"A\n" +
-	"\x0fDescribeRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\trevisions\x18\x01 \x03(\v2\".operator.v1.cluster.ClusterStatusR\trevisions\"q\n" +
+	"\x0fDescribeRequest\x12B\n" +
+	"\x04name\x18\x01 \x01(\tB.\xbaH+\xc8\x01\x01r&2$^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$R\x04name\x12\x1a\n" +
 	"\brevision\x18\x02 \x01(\tR\brevision\"N\n" +
 	"\x10DescribeResponse\x12:\n" +
-	"\x06config\x18\x01 \x01(\v2\".operator.v1.cluster.ClusterConfigR\x06config\"K\n" +
-	"\rUpdateRequest\x12:\n" +
-	"\x06config\x18\x01 \x01(\v2\".operator.v1.cluster.ClusterConfigR\x06config\",\n" +
+	"\x06config\x18\x01 \x01(\v2\".operator.v1.cluster.ClusterConfigR\x06config\"w\n" +
+	"\rUpdateRequest\x12B\n" +
+	"\x06config\x18\x01 \x01(\v2\".operator.v1.cluster.ClusterConfigB\x06\xbaH\x03\xc8\x01\x01R\x06config\x12\"\n" +
+	"\amessage\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\amessage\",\n" +
 	"\x0eUpdateResponse\x12\x1a\n" +
-	"\brevision\x18\x01 \x01(\tR\brevision\"$\n" +
-	"\x0eDestroyRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"\x11\n" +
-	"\x0fDestroyResponse*<\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\"x\n" +
+	"\x0eDestroyRequest\x12B\n" +
+	"\x04name\x18\x01 \x01(\tB.\xbaH+\xc8\x01\x01r&2$^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$R\x04name\x12\"\n" +
+	"\amessage\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\amessage\"-\n" +
+	"\x0fDestroyResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\"\x8d\x01\n" +
+	"\rCancelRequest\x12B\n" +
+	"\x04name\x18\x01 \x01(\tB.\xbaH+\xc8\x01\x01r&2$^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$R\x04name\x12\"\n" +
+	"\brevision\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\brevision\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"\x10\n" +
+	"\x0eCancelResponse\"R\n" +
+	"\fPruneRequest\x12B\n" +
+	"\x04name\x18\x01 \x01(\tB.\xbaH+\xd8\x01\x01r&2$^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$R\x04name\"Q\n" +
+	"\rPruneResponse\x12@\n" +
+	"\trevisions\x18\x01 \x03(\v2\".operator.v1.cluster.ClusterStatusR\trevisions*<\n" +
 	"\x05State\x12\n" +
 	"\n" +
 	"\x06ACTIVE\x10\x00\x12\f\n" +
@@ -770,36 +1118,45 @@ func file_operator_v1_cluster_message_proto_rawDescGZIP() []byte {
 }
 
 var file_operator_v1_cluster_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_operator_v1_cluster_message_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_operator_v1_cluster_message_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_operator_v1_cluster_message_proto_goTypes = []any{
-	(State)(0),               // 0: operator.v1.cluster.State
-	(*ClusterStatus)(nil),    // 1: operator.v1.cluster.ClusterStatus
-	(*ClusterConfig)(nil),    // 2: operator.v1.cluster.ClusterConfig
-	(*InstanceConfig)(nil),   // 3: operator.v1.cluster.InstanceConfig
-	(*ListRequest)(nil),      // 4: operator.v1.cluster.ListRequest
-	(*ListResponse)(nil),     // 5: operator.v1.cluster.ListResponse
-	(*GetRequest)(nil),       // 6: operator.v1.cluster.GetRequest
-	(*GetResponse)(nil),      // 7: operator.v1.cluster.GetResponse
-	(*DescribeRequest)(nil),  // 8: operator.v1.cluster.DescribeRequest
-	(*DescribeResponse)(nil), // 9: operator.v1.cluster.DescribeResponse
-	(*UpdateRequest)(nil),    // 10: operator.v1.cluster.UpdateRequest
-	(*UpdateResponse)(nil),   // 11: operator.v1.cluster.UpdateResponse
-	(*DestroyRequest)(nil),   // 12: operator.v1.cluster.DestroyRequest
-	(*DestroyResponse)(nil),  // 13: operator.v1.cluster.DestroyResponse
+	(State)(0),                    // 0: operator.v1.cluster.State
+	(*ClusterStatus)(nil),         // 1: operator.v1.cluster.ClusterStatus
+	(*ClusterConfig)(nil),         // 2: operator.v1.cluster.ClusterConfig
+	(*InstanceConfig)(nil),        // 3: operator.v1.cluster.InstanceConfig
+	(*ListRequest)(nil),           // 4: operator.v1.cluster.ListRequest
+	(*ListResponse)(nil),          // 5: operator.v1.cluster.ListResponse
+	(*GetRequest)(nil),            // 6: operator.v1.cluster.GetRequest
+	(*GetResponse)(nil),           // 7: operator.v1.cluster.GetResponse
+	(*DescribeRequest)(nil),       // 8: operator.v1.cluster.DescribeRequest
+	(*DescribeResponse)(nil),      // 9: operator.v1.cluster.DescribeResponse
+	(*UpdateRequest)(nil),         // 10: operator.v1.cluster.UpdateRequest
+	(*UpdateResponse)(nil),        // 11: operator.v1.cluster.UpdateResponse
+	(*DestroyRequest)(nil),        // 12: operator.v1.cluster.DestroyRequest
+	(*DestroyResponse)(nil),       // 13: operator.v1.cluster.DestroyResponse
+	(*CancelRequest)(nil),         // 14: operator.v1.cluster.CancelRequest
+	(*CancelResponse)(nil),        // 15: operator.v1.cluster.CancelResponse
+	(*PruneRequest)(nil),          // 16: operator.v1.cluster.PruneRequest
+	(*PruneResponse)(nil),         // 17: operator.v1.cluster.PruneResponse
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
 }
 var file_operator_v1_cluster_message_proto_depIdxs = []int32{
-	0, // 0: operator.v1.cluster.ClusterStatus.state:type_name -> operator.v1.cluster.State
-	3, // 1: operator.v1.cluster.ClusterConfig.control_config:type_name -> operator.v1.cluster.InstanceConfig
-	3, // 2: operator.v1.cluster.ClusterConfig.worker_config:type_name -> operator.v1.cluster.InstanceConfig
-	1, // 3: operator.v1.cluster.ListResponse.clusters:type_name -> operator.v1.cluster.ClusterStatus
-	1, // 4: operator.v1.cluster.GetResponse.revisions:type_name -> operator.v1.cluster.ClusterStatus
-	2, // 5: operator.v1.cluster.DescribeResponse.config:type_name -> operator.v1.cluster.ClusterConfig
-	2, // 6: operator.v1.cluster.UpdateRequest.config:type_name -> operator.v1.cluster.ClusterConfig
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	0,  // 0: operator.v1.cluster.ClusterStatus.state:type_name -> operator.v1.cluster.State
+	18, // 1: operator.v1.cluster.ClusterStatus.created_at:type_name -> google.protobuf.Timestamp
+	18, // 2: operator.v1.cluster.ClusterStatus.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 3: operator.v1.cluster.ClusterConfig.control_config:type_name -> operator.v1.cluster.InstanceConfig
+	3,  // 4: operator.v1.cluster.ClusterConfig.worker_config:type_name -> operator.v1.cluster.InstanceConfig
+	0,  // 5: operator.v1.cluster.ListRequest.states:type_name -> operator.v1.cluster.State
+	1,  // 6: operator.v1.cluster.ListResponse.clusters:type_name -> operator.v1.cluster.ClusterStatus
+	1,  // 7: operator.v1.cluster.GetResponse.revisions:type_name -> operator.v1.cluster.ClusterStatus
+	2,  // 8: operator.v1.cluster.DescribeResponse.config:type_name -> operator.v1.cluster.ClusterConfig
+	2,  // 9: operator.v1.cluster.UpdateRequest.config:type_name -> operator.v1.cluster.ClusterConfig
+	1,  // 10: operator.v1.cluster.PruneResponse.revisions:type_name -> operator.v1.cluster.ClusterStatus
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_operator_v1_cluster_message_proto_init() }
@@ -813,7 +1170,7 @@ func file_operator_v1_cluster_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_operator_v1_cluster_message_proto_rawDesc), len(file_operator_v1_cluster_message_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   13,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
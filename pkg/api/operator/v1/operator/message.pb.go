@@ -7,8 +7,10 @@
 package operator
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -78,6 +80,10 @@ type OperatorStatus struct {
 	Revision      string                 `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
 	State         State                  `protobuf:"varint,4,opt,name=state,proto3,enum=operator.v1.operator.State" json:"state,omitempty"`
 	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // time of the last state change
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // identity that requested the revision
+	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`                      // change message provided by the author
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +139,34 @@ func (x *OperatorStatus) GetError() string {
 	return ""
 }
 
+func (x *OperatorStatus) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OperatorStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *OperatorStatus) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *OperatorStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type OperatorConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RepoUrl       string                 `protobuf:"bytes,2,opt,name=repo_url,json=repoUrl,proto3" json:"repo_url,omitempty"`
@@ -223,7 +257,7 @@ func (*GetRequest) Descriptor() ([]byte, []int) {
 
 type GetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Revisions     []*OperatorStatus      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // returns all revisions of the operator
+	Revisions     []*OperatorStatus      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // returns all revisions of the operator (newest first)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -356,6 +390,7 @@ func (x *DescribeResponse) GetConfig() *OperatorConfig {
 type UpdateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Config        *OperatorConfig        `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // describes the change, recorded on the revision
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -397,6 +432,13 @@ func (x *UpdateRequest) GetConfig() *OperatorConfig {
 	return nil
 }
 
+func (x *UpdateRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type UpdateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Revision      string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
@@ -443,6 +485,7 @@ func (x *UpdateResponse) GetRevision() string {
 
 type DestroyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // describes the change, recorded on the revision
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -477,8 +520,16 @@ func (*DestroyRequest) Descriptor() ([]byte, []int) {
 	return file_operator_v1_operator_message_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *DestroyRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type DestroyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -513,32 +564,224 @@ func (*DestroyResponse) Descriptor() ([]byte, []int) {
 	return file_operator_v1_operator_message_proto_rawDescGZIP(), []int{9}
 }
 
+func (x *DestroyResponse) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type CancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"` // revision that is currently deploying
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`      // releases the revision even if another operator instance still holds its lease
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_operator_v1_operator_message_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_operator_message_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_operator_v1_operator_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *CancelRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type CancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_operator_v1_operator_message_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResponse) ProtoMessage() {}
+
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_operator_message_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_operator_v1_operator_message_proto_rawDescGZIP(), []int{11}
+}
+
+type PruneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneRequest) Reset() {
+	*x = PruneRequest{}
+	mi := &file_operator_v1_operator_message_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRequest) ProtoMessage() {}
+
+func (x *PruneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_operator_message_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRequest.ProtoReflect.Descriptor instead.
+func (*PruneRequest) Descriptor() ([]byte, []int) {
+	return file_operator_v1_operator_message_proto_rawDescGZIP(), []int{12}
+}
+
+type PruneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*OperatorStatus      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // returns the pruned revisions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneResponse) Reset() {
+	*x = PruneResponse{}
+	mi := &file_operator_v1_operator_message_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneResponse) ProtoMessage() {}
+
+func (x *PruneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_operator_v1_operator_message_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneResponse.ProtoReflect.Descriptor instead.
+func (*PruneResponse) Descriptor() ([]byte, []int) {
+	return file_operator_v1_operator_message_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PruneResponse) GetRevisions() []*OperatorStatus {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
 var File_operator_v1_operator_message_proto protoreflect.FileDescriptor
 
 const file_operator_v1_operator_message_proto_rawDesc = "" +
 	"\n" +
-	"\"operator/v1/operator/message.proto\x12\x14operator.v1.operator\"u\n" +
+	"\"operator/v1/operator/message.proto\x12\x14operator.v1.operator\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa4\x02\n" +
 	"\x0eOperatorStatus\x12\x1a\n" +
 	"\brevision\x18\x02 \x01(\tR\brevision\x121\n" +
 	"\x05state\x18\x04 \x01(\x0e2\x1b.operator.v1.operator.StateR\x05state\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"F\n" +
-	"\x0eOperatorConfig\x12\x19\n" +
-	"\brepo_url\x18\x02 \x01(\tR\arepoUrl\x12\x19\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\"\xa4\x01\n" +
+	"\x0eOperatorConfig\x12w\n" +
+	"\brepo_url\x18\x02 \x01(\tB\\\xbaHY\xd8\x01\x01rT2R^((https?|ssh|git)://[^\\s/?#]+([/?#]\\S*)?|[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/]\\S*)$R\arepoUrl\x12\x19\n" +
 	"\brepo_ref\x18\x03 \x01(\tR\arepoRef\"\f\n" +
 	"\n" +
 	"GetRequest\"Q\n" +
 	"\vGetResponse\x12B\n" +
-	"\trevisions\x18\x01 \x03(\v2$.operator.v1.operator.OperatorStatusR\trevisions\"-\n" +
-	"\x0fDescribeRequest\x12\x1a\n" +
-	"\brevision\x18\x01 \x01(\tR\brevision\"P\n" +
+	"\trevisions\x18\x01 \x03(\v2$.operator.v1.operator.OperatorStatusR\trevisions\"5\n" +
+	"\x0fDescribeRequest\x12\"\n" +
+	"\brevision\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\brevision\"P\n" +
 	"\x10DescribeResponse\x12<\n" +
-	"\x06config\x18\x01 \x01(\v2$.operator.v1.operator.OperatorConfigR\x06config\"M\n" +
-	"\rUpdateRequest\x12<\n" +
-	"\x06config\x18\x01 \x01(\v2$.operator.v1.operator.OperatorConfigR\x06config\",\n" +
+	"\x06config\x18\x01 \x01(\v2$.operator.v1.operator.OperatorConfigR\x06config\"y\n" +
+	"\rUpdateRequest\x12D\n" +
+	"\x06config\x18\x01 \x01(\v2$.operator.v1.operator.OperatorConfigB\x06\xbaH\x03\xc8\x01\x01R\x06config\x12\"\n" +
+	"\amessage\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\amessage\",\n" +
 	"\x0eUpdateResponse\x12\x1a\n" +
-	"\brevision\x18\x01 \x01(\tR\brevision\"\x10\n" +
-	"\x0eDestroyRequest\"\x11\n" +
-	"\x0fDestroyResponse*<\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\"4\n" +
+	"\x0eDestroyRequest\x12\"\n" +
+	"\amessage\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\amessage\"-\n" +
+	"\x0fDestroyResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\"I\n" +
+	"\rCancelRequest\x12\"\n" +
+	"\brevision\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\brevision\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"\x10\n" +
+	"\x0eCancelResponse\"\x0e\n" +
+	"\fPruneRequest\"S\n" +
+	"\rPruneResponse\x12B\n" +
+	"\trevisions\x18\x01 \x03(\v2$.operator.v1.operator.OperatorStatusR\trevisions*<\n" +
 	"\x05State\x12\n" +
 	"\n" +
 	"\x06ACTIVE\x10\x00\x12\f\n" +
@@ -560,30 +803,38 @@ func file_operator_v1_operator_message_proto_rawDescGZIP() []byte {
 }
 
 var file_operator_v1_operator_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_operator_v1_operator_message_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_operator_v1_operator_message_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_operator_v1_operator_message_proto_goTypes = []any{
-	(State)(0),               // 0: operator.v1.operator.State
-	(*OperatorStatus)(nil),   // 1: operator.v1.operator.OperatorStatus
-	(*OperatorConfig)(nil),   // 2: operator.v1.operator.OperatorConfig
-	(*GetRequest)(nil),       // 3: operator.v1.operator.GetRequest
-	(*GetResponse)(nil),      // 4: operator.v1.operator.GetResponse
-	(*DescribeRequest)(nil),  // 5: operator.v1.operator.DescribeRequest
-	(*DescribeResponse)(nil), // 6: operator.v1.operator.DescribeResponse
-	(*UpdateRequest)(nil),    // 7: operator.v1.operator.UpdateRequest
-	(*UpdateResponse)(nil),   // 8: operator.v1.operator.UpdateResponse
-	(*DestroyRequest)(nil),   // 9: operator.v1.operator.DestroyRequest
-	(*DestroyResponse)(nil),  // 10: operator.v1.operator.DestroyResponse
+	(State)(0),                    // 0: operator.v1.operator.State
+	(*OperatorStatus)(nil),        // 1: operator.v1.operator.OperatorStatus
+	(*OperatorConfig)(nil),        // 2: operator.v1.operator.OperatorConfig
+	(*GetRequest)(nil),            // 3: operator.v1.operator.GetRequest
+	(*GetResponse)(nil),           // 4: operator.v1.operator.GetResponse
+	(*DescribeRequest)(nil),       // 5: operator.v1.operator.DescribeRequest
+	(*DescribeResponse)(nil),      // 6: operator.v1.operator.DescribeResponse
+	(*UpdateRequest)(nil),         // 7: operator.v1.operator.UpdateRequest
+	(*UpdateResponse)(nil),        // 8: operator.v1.operator.UpdateResponse
+	(*DestroyRequest)(nil),        // 9: operator.v1.operator.DestroyRequest
+	(*DestroyResponse)(nil),       // 10: operator.v1.operator.DestroyResponse
+	(*CancelRequest)(nil),         // 11: operator.v1.operator.CancelRequest
+	(*CancelResponse)(nil),        // 12: operator.v1.operator.CancelResponse
+	(*PruneRequest)(nil),          // 13: operator.v1.operator.PruneRequest
+	(*PruneResponse)(nil),         // 14: operator.v1.operator.PruneResponse
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
 }
 var file_operator_v1_operator_message_proto_depIdxs = []int32{
-	0, // 0: operator.v1.operator.OperatorStatus.state:type_name -> operator.v1.operator.State
-	1, // 1: operator.v1.operator.GetResponse.revisions:type_name -> operator.v1.operator.OperatorStatus
-	2, // 2: operator.v1.operator.DescribeResponse.config:type_name -> operator.v1.operator.OperatorConfig
-	2, // 3: operator.v1.operator.UpdateRequest.config:type_name -> operator.v1.operator.OperatorConfig
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: operator.v1.operator.OperatorStatus.state:type_name -> operator.v1.operator.State
+	15, // 1: operator.v1.operator.OperatorStatus.created_at:type_name -> google.protobuf.Timestamp
+	15, // 2: operator.v1.operator.OperatorStatus.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: operator.v1.operator.GetResponse.revisions:type_name -> operator.v1.operator.OperatorStatus
+	2,  // 4: operator.v1.operator.DescribeResponse.config:type_name -> operator.v1.operator.OperatorConfig
+	2,  // 5: operator.v1.operator.UpdateRequest.config:type_name -> operator.v1.operator.OperatorConfig
+	1,  // 6: operator.v1.operator.PruneResponse.revisions:type_name -> operator.v1.operator.OperatorStatus
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_operator_v1_operator_message_proto_init() }
@@ -597,7 +848,7 @@ func file_operator_v1_operator_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_operator_v1_operator_message_proto_rawDesc), len(file_operator_v1_operator_message_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   10,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
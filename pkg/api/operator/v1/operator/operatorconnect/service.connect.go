@@ -44,6 +44,12 @@ const (
 	// MaintenanceServiceDestroyProcedure is the fully-qualified name of the MaintenanceService's
 	// Destroy RPC.
 	MaintenanceServiceDestroyProcedure = "/operator.v1.operator.MaintenanceService/Destroy"
+	// MaintenanceServiceCancelProcedure is the fully-qualified name of the MaintenanceService's Cancel
+	// RPC.
+	MaintenanceServiceCancelProcedure = "/operator.v1.operator.MaintenanceService/Cancel"
+	// MaintenanceServicePruneProcedure is the fully-qualified name of the MaintenanceService's Prune
+	// RPC.
+	MaintenanceServicePruneProcedure = "/operator.v1.operator.MaintenanceService/Prune"
 )
 
 // MaintenanceServiceClient is a client for the operator.v1.operator.MaintenanceService service.
@@ -52,6 +58,8 @@ type MaintenanceServiceClient interface {
 	Describe(context.Context, *connect.Request[operator.DescribeRequest]) (*connect.Response[operator.DescribeResponse], error)
 	Update(context.Context, *connect.Request[operator.UpdateRequest]) (*connect.Response[operator.UpdateResponse], error)
 	Destroy(context.Context, *connect.Request[operator.DestroyRequest]) (*connect.Response[operator.DestroyResponse], error)
+	Cancel(context.Context, *connect.Request[operator.CancelRequest]) (*connect.Response[operator.CancelResponse], error)
+	Prune(context.Context, *connect.Request[operator.PruneRequest]) (*connect.Response[operator.PruneResponse], error)
 }
 
 // NewMaintenanceServiceClient constructs a client for the operator.v1.operator.MaintenanceService
@@ -89,6 +97,18 @@ func NewMaintenanceServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(maintenanceServiceMethods.ByName("Destroy")),
 			connect.WithClientOptions(opts...),
 		),
+		cancel: connect.NewClient[operator.CancelRequest, operator.CancelResponse](
+			httpClient,
+			baseURL+MaintenanceServiceCancelProcedure,
+			connect.WithSchema(maintenanceServiceMethods.ByName("Cancel")),
+			connect.WithClientOptions(opts...),
+		),
+		prune: connect.NewClient[operator.PruneRequest, operator.PruneResponse](
+			httpClient,
+			baseURL+MaintenanceServicePruneProcedure,
+			connect.WithSchema(maintenanceServiceMethods.ByName("Prune")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -98,6 +118,8 @@ type maintenanceServiceClient struct {
 	describe *connect.Client[operator.DescribeRequest, operator.DescribeResponse]
 	update   *connect.Client[operator.UpdateRequest, operator.UpdateResponse]
 	destroy  *connect.Client[operator.DestroyRequest, operator.DestroyResponse]
+	cancel   *connect.Client[operator.CancelRequest, operator.CancelResponse]
+	prune    *connect.Client[operator.PruneRequest, operator.PruneResponse]
 }
 
 // Get calls operator.v1.operator.MaintenanceService.Get.
@@ -120,6 +142,16 @@ func (c *maintenanceServiceClient) Destroy(ctx context.Context, req *connect.Req
 	return c.destroy.CallUnary(ctx, req)
 }
 
+// Cancel calls operator.v1.operator.MaintenanceService.Cancel.
+func (c *maintenanceServiceClient) Cancel(ctx context.Context, req *connect.Request[operator.CancelRequest]) (*connect.Response[operator.CancelResponse], error) {
+	return c.cancel.CallUnary(ctx, req)
+}
+
+// Prune calls operator.v1.operator.MaintenanceService.Prune.
+func (c *maintenanceServiceClient) Prune(ctx context.Context, req *connect.Request[operator.PruneRequest]) (*connect.Response[operator.PruneResponse], error) {
+	return c.prune.CallUnary(ctx, req)
+}
+
 // MaintenanceServiceHandler is an implementation of the operator.v1.operator.MaintenanceService
 // service.
 type MaintenanceServiceHandler interface {
@@ -127,6 +159,8 @@ type MaintenanceServiceHandler interface {
 	Describe(context.Context, *connect.Request[operator.DescribeRequest]) (*connect.Response[operator.DescribeResponse], error)
 	Update(context.Context, *connect.Request[operator.UpdateRequest]) (*connect.Response[operator.UpdateResponse], error)
 	Destroy(context.Context, *connect.Request[operator.DestroyRequest]) (*connect.Response[operator.DestroyResponse], error)
+	Cancel(context.Context, *connect.Request[operator.CancelRequest]) (*connect.Response[operator.CancelResponse], error)
+	Prune(context.Context, *connect.Request[operator.PruneRequest]) (*connect.Response[operator.PruneResponse], error)
 }
 
 // NewMaintenanceServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -160,6 +194,18 @@ func NewMaintenanceServiceHandler(svc MaintenanceServiceHandler, opts ...connect
 		connect.WithSchema(maintenanceServiceMethods.ByName("Destroy")),
 		connect.WithHandlerOptions(opts...),
 	)
+	maintenanceServiceCancelHandler := connect.NewUnaryHandler(
+		MaintenanceServiceCancelProcedure,
+		svc.Cancel,
+		connect.WithSchema(maintenanceServiceMethods.ByName("Cancel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	maintenanceServicePruneHandler := connect.NewUnaryHandler(
+		MaintenanceServicePruneProcedure,
+		svc.Prune,
+		connect.WithSchema(maintenanceServiceMethods.ByName("Prune")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/operator.v1.operator.MaintenanceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case MaintenanceServiceGetProcedure:
@@ -170,6 +216,10 @@ func NewMaintenanceServiceHandler(svc MaintenanceServiceHandler, opts ...connect
 			maintenanceServiceUpdateHandler.ServeHTTP(w, r)
 		case MaintenanceServiceDestroyProcedure:
 			maintenanceServiceDestroyHandler.ServeHTTP(w, r)
+		case MaintenanceServiceCancelProcedure:
+			maintenanceServiceCancelHandler.ServeHTTP(w, r)
+		case MaintenanceServicePruneProcedure:
+			maintenanceServicePruneHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -194,3 +244,11 @@ func (UnimplementedMaintenanceServiceHandler) Update(context.Context, *connect.R
 func (UnimplementedMaintenanceServiceHandler) Destroy(context.Context, *connect.Request[operator.DestroyRequest]) (*connect.Response[operator.DestroyResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("operator.v1.operator.MaintenanceService.Destroy is not implemented"))
 }
+
+func (UnimplementedMaintenanceServiceHandler) Cancel(context.Context, *connect.Request[operator.CancelRequest]) (*connect.Response[operator.CancelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("operator.v1.operator.MaintenanceService.Cancel is not implemented"))
+}
+
+func (UnimplementedMaintenanceServiceHandler) Prune(context.Context, *connect.Request[operator.PruneRequest]) (*connect.Response[operator.PruneResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("operator.v1.operator.MaintenanceService.Prune is not implemented"))
+}
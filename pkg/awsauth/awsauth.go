@@ -0,0 +1,70 @@
+// Package awsauth authenticates operator api calls with aws credentials.
+//
+// Each request carries a bearer token containing a sigv4 presigned sts GetCallerIdentity url.
+// The operator calls the url to resolve the iam principal of the caller, the project header is part
+// of the signature so that tokens issued for one operator cannot be replayed against another project.
+package awsauth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// TokenPrefix marks bearer tokens carrying a presigned sts url.
+const TokenPrefix = "miam-aws-v1."
+
+// ProjectHeader is the signed header binding a token to the operator project.
+const ProjectHeader = "X-Miam-Project"
+
+// Token creates a bearer token for the operator of the specified project.
+func Token(ctx context.Context, presigner *sts.PresignClient, project string) (string, error) {
+	req, err := presigner.PresignGetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}, func(o *sts.PresignOptions) {
+		o.ClientOptions = append(o.ClientOptions, func(o *sts.Options) {
+			o.APIOptions = append(o.APIOptions, smithyhttp.AddHeaderValue(ProjectHeader, project))
+		})
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to presign sts request: %v", err)
+	}
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(req.URL)), nil
+}
+
+// Transport is a http.RoundTripper attaching an aws bearer token to each request.
+type Transport struct {
+	base      http.RoundTripper
+	presigner *sts.PresignClient
+	project   string
+}
+
+// NewTransport creates a transport signing requests with the credentials of awsConfig before passing them to base.
+func NewTransport(base http.RoundTripper, awsConfig aws.Config, project string) *Transport {
+	return &Transport{
+		base:      base,
+		presigner: sts.NewPresignClient(sts.NewFromConfig(awsConfig)),
+		project:   project,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := Token(req.Context(), t.presigner, t.project)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
+
+// NewHTTPClient creates a http client for the generated connect clients that signs all requests
+// with the credentials of awsConfig, e.g.:
+//
+//	clusterconnect.NewClusterServiceClient(awsauth.NewHTTPClient(awsConfig, "miam"), "https://operator.example.com")
+func NewHTTPClient(awsConfig aws.Config, project string) *http.Client {
+	return &http.Client{Transport: NewTransport(http.DefaultTransport, awsConfig, project)}
+}
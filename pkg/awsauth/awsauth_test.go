@@ -0,0 +1,95 @@
+package awsauth
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+func testConfig() aws.Config {
+	return aws.Config{
+		Region: "eu-central-1",
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+		}),
+	}
+}
+
+// decodeToken returns the presigned url of the token.
+func decodeToken(t *testing.T, token string) *url.URL {
+	t.Helper()
+	encoded, ok := strings.CutPrefix(token, TokenPrefix)
+	if !ok {
+		t.Fatalf("token %q has no prefix %q", token, TokenPrefix)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("failed to decode token: %v", err)
+	}
+	presigned, err := url.Parse(string(raw))
+	if err != nil {
+		t.Fatalf("failed to parse token url: %v", err)
+	}
+	return presigned
+}
+
+func TestToken(t *testing.T) {
+	presigner := sts.NewPresignClient(sts.NewFromConfig(testConfig()))
+	token, err := Token(context.Background(), presigner, "miam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	presigned := decodeToken(t, token)
+	query := presigned.Query()
+
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{name: "https sts endpoint", ok: presigned.Scheme == "https" && strings.HasPrefix(presigned.Host, "sts.")},
+		{name: "GetCallerIdentity action", ok: query.Get("Action") == "GetCallerIdentity"},
+		{name: "project header is signed", ok: slices.Contains(strings.Split(query.Get("X-Amz-SignedHeaders"), ";"), strings.ToLower(ProjectHeader))},
+		{name: "signed with the credentials", ok: strings.HasPrefix(query.Get("X-Amz-Credential"), "AKIDEXAMPLE/")},
+		{name: "signature date", ok: query.Get("X-Amz-Date") != "" && query.Get("X-Amz-Signature") != ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.ok {
+				t.Fatalf("unexpected token url %s", presigned)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var authorization string
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		authorization = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	req, _ := http.NewRequest(http.MethodPost, "https://operator.example.com", nil)
+	if _, err := NewTransport(base, testConfig(), "miam").RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		t.Fatalf("authorization = %q, want bearer token", authorization)
+	}
+	decodeToken(t, token)
+	if req.Header.Get("Authorization") != "" {
+		t.Fatal("transport modified the original request")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,244 @@
+// Package policy implements the miam permission language.
+//
+// A policy document is a list of statements. Each statement allows or denies a set of actions on a set of
+// resources, optionally restricted by conditions on the request context:
+//
+//	{"statements": [{
+//	  "effect": "allow",
+//	  "actions": ["cluster:Get", "cluster:Update"],
+//	  "resources": ["cluster:prod-*"],
+//	  "conditions": [{"key": "cluster:tag", "operator": "equals", "values": ["team=platform"]}]
+//	}]}
+//
+// Actions are named "<service>:<method>" (see ActionOf), resources are "cluster:<name>" for cluster requests
+// and "<service>" for all other requests. Actions and resources support glob patterns (path.Match syntax).
+// A request is allowed if at least one statement allows it and no statement denies it (explicit deny precedence).
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Effect is the outcome of a statement that matches a request.
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Condition operators.
+const (
+	OperatorEquals    = "equals"
+	OperatorNotEquals = "not_equals"
+	OperatorLike      = "like"
+	OperatorNotLike   = "not_like"
+)
+
+// Condition keys provided by the operator.
+const (
+	KeyClusterTag      = "cluster:tag"
+	KeyIdentitySubject = "identity:subject"
+	KeyIdentityName    = "identity:name"
+	KeyIdentityGroup   = "identity:group"
+	KeyIdentityIssuer  = "identity:issuer"
+)
+
+var (
+	actionPattern   = regexp.MustCompile(`^(\*|[a-z*?]+:[A-Za-z*?]+)$`)
+	resourcePattern = regexp.MustCompile(`^[a-z*?]+(:[A-Za-z0-9_.=+@*?-]+)?$`)
+	conditionKeys   = []string{KeyClusterTag, KeyIdentitySubject, KeyIdentityName, KeyIdentityGroup, KeyIdentityIssuer}
+	operators       = []string{OperatorEquals, OperatorNotEquals, OperatorLike, OperatorNotLike}
+)
+
+// Condition compares the values of a context key with the condition values.
+// Positive operators hold if any context value matches any condition value,
+// negated operators hold if no context value matches.
+type Condition struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+// Statement allows or denies actions on resources.
+type Statement struct {
+	Effect     Effect      `json:"effect"`
+	Actions    []string    `json:"actions"`
+	Resources  []string    `json:"resources"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// Document is a set of statements, typically attached to a role.
+type Document struct {
+	Statements []Statement `json:"statements"`
+}
+
+// Parse decodes and validates a json policy document.
+func Parse(data []byte) (*Document, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	document := &Document{}
+	if err := decoder.Decode(document); err != nil {
+		return nil, fmt.Errorf("failed to decode policy: %v", err)
+	}
+	if err := document.Validate(); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// Validate checks that all statements are well-formed.
+func (d *Document) Validate() error {
+	for i, statement := range d.Statements {
+		if err := statement.validate(); err != nil {
+			return fmt.Errorf("statements[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func (s *Statement) validate() error {
+	if s.Effect != Allow && s.Effect != Deny {
+		return fmt.Errorf("effect must be '%s' or '%s'", Allow, Deny)
+	}
+	if len(s.Actions) < 1 {
+		return fmt.Errorf("at least one action is required")
+	}
+	for i, action := range s.Actions {
+		if !actionPattern.MatchString(action) || !validGlob(action) {
+			return fmt.Errorf("actions[%d]: '%s' is not a valid action (<service>:<method>)", i, action)
+		}
+	}
+	if len(s.Resources) < 1 {
+		return fmt.Errorf("at least one resource is required")
+	}
+	for i, resource := range s.Resources {
+		if !resourcePattern.MatchString(resource) || !validGlob(resource) {
+			return fmt.Errorf("resources[%d]: '%s' is not a valid resource", i, resource)
+		}
+	}
+	for i, condition := range s.Conditions {
+		if !slices.Contains(conditionKeys, condition.Key) {
+			return fmt.Errorf("conditions[%d]: unknown key '%s'", i, condition.Key)
+		}
+		if !slices.Contains(operators, condition.Operator) {
+			return fmt.Errorf("conditions[%d]: unknown operator '%s'", i, condition.Operator)
+		}
+		if len(condition.Values) < 1 {
+			return fmt.Errorf("conditions[%d]: at least one value is required", i)
+		}
+		for _, value := range condition.Values {
+			if !validGlob(value) {
+				return fmt.Errorf("conditions[%d]: '%s' is not a valid pattern", i, value)
+			}
+		}
+	}
+	return nil
+}
+
+func validGlob(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+func glob(pattern, value string) bool {
+	ok, _ := path.Match(pattern, value)
+	return ok
+}
+
+// ActionOf returns the action of a connect procedure,
+// e.g. "/operator.v1.cluster.ClusterService/Update" -> "cluster:Update".
+func ActionOf(procedure string) string {
+	service, method, _ := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	pkg := service[:max(strings.LastIndex(service, "."), 0)]
+	return pkg[strings.LastIndex(pkg, ".")+1:] + ":" + method
+}
+
+// Request is the subject of an evaluation.
+type Request struct {
+	Action   string
+	Resource string
+	Context  map[string][]string // condition key -> values
+}
+
+// Decision is the result of an evaluation.
+type Decision struct {
+	Allowed bool
+	Reason  string
+}
+
+// Evaluate decides the request against the documents with explicit deny precedence.
+// Requests that are not explicitly allowed are denied.
+func Evaluate(documents []*Document, request Request) Decision {
+	allowed := false
+	for _, document := range documents {
+		for i := range document.Statements {
+			statement := &document.Statements[i]
+			if !statement.matches(request) {
+				continue
+			}
+			if statement.Effect == Deny {
+				return Decision{Allowed: false, Reason: fmt.Sprintf("explicitly denied by statement %d", i)}
+			}
+			allowed = true
+		}
+	}
+	if !allowed {
+		return Decision{Allowed: false, Reason: "no statement allows the request"}
+	}
+	return Decision{Allowed: true, Reason: "allowed"}
+}
+
+// MayAllow reports whether any statement allows the action on some resource, ignoring conditions.
+// It is used to decide whether a listing is worth filtering.
+func MayAllow(documents []*Document, action string) bool {
+	for _, document := range documents {
+		for _, statement := range document.Statements {
+			if statement.Effect == Allow && slices.ContainsFunc(statement.Actions, func(pattern string) bool {
+				return glob(pattern, action)
+			}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Statement) matches(request Request) bool {
+	if !slices.ContainsFunc(s.Actions, func(pattern string) bool { return glob(pattern, request.Action) }) {
+		return false
+	}
+	if !slices.ContainsFunc(s.Resources, func(pattern string) bool { return glob(pattern, request.Resource) }) {
+		return false
+	}
+	for _, condition := range s.Conditions {
+		if !condition.holds(request.Context[condition.Key]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Condition) holds(values []string) bool {
+	matched := false
+	for _, value := range values {
+		for _, expected := range c.Values {
+			switch c.Operator {
+			case OperatorEquals, OperatorNotEquals:
+				matched = matched || value == expected
+			case OperatorLike, OperatorNotLike:
+				matched = matched || glob(expected, value)
+			}
+		}
+	}
+	if c.Operator == OperatorNotEquals || c.Operator == OperatorNotLike {
+		return !matched
+	}
+	return matched
+}
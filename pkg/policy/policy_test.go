@@ -0,0 +1,146 @@
+package policy
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	allowClusters := Statement{Effect: Allow, Actions: []string{"cluster:*"}, Resources: []string{"cluster:*"}}
+	denyProd := Statement{Effect: Deny, Actions: []string{"cluster:Update"}, Resources: []string{"cluster:prod-*"}}
+	teamTag := Condition{Key: KeyClusterTag, Operator: OperatorEquals, Values: []string{"team=platform"}}
+	tests := []struct {
+		name      string
+		documents []*Document
+		request   Request
+		allowed   bool
+	}{
+		{
+			name:      "allowed by statement",
+			documents: []*Document{{Statements: []Statement{allowClusters}}},
+			request:   Request{Action: "cluster:Update", Resource: "cluster:dev-1"},
+			allowed:   true,
+		},
+		{
+			name:      "not allowed by default",
+			documents: []*Document{{Statements: []Statement{allowClusters}}},
+			request:   Request{Action: "user:Get", Resource: "user"},
+			allowed:   false,
+		},
+		{
+			name:      "no documents",
+			documents: nil,
+			request:   Request{Action: "cluster:Get", Resource: "cluster:dev-1"},
+			allowed:   false,
+		},
+		{
+			name:      "explicit deny wins",
+			documents: []*Document{{Statements: []Statement{allowClusters, denyProd}}},
+			request:   Request{Action: "cluster:Update", Resource: "cluster:prod-1"},
+			allowed:   false,
+		},
+		{
+			name:      "explicit deny wins across documents",
+			documents: []*Document{{Statements: []Statement{denyProd}}, {Statements: []Statement{allowClusters}}},
+			request:   Request{Action: "cluster:Update", Resource: "cluster:prod-1"},
+			allowed:   false,
+		},
+		{
+			name:      "deny of other resource",
+			documents: []*Document{{Statements: []Statement{allowClusters, denyProd}}},
+			request:   Request{Action: "cluster:Update", Resource: "cluster:dev-1"},
+			allowed:   true,
+		},
+		{
+			name: "condition holds",
+			documents: []*Document{{Statements: []Statement{
+				{Effect: Allow, Actions: []string{"cluster:Get"}, Resources: []string{"cluster:*"}, Conditions: []Condition{teamTag}},
+			}}},
+			request: Request{Action: "cluster:Get", Resource: "cluster:dev-1", Context: map[string][]string{
+				KeyClusterTag: {"env=dev", "team=platform"},
+			}},
+			allowed: true,
+		},
+		{
+			name: "condition does not hold",
+			documents: []*Document{{Statements: []Statement{
+				{Effect: Allow, Actions: []string{"cluster:Get"}, Resources: []string{"cluster:*"}, Conditions: []Condition{teamTag}},
+			}}},
+			request: Request{Action: "cluster:Get", Resource: "cluster:dev-1", Context: map[string][]string{
+				KeyClusterTag: {"team=web"},
+			}},
+			allowed: false,
+		},
+		{
+			name: "negated condition holds without values",
+			documents: []*Document{{Statements: []Statement{
+				{Effect: Allow, Actions: []string{"cluster:Get"}, Resources: []string{"cluster:*"}, Conditions: []Condition{
+					{Key: KeyIdentityGroup, Operator: OperatorNotLike, Values: []string{"contractors*"}},
+				}},
+			}}},
+			request: Request{Action: "cluster:Get", Resource: "cluster:dev-1"},
+			allowed: true,
+		},
+		{
+			name: "conditional deny applies only when it holds",
+			documents: []*Document{{Statements: []Statement{
+				allowClusters,
+				{Effect: Deny, Actions: []string{"*"}, Resources: []string{"*"}, Conditions: []Condition{
+					{Key: KeyIdentityGroup, Operator: OperatorLike, Values: []string{"contractors*"}},
+				}},
+			}}},
+			request: Request{Action: "cluster:Get", Resource: "cluster:dev-1", Context: map[string][]string{
+				KeyIdentityGroup: {"contractors-eu"},
+			}},
+			allowed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := Evaluate(test.documents, test.request)
+			if decision.Allowed != test.allowed {
+				t.Fatalf("allowed = %v, want %v (%s)", decision.Allowed, test.allowed, decision.Reason)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"valid", `{"statements": [{"effect": "allow", "actions": ["cluster:Get"], "resources": ["cluster:prod-*"]}]}`, false},
+		{"wildcard action", `{"statements": [{"effect": "deny", "actions": ["*"], "resources": ["*"]}]}`, false},
+		{"unknown field", `{"statements": [], "version": 1}`, true},
+		{"unknown effect", `{"statements": [{"effect": "maybe", "actions": ["cluster:Get"], "resources": ["*"]}]}`, true},
+		{"missing actions", `{"statements": [{"effect": "allow", "resources": ["*"]}]}`, true},
+		{"invalid action", `{"statements": [{"effect": "allow", "actions": ["Get"], "resources": ["*"]}]}`, true},
+		{"invalid glob", `{"statements": [{"effect": "allow", "actions": ["cluster:["], "resources": ["*"]}]}`, true},
+		{"unknown condition key", `{"statements": [{"effect": "allow", "actions": ["*"], "resources": ["*"], "conditions": [{"key": "x", "operator": "equals", "values": ["y"]}]}]}`, true},
+		{"condition without values", `{"statements": [{"effect": "allow", "actions": ["*"], "resources": ["*"], "conditions": [{"key": "cluster:tag", "operator": "equals", "values": []}]}]}`, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.data))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("err = %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestActionOf(t *testing.T) {
+	tests := []struct {
+		procedure string
+		want      string
+	}{
+		{"/operator.v1.cluster.ClusterService/Update", "cluster:Update"},
+		{"/iam.v1.authorize.AuthorizeService/Check", "authorize:Check"},
+	}
+	for _, test := range tests {
+		t.Run(test.procedure, func(t *testing.T) {
+			if got := ActionOf(test.procedure); got != test.want {
+				t.Fatalf("action = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
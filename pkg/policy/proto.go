@@ -0,0 +1,55 @@
+package policy
+
+import "github.com/megakuul/miam/pkg/api/iam/v1/role"
+
+// FromProto converts the statements of a role into a document.
+func FromProto(statements []*role.Statement) *Document {
+	document := &Document{Statements: []Statement{}}
+	for _, statement := range statements {
+		effect := Allow
+		if statement.GetEffect() == role.Effect_DENY {
+			effect = Deny
+		}
+		conditions := []Condition{}
+		for _, condition := range statement.GetConditions() {
+			conditions = append(conditions, Condition{
+				Key:      condition.GetKey(),
+				Operator: condition.GetOperator(),
+				Values:   condition.GetValues(),
+			})
+		}
+		document.Statements = append(document.Statements, Statement{
+			Effect:     effect,
+			Actions:    statement.GetActions(),
+			Resources:  statement.GetResources(),
+			Conditions: conditions,
+		})
+	}
+	return document
+}
+
+// Proto converts the document into role statements.
+func (d *Document) Proto() []*role.Statement {
+	statements := []*role.Statement{}
+	for _, statement := range d.Statements {
+		effect := role.Effect_ALLOW
+		if statement.Effect == Deny {
+			effect = role.Effect_DENY
+		}
+		conditions := []*role.Condition{}
+		for _, condition := range statement.Conditions {
+			conditions = append(conditions, &role.Condition{
+				Key:      condition.Key,
+				Operator: condition.Operator,
+				Values:   condition.Values,
+			})
+		}
+		statements = append(statements, &role.Statement{
+			Effect:     effect,
+			Actions:    statement.Actions,
+			Resources:  statement.Resources,
+			Conditions: conditions,
+		})
+	}
+	return statements
+}
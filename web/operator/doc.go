@@ -0,0 +1,7 @@
+// Package operator embeds the static build of the operator web ui.
+//
+// The ui is only embedded if the operator is built with the webui tag after building the ui:
+//
+//	npm --prefix web/operator ci && npm --prefix web/operator run build
+//	go build -tags webui ./cmd/operator
+package operator
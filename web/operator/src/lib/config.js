@@ -0,0 +1,22 @@
+// runtime config injected by the operator into the index page (see internal/webui).
+// During development (vite dev) no config is injected, the ui then calls the api at its own origin.
+
+/**
+ * @typedef {object} Config
+ * @property {string} apiUrl base url of the operator api
+ * @property {{ issuer?: string, clientId?: string, scopes?: string[] }} oidc sign-in settings, disabled without issuer
+ */
+
+/** @returns {Config} */
+export function loadConfig() {
+	/** @type {Partial<Config>} */
+	let config = {};
+	const element = typeof document !== 'undefined' ? document.getElementById('miam-config') : null;
+	if (element?.textContent) {
+		config = JSON.parse(element.textContent);
+	}
+	return {
+		apiUrl: config.apiUrl || (typeof location !== 'undefined' ? location.origin : ''),
+		oidc: config.oidc ?? {}
+	};
+}
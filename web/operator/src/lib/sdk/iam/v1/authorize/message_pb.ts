@@ -0,0 +1,80 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/authorize/message.proto (package iam.v1.authorize, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file iam/v1/authorize/message.proto.
+ */
+export const file_iam_v1_authorize_message: GenFile = /*@__PURE__*/
+  fileDesc("Ch5pYW0vdjEvYXV0aG9yaXplL21lc3NhZ2UucHJvdG8SEGlhbS52MS5hdXRob3JpemUimgMKDENoZWNrUmVxdWVzdBIZCgdzdWJqZWN0GAEgASgJQgi6SAVyAxiABBJNCgZhY3Rpb24YAyABKAlCPbpIOnI4MjZeW2EtejAtOV0oWy1hLXowLTldezAsNjF9W2EtejAtOV0pPzpbQS1aXVtBLVphLXowLTldKiQSEAoIcmVzb3VyY2UYBCABKAkSNgoEdGFncxgFIAMoCUIoukglkgEiIiByHjIcXltBLVphLXowLTlfLjovPStALV17MSwxMjh9JDrHAbpIwwEawAEKCHJlc291cmNlEj5yZXNvdXJjZSBtdXN0IGJlICdjbHVzdGVyOjxuYW1lPicgb3IgdGhlIHNlcnZpY2Ugb2YgdGhlIGFjdGlvbhp0dGhpcy5yZXNvdXJjZS5tYXRjaGVzKCdeY2x1c3RlcjpbYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JCcpIHx8IHRoaXMuYWN0aW9uLnN0YXJ0c1dpdGgodGhpcy5yZXNvdXJjZSArICc6JylKBAgCEANSBmdyb3VwcyIwCg1DaGVja1Jlc3BvbnNlEg8KB2FsbG93ZWQYASABKAgSDgoGcmVhc29uGAIgASgJQjNaMWdpdGh1Yi5jb20vbWVnYWt1dWwvbWlhbS9wa2cvYXBpL2lhbS92MS9hdXRob3JpemViBnByb3RvMw", [file_buf_validate_validate]);
+
+/**
+ * @generated from message iam.v1.authorize.CheckRequest
+ */
+export type CheckRequest = Message<"iam.v1.authorize.CheckRequest"> & {
+  /**
+   * principal to check (see RoleBinding.subject), defaults to the caller.
+   * Checking another principal requires the "authorize:CheckOther" action, its groups are resolved from the directory.
+   *
+   * @generated from field: string subject = 1;
+   */
+  subject: string;
+
+  /**
+   * "<service>:<method>" (e.g. "cluster:Update")
+   *
+   * @generated from field: string action = 3;
+   */
+  action: string;
+
+  /**
+   * "cluster:<name>" or "<service>" (e.g. "operator")
+   *
+   * @generated from field: string resource = 4;
+   */
+  resource: string;
+
+  /**
+   * tags of the cluster, defaults to the tags of its latest revision
+   *
+   * @generated from field: repeated string tags = 5;
+   */
+  tags: string[];
+};
+
+/**
+ * Describes the message iam.v1.authorize.CheckRequest.
+ * Use `create(CheckRequestSchema)` to create a new message.
+ */
+export const CheckRequestSchema: GenMessage<CheckRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_authorize_message, 0);
+
+/**
+ * @generated from message iam.v1.authorize.CheckResponse
+ */
+export type CheckResponse = Message<"iam.v1.authorize.CheckResponse"> & {
+  /**
+   * @generated from field: bool allowed = 1;
+   */
+  allowed: boolean;
+
+  /**
+   * explains the decision
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message iam.v1.authorize.CheckResponse.
+ * Use `create(CheckResponseSchema)` to create a new message.
+ */
+export const CheckResponseSchema: GenMessage<CheckResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_authorize_message, 1);
+
@@ -0,0 +1,30 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/authorize/service.proto (package iam.v1.authorize, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { CheckRequestSchema, CheckResponseSchema } from "./message_pb";
+import { file_iam_v1_authorize_message } from "./message_pb";
+
+/**
+ * Describes the file iam/v1/authorize/service.proto.
+ */
+export const file_iam_v1_authorize_service: GenFile = /*@__PURE__*/
+  fileDesc("Ch5pYW0vdjEvYXV0aG9yaXplL3NlcnZpY2UucHJvdG8SEGlhbS52MS5hdXRob3JpemUyXgoQQXV0aG9yaXplU2VydmljZRJKCgVDaGVjaxIeLmlhbS52MS5hdXRob3JpemUuQ2hlY2tSZXF1ZXN0Gh8uaWFtLnYxLmF1dGhvcml6ZS5DaGVja1Jlc3BvbnNlIgBCM1oxZ2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvaWFtL3YxL2F1dGhvcml6ZWIGcHJvdG8z", [file_iam_v1_authorize_message]);
+
+/**
+ * @generated from service iam.v1.authorize.AuthorizeService
+ */
+export const AuthorizeService: GenService<{
+  /**
+   * @generated from rpc iam.v1.authorize.AuthorizeService.Check
+   */
+  check: {
+    methodKind: "unary";
+    input: typeof CheckRequestSchema;
+    output: typeof CheckResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_iam_v1_authorize_service, 0);
+
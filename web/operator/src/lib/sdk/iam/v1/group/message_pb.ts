@@ -0,0 +1,314 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/group/message.proto (package iam.v1.group, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file iam/v1/group/message.proto.
+ */
+export const file_iam_v1_group_message: GenFile = /*@__PURE__*/
+  fileDesc("ChppYW0vdjEvZ3JvdXAvbWVzc2FnZS5wcm90bxIMaWFtLnYxLmdyb3VwItUBCgVHcm91cBI8CgRuYW1lGAEgASgJQi66SCvIAQFyJjIkXlthLXowLTldKFstYS16MC05XXswLDYxfVthLXowLTldKT8kEh0KC2Rlc2NyaXB0aW9uGAIgASgJQgi6SAVyAxiACBIPCgdtZW1iZXJzGAMgAygJEi4KCmNyZWF0ZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIkAKC0xpc3RSZXF1ZXN0Eh0KCXBhZ2Vfc2l6ZRgBIAEoBUIKukgHGgUY6AcoABISCgpwYWdlX3Rva2VuGAIgASgJIkwKDExpc3RSZXNwb25zZRIjCgZncm91cHMYASADKAsyEy5pYW0udjEuZ3JvdXAuR3JvdXASFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJIkoKCkdldFJlcXVlc3QSPAoEbmFtZRgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JCIxCgtHZXRSZXNwb25zZRIiCgVncm91cBgBIAEoCzITLmlhbS52MS5ncm91cC5Hcm91cCI7Cg1DcmVhdGVSZXF1ZXN0EioKBWdyb3VwGAEgASgLMhMuaWFtLnYxLmdyb3VwLkdyb3VwQga6SAPIAQEiNAoOQ3JlYXRlUmVzcG9uc2USIgoFZ3JvdXAYASABKAsyEy5pYW0udjEuZ3JvdXAuR3JvdXAiOwoNVXBkYXRlUmVxdWVzdBIqCgVncm91cBgBIAEoCzITLmlhbS52MS5ncm91cC5Hcm91cEIGukgDyAEBIjQKDlVwZGF0ZVJlc3BvbnNlEiIKBWdyb3VwGAEgASgLMhMuaWFtLnYxLmdyb3VwLkdyb3VwIk0KDURlbGV0ZVJlcXVlc3QSPAoEbmFtZRgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JCIQCg5EZWxldGVSZXNwb25zZSKIAQoQQWRkTWVtYmVyUmVxdWVzdBI9CgVncm91cBgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JBI1CgR1c2VyGAIgASgJQie6SCTIAQFyHzIdXltBLVphLXowLTkuX0ArPSw6Ly1dezEsMjU2fSQiEwoRQWRkTWVtYmVyUmVzcG9uc2UiiwEKE1JlbW92ZU1lbWJlclJlcXVlc3QSPQoFZ3JvdXAYASABKAlCLrpIK8gBAXImMiReW2EtejAtOV0oWy1hLXowLTldezAsNjF9W2EtejAtOV0pPyQSNQoEdXNlchgCIAEoCUInukgkyAEBch8yHV5bQS1aYS16MC05Ll9AKz0sOi8tXXsxLDI1Nn0kIhYKFFJlbW92ZU1lbWJlclJlc3BvbnNlQi9aLWdpdGh1Yi5jb20vbWVnYWt1dWwvbWlhbS9wa2cvYXBpL2lhbS92MS9ncm91cGIGcHJvdG8z", [file_buf_validate_validate, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message iam.v1.group.Group
+ */
+export type Group = Message<"iam.v1.group.Group"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * user names, managed through AddMember and RemoveMember
+   *
+   * @generated from field: repeated string members = 3;
+   */
+  members: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 5;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message iam.v1.group.Group.
+ * Use `create(GroupSchema)` to create a new message.
+ */
+export const GroupSchema: GenMessage<Group> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 0);
+
+/**
+ * @generated from message iam.v1.group.ListRequest
+ */
+export type ListRequest = Message<"iam.v1.group.ListRequest"> & {
+  /**
+   * maximum number of groups returned (defaults to 100)
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token of the previous page
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message iam.v1.group.ListRequest.
+ * Use `create(ListRequestSchema)` to create a new message.
+ */
+export const ListRequestSchema: GenMessage<ListRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 1);
+
+/**
+ * @generated from message iam.v1.group.ListResponse
+ */
+export type ListResponse = Message<"iam.v1.group.ListResponse"> & {
+  /**
+   * returns groups ordered by name
+   *
+   * @generated from field: repeated iam.v1.group.Group groups = 1;
+   */
+  groups: Group[];
+
+  /**
+   * empty if there are no more pages
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message iam.v1.group.ListResponse.
+ * Use `create(ListResponseSchema)` to create a new message.
+ */
+export const ListResponseSchema: GenMessage<ListResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 2);
+
+/**
+ * @generated from message iam.v1.group.GetRequest
+ */
+export type GetRequest = Message<"iam.v1.group.GetRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.group.GetRequest.
+ * Use `create(GetRequestSchema)` to create a new message.
+ */
+export const GetRequestSchema: GenMessage<GetRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 3);
+
+/**
+ * @generated from message iam.v1.group.GetResponse
+ */
+export type GetResponse = Message<"iam.v1.group.GetResponse"> & {
+  /**
+   * @generated from field: iam.v1.group.Group group = 1;
+   */
+  group?: Group;
+};
+
+/**
+ * Describes the message iam.v1.group.GetResponse.
+ * Use `create(GetResponseSchema)` to create a new message.
+ */
+export const GetResponseSchema: GenMessage<GetResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 4);
+
+/**
+ * @generated from message iam.v1.group.CreateRequest
+ */
+export type CreateRequest = Message<"iam.v1.group.CreateRequest"> & {
+  /**
+   * members are ignored
+   *
+   * @generated from field: iam.v1.group.Group group = 1;
+   */
+  group?: Group;
+};
+
+/**
+ * Describes the message iam.v1.group.CreateRequest.
+ * Use `create(CreateRequestSchema)` to create a new message.
+ */
+export const CreateRequestSchema: GenMessage<CreateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 5);
+
+/**
+ * @generated from message iam.v1.group.CreateResponse
+ */
+export type CreateResponse = Message<"iam.v1.group.CreateResponse"> & {
+  /**
+   * @generated from field: iam.v1.group.Group group = 1;
+   */
+  group?: Group;
+};
+
+/**
+ * Describes the message iam.v1.group.CreateResponse.
+ * Use `create(CreateResponseSchema)` to create a new message.
+ */
+export const CreateResponseSchema: GenMessage<CreateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 6);
+
+/**
+ * @generated from message iam.v1.group.UpdateRequest
+ */
+export type UpdateRequest = Message<"iam.v1.group.UpdateRequest"> & {
+  /**
+   * members are ignored
+   *
+   * @generated from field: iam.v1.group.Group group = 1;
+   */
+  group?: Group;
+};
+
+/**
+ * Describes the message iam.v1.group.UpdateRequest.
+ * Use `create(UpdateRequestSchema)` to create a new message.
+ */
+export const UpdateRequestSchema: GenMessage<UpdateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 7);
+
+/**
+ * @generated from message iam.v1.group.UpdateResponse
+ */
+export type UpdateResponse = Message<"iam.v1.group.UpdateResponse"> & {
+  /**
+   * @generated from field: iam.v1.group.Group group = 1;
+   */
+  group?: Group;
+};
+
+/**
+ * Describes the message iam.v1.group.UpdateResponse.
+ * Use `create(UpdateResponseSchema)` to create a new message.
+ */
+export const UpdateResponseSchema: GenMessage<UpdateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 8);
+
+/**
+ * @generated from message iam.v1.group.DeleteRequest
+ */
+export type DeleteRequest = Message<"iam.v1.group.DeleteRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.group.DeleteRequest.
+ * Use `create(DeleteRequestSchema)` to create a new message.
+ */
+export const DeleteRequestSchema: GenMessage<DeleteRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 9);
+
+/**
+ * @generated from message iam.v1.group.DeleteResponse
+ */
+export type DeleteResponse = Message<"iam.v1.group.DeleteResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.group.DeleteResponse.
+ * Use `create(DeleteResponseSchema)` to create a new message.
+ */
+export const DeleteResponseSchema: GenMessage<DeleteResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 10);
+
+/**
+ * @generated from message iam.v1.group.AddMemberRequest
+ */
+export type AddMemberRequest = Message<"iam.v1.group.AddMemberRequest"> & {
+  /**
+   * @generated from field: string group = 1;
+   */
+  group: string;
+
+  /**
+   * @generated from field: string user = 2;
+   */
+  user: string;
+};
+
+/**
+ * Describes the message iam.v1.group.AddMemberRequest.
+ * Use `create(AddMemberRequestSchema)` to create a new message.
+ */
+export const AddMemberRequestSchema: GenMessage<AddMemberRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 11);
+
+/**
+ * @generated from message iam.v1.group.AddMemberResponse
+ */
+export type AddMemberResponse = Message<"iam.v1.group.AddMemberResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.group.AddMemberResponse.
+ * Use `create(AddMemberResponseSchema)` to create a new message.
+ */
+export const AddMemberResponseSchema: GenMessage<AddMemberResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 12);
+
+/**
+ * @generated from message iam.v1.group.RemoveMemberRequest
+ */
+export type RemoveMemberRequest = Message<"iam.v1.group.RemoveMemberRequest"> & {
+  /**
+   * @generated from field: string group = 1;
+   */
+  group: string;
+
+  /**
+   * @generated from field: string user = 2;
+   */
+  user: string;
+};
+
+/**
+ * Describes the message iam.v1.group.RemoveMemberRequest.
+ * Use `create(RemoveMemberRequestSchema)` to create a new message.
+ */
+export const RemoveMemberRequestSchema: GenMessage<RemoveMemberRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 13);
+
+/**
+ * @generated from message iam.v1.group.RemoveMemberResponse
+ */
+export type RemoveMemberResponse = Message<"iam.v1.group.RemoveMemberResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.group.RemoveMemberResponse.
+ * Use `create(RemoveMemberResponseSchema)` to create a new message.
+ */
+export const RemoveMemberResponseSchema: GenMessage<RemoveMemberResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_group_message, 14);
+
@@ -0,0 +1,78 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/group/service.proto (package iam.v1.group, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { AddMemberRequestSchema, AddMemberResponseSchema, CreateRequestSchema, CreateResponseSchema, DeleteRequestSchema, DeleteResponseSchema, GetRequestSchema, GetResponseSchema, ListRequestSchema, ListResponseSchema, RemoveMemberRequestSchema, RemoveMemberResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
+import { file_iam_v1_group_message } from "./message_pb";
+
+/**
+ * Describes the file iam/v1/group/service.proto.
+ */
+export const file_iam_v1_group_service: GenFile = /*@__PURE__*/
+  fileDesc("ChppYW0vdjEvZ3JvdXAvc2VydmljZS5wcm90bxIMaWFtLnYxLmdyb3VwMosECgxHcm91cFNlcnZpY2USPwoETGlzdBIZLmlhbS52MS5ncm91cC5MaXN0UmVxdWVzdBoaLmlhbS52MS5ncm91cC5MaXN0UmVzcG9uc2UiABI8CgNHZXQSGC5pYW0udjEuZ3JvdXAuR2V0UmVxdWVzdBoZLmlhbS52MS5ncm91cC5HZXRSZXNwb25zZSIAEkUKBkNyZWF0ZRIbLmlhbS52MS5ncm91cC5DcmVhdGVSZXF1ZXN0GhwuaWFtLnYxLmdyb3VwLkNyZWF0ZVJlc3BvbnNlIgASRQoGVXBkYXRlEhsuaWFtLnYxLmdyb3VwLlVwZGF0ZVJlcXVlc3QaHC5pYW0udjEuZ3JvdXAuVXBkYXRlUmVzcG9uc2UiABJFCgZEZWxldGUSGy5pYW0udjEuZ3JvdXAuRGVsZXRlUmVxdWVzdBocLmlhbS52MS5ncm91cC5EZWxldGVSZXNwb25zZSIAEk4KCUFkZE1lbWJlchIeLmlhbS52MS5ncm91cC5BZGRNZW1iZXJSZXF1ZXN0Gh8uaWFtLnYxLmdyb3VwLkFkZE1lbWJlclJlc3BvbnNlIgASVwoMUmVtb3ZlTWVtYmVyEiEuaWFtLnYxLmdyb3VwLlJlbW92ZU1lbWJlclJlcXVlc3QaIi5pYW0udjEuZ3JvdXAuUmVtb3ZlTWVtYmVyUmVzcG9uc2UiAEIvWi1naXRodWIuY29tL21lZ2FrdXVsL21pYW0vcGtnL2FwaS9pYW0vdjEvZ3JvdXBiBnByb3RvMw", [file_iam_v1_group_message]);
+
+/**
+ * @generated from service iam.v1.group.GroupService
+ */
+export const GroupService: GenService<{
+  /**
+   * @generated from rpc iam.v1.group.GroupService.List
+   */
+  list: {
+    methodKind: "unary";
+    input: typeof ListRequestSchema;
+    output: typeof ListResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.group.GroupService.Get
+   */
+  get: {
+    methodKind: "unary";
+    input: typeof GetRequestSchema;
+    output: typeof GetResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.group.GroupService.Create
+   */
+  create: {
+    methodKind: "unary";
+    input: typeof CreateRequestSchema;
+    output: typeof CreateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.group.GroupService.Update
+   */
+  update: {
+    methodKind: "unary";
+    input: typeof UpdateRequestSchema;
+    output: typeof UpdateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.group.GroupService.Delete
+   */
+  delete: {
+    methodKind: "unary";
+    input: typeof DeleteRequestSchema;
+    output: typeof DeleteResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.group.GroupService.AddMember
+   */
+  addMember: {
+    methodKind: "unary";
+    input: typeof AddMemberRequestSchema;
+    output: typeof AddMemberResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.group.GroupService.RemoveMember
+   */
+  removeMember: {
+    methodKind: "unary";
+    input: typeof RemoveMemberRequestSchema;
+    output: typeof RemoveMemberResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_iam_v1_group_service, 0);
+
@@ -0,0 +1,472 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/role/message.proto (package iam.v1.role, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file iam/v1/role/message.proto.
+ */
+export const file_iam_v1_role_message: GenFile = /*@__PURE__*/
+  fileDesc("ChlpYW0vdjEvcm9sZS9tZXNzYWdlLnByb3RvEgtpYW0udjEucm9sZSLFAQoJQ29uZGl0aW9uEmEKA2tleRgBIAEoCUJUukhRck9SC2NsdXN0ZXI6dGFnUhBpZGVudGl0eTpzdWJqZWN0Ug1pZGVudGl0eTpuYW1lUg5pZGVudGl0eTpncm91cFIPaWRlbnRpdHk6aXNzdWVyEjsKCG9wZXJhdG9yGAIgASgJQim6SCZyJFIGZXF1YWxzUgpub3RfZXF1YWxzUgRsaWtlUghub3RfbGlrZRIYCgZ2YWx1ZXMYAyADKAlCCLpIBZIBAggBIucBCglTdGF0ZW1lbnQSLQoGZWZmZWN0GAEgASgOMhMuaWFtLnYxLnJvbGUuRWZmZWN0Qgi6SAWCAQIQARI6CgdhY3Rpb25zGAIgAygJQim6SCaSASMIASIfch0yG14oXCp8W2Eteio/XSs6W0EtWmEteio/XSspJBJDCglyZXNvdXJjZXMYAyADKAlCMLpILZIBKggBIiZyJDIiXlthLXoqP10rKDpbQS1aYS16MC05Xy49K0AqPy1dKyk/JBIqCgpjb25kaXRpb25zGAQgAygLMhYuaWFtLnYxLnJvbGUuQ29uZGl0aW9uIoYCCgRSb2xlEjwKBG5hbWUYASABKAlCLrpIK8gBAXImMiReW2EtejAtOV0oWy1hLXowLTldezAsNjF9W2EtejAtOV0pPyQSHQoLZGVzY3JpcHRpb24YAiABKAlCCLpIBXIDGIAIEioKCnN0YXRlbWVudHMYByADKAsyFi5pYW0udjEucm9sZS5TdGF0ZW1lbnQSDwoHYnVpbHRpbhgEIAEoCBIuCgpjcmVhdGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEoECAMQBCKsAgoLUm9sZUJpbmRpbmcSCgoCaWQYASABKAkSPAoEcm9sZRgCIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JBIcCgdzdWJqZWN0GAMgASgJQgu6SAjIAQFyAxiABBJNCghjbHVzdGVycxgEIAMoCUI7ukg4kgE1IjNyMTIvXihbLWEtejAtOSo/XXxcW1xePyhbYS16MC05XSgtW2EtejAtOV0pPykrXF0pKyQSNgoEdGFncxgFIAMoCUIoukglkgEiIiByHjIcXltBLVphLXowLTlfLjovPStALV17MSwxMjh9JBIuCgpjcmVhdGVkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCINCgtMaXN0UmVxdWVzdCIwCgxMaXN0UmVzcG9uc2USIAoFcm9sZXMYASADKAsyES5pYW0udjEucm9sZS5Sb2xlIkoKCkdldFJlcXVlc3QSPAoEbmFtZRgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JCIuCgtHZXRSZXNwb25zZRIfCgRyb2xlGAEgASgLMhEuaWFtLnYxLnJvbGUuUm9sZSI4Cg1DcmVhdGVSZXF1ZXN0EicKBHJvbGUYASABKAsyES5pYW0udjEucm9sZS5Sb2xlQga6SAPIAQEiMQoOQ3JlYXRlUmVzcG9uc2USHwoEcm9sZRgBIAEoCzIRLmlhbS52MS5yb2xlLlJvbGUiOAoNVXBkYXRlUmVxdWVzdBInCgRyb2xlGAEgASgLMhEuaWFtLnYxLnJvbGUuUm9sZUIGukgDyAEBIjEKDlVwZGF0ZVJlc3BvbnNlEh8KBHJvbGUYASABKAsyES5pYW0udjEucm9sZS5Sb2xlIk0KDURlbGV0ZVJlcXVlc3QSPAoEbmFtZRgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JCIQCg5EZWxldGVSZXNwb25zZSI0ChNMaXN0QmluZGluZ3NSZXF1ZXN0EgwKBHJvbGUYASABKAkSDwoHc3ViamVjdBgCIAEoCSJCChRMaXN0QmluZGluZ3NSZXNwb25zZRIqCghiaW5kaW5ncxgBIAMoCzIYLmlhbS52MS5yb2xlLlJvbGVCaW5kaW5nIkkKFENyZWF0ZUJpbmRpbmdSZXF1ZXN0EjEKB2JpbmRpbmcYASABKAsyGC5pYW0udjEucm9sZS5Sb2xlQmluZGluZ0IGukgDyAEBIkIKFUNyZWF0ZUJpbmRpbmdSZXNwb25zZRIpCgdiaW5kaW5nGAEgASgLMhguaWFtLnYxLnJvbGUuUm9sZUJpbmRpbmciKgoURGVsZXRlQmluZGluZ1JlcXVlc3QSEgoCaWQYASABKAlCBrpIA8gBASIXChVEZWxldGVCaW5kaW5nUmVzcG9uc2UqHQoGRWZmZWN0EgkKBUFMTE9XEAASCAoEREVOWRABQi5aLGdpdGh1Yi5jb20vbWVnYWt1dWwvbWlhbS9wa2cvYXBpL2lhbS92MS9yb2xlYgZwcm90bzM", [file_buf_validate_validate, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message iam.v1.role.Condition
+ */
+export type Condition = Message<"iam.v1.role.Condition"> & {
+  /**
+   * cluster:tag, identity:subject, identity:name, identity:group or identity:issuer
+   *
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * equals, not_equals, like or not_like
+   *
+   * @generated from field: string operator = 2;
+   */
+  operator: string;
+
+  /**
+   * @generated from field: repeated string values = 3;
+   */
+  values: string[];
+};
+
+/**
+ * Describes the message iam.v1.role.Condition.
+ * Use `create(ConditionSchema)` to create a new message.
+ */
+export const ConditionSchema: GenMessage<Condition> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 0);
+
+/**
+ * @generated from message iam.v1.role.Statement
+ */
+export type Statement = Message<"iam.v1.role.Statement"> & {
+  /**
+   * @generated from field: iam.v1.role.Effect effect = 1;
+   */
+  effect: Effect;
+
+  /**
+   * "<service>:<method>" patterns (e.g. "cluster:Get", "cluster:*" or "*")
+   *
+   * @generated from field: repeated string actions = 2;
+   */
+  actions: string[];
+
+  /**
+   * "cluster:<name>" or "<service>" patterns (e.g. "cluster:prod-*" or "*")
+   *
+   * @generated from field: repeated string resources = 3;
+   */
+  resources: string[];
+
+  /**
+   * all conditions must hold for the statement to apply
+   *
+   * @generated from field: repeated iam.v1.role.Condition conditions = 4;
+   */
+  conditions: Condition[];
+};
+
+/**
+ * Describes the message iam.v1.role.Statement.
+ * Use `create(StatementSchema)` to create a new message.
+ */
+export const StatementSchema: GenMessage<Statement> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 1);
+
+/**
+ * @generated from message iam.v1.role.Role
+ */
+export type Role = Message<"iam.v1.role.Role"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * policy of the role, denying statements take precedence
+   *
+   * @generated from field: repeated iam.v1.role.Statement statements = 7;
+   */
+  statements: Statement[];
+
+  /**
+   * builtin roles (viewer, operator, admin) cannot be changed
+   *
+   * @generated from field: bool builtin = 4;
+   */
+  builtin: boolean;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 5;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 6;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message iam.v1.role.Role.
+ * Use `create(RoleSchema)` to create a new message.
+ */
+export const RoleSchema: GenMessage<Role> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 2);
+
+/**
+ * @generated from message iam.v1.role.RoleBinding
+ */
+export type RoleBinding = Message<"iam.v1.role.RoleBinding"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string role = 2;
+   */
+  role: string;
+
+  /**
+   * principal (user name, identity subject or "<issuer>#<subject>" of untrusted issuers) or "group:<name>"
+   *
+   * @generated from field: string subject = 3;
+   */
+  subject: string;
+
+  /**
+   * restricts the binding to clusters matching one of these name patterns
+   *
+   * @generated from field: repeated string clusters = 4;
+   */
+  clusters: string[];
+
+  /**
+   * restricts the binding to clusters carrying one of these tags
+   *
+   * @generated from field: repeated string tags = 5;
+   */
+  tags: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message iam.v1.role.RoleBinding.
+ * Use `create(RoleBindingSchema)` to create a new message.
+ */
+export const RoleBindingSchema: GenMessage<RoleBinding> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 3);
+
+/**
+ * @generated from message iam.v1.role.ListRequest
+ */
+export type ListRequest = Message<"iam.v1.role.ListRequest"> & {
+};
+
+/**
+ * Describes the message iam.v1.role.ListRequest.
+ * Use `create(ListRequestSchema)` to create a new message.
+ */
+export const ListRequestSchema: GenMessage<ListRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 4);
+
+/**
+ * @generated from message iam.v1.role.ListResponse
+ */
+export type ListResponse = Message<"iam.v1.role.ListResponse"> & {
+  /**
+   * returns builtin and custom roles ordered by name
+   *
+   * @generated from field: repeated iam.v1.role.Role roles = 1;
+   */
+  roles: Role[];
+};
+
+/**
+ * Describes the message iam.v1.role.ListResponse.
+ * Use `create(ListResponseSchema)` to create a new message.
+ */
+export const ListResponseSchema: GenMessage<ListResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 5);
+
+/**
+ * @generated from message iam.v1.role.GetRequest
+ */
+export type GetRequest = Message<"iam.v1.role.GetRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.role.GetRequest.
+ * Use `create(GetRequestSchema)` to create a new message.
+ */
+export const GetRequestSchema: GenMessage<GetRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 6);
+
+/**
+ * @generated from message iam.v1.role.GetResponse
+ */
+export type GetResponse = Message<"iam.v1.role.GetResponse"> & {
+  /**
+   * @generated from field: iam.v1.role.Role role = 1;
+   */
+  role?: Role;
+};
+
+/**
+ * Describes the message iam.v1.role.GetResponse.
+ * Use `create(GetResponseSchema)` to create a new message.
+ */
+export const GetResponseSchema: GenMessage<GetResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 7);
+
+/**
+ * @generated from message iam.v1.role.CreateRequest
+ */
+export type CreateRequest = Message<"iam.v1.role.CreateRequest"> & {
+  /**
+   * @generated from field: iam.v1.role.Role role = 1;
+   */
+  role?: Role;
+};
+
+/**
+ * Describes the message iam.v1.role.CreateRequest.
+ * Use `create(CreateRequestSchema)` to create a new message.
+ */
+export const CreateRequestSchema: GenMessage<CreateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 8);
+
+/**
+ * @generated from message iam.v1.role.CreateResponse
+ */
+export type CreateResponse = Message<"iam.v1.role.CreateResponse"> & {
+  /**
+   * @generated from field: iam.v1.role.Role role = 1;
+   */
+  role?: Role;
+};
+
+/**
+ * Describes the message iam.v1.role.CreateResponse.
+ * Use `create(CreateResponseSchema)` to create a new message.
+ */
+export const CreateResponseSchema: GenMessage<CreateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 9);
+
+/**
+ * @generated from message iam.v1.role.UpdateRequest
+ */
+export type UpdateRequest = Message<"iam.v1.role.UpdateRequest"> & {
+  /**
+   * @generated from field: iam.v1.role.Role role = 1;
+   */
+  role?: Role;
+};
+
+/**
+ * Describes the message iam.v1.role.UpdateRequest.
+ * Use `create(UpdateRequestSchema)` to create a new message.
+ */
+export const UpdateRequestSchema: GenMessage<UpdateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 10);
+
+/**
+ * @generated from message iam.v1.role.UpdateResponse
+ */
+export type UpdateResponse = Message<"iam.v1.role.UpdateResponse"> & {
+  /**
+   * @generated from field: iam.v1.role.Role role = 1;
+   */
+  role?: Role;
+};
+
+/**
+ * Describes the message iam.v1.role.UpdateResponse.
+ * Use `create(UpdateResponseSchema)` to create a new message.
+ */
+export const UpdateResponseSchema: GenMessage<UpdateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 11);
+
+/**
+ * @generated from message iam.v1.role.DeleteRequest
+ */
+export type DeleteRequest = Message<"iam.v1.role.DeleteRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.role.DeleteRequest.
+ * Use `create(DeleteRequestSchema)` to create a new message.
+ */
+export const DeleteRequestSchema: GenMessage<DeleteRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 12);
+
+/**
+ * @generated from message iam.v1.role.DeleteResponse
+ */
+export type DeleteResponse = Message<"iam.v1.role.DeleteResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.role.DeleteResponse.
+ * Use `create(DeleteResponseSchema)` to create a new message.
+ */
+export const DeleteResponseSchema: GenMessage<DeleteResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 13);
+
+/**
+ * @generated from message iam.v1.role.ListBindingsRequest
+ */
+export type ListBindingsRequest = Message<"iam.v1.role.ListBindingsRequest"> & {
+  /**
+   * only returns bindings of this role
+   *
+   * @generated from field: string role = 1;
+   */
+  role: string;
+
+  /**
+   * only returns bindings of this subject
+   *
+   * @generated from field: string subject = 2;
+   */
+  subject: string;
+};
+
+/**
+ * Describes the message iam.v1.role.ListBindingsRequest.
+ * Use `create(ListBindingsRequestSchema)` to create a new message.
+ */
+export const ListBindingsRequestSchema: GenMessage<ListBindingsRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 14);
+
+/**
+ * @generated from message iam.v1.role.ListBindingsResponse
+ */
+export type ListBindingsResponse = Message<"iam.v1.role.ListBindingsResponse"> & {
+  /**
+   * @generated from field: repeated iam.v1.role.RoleBinding bindings = 1;
+   */
+  bindings: RoleBinding[];
+};
+
+/**
+ * Describes the message iam.v1.role.ListBindingsResponse.
+ * Use `create(ListBindingsResponseSchema)` to create a new message.
+ */
+export const ListBindingsResponseSchema: GenMessage<ListBindingsResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 15);
+
+/**
+ * @generated from message iam.v1.role.CreateBindingRequest
+ */
+export type CreateBindingRequest = Message<"iam.v1.role.CreateBindingRequest"> & {
+  /**
+   * @generated from field: iam.v1.role.RoleBinding binding = 1;
+   */
+  binding?: RoleBinding;
+};
+
+/**
+ * Describes the message iam.v1.role.CreateBindingRequest.
+ * Use `create(CreateBindingRequestSchema)` to create a new message.
+ */
+export const CreateBindingRequestSchema: GenMessage<CreateBindingRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 16);
+
+/**
+ * @generated from message iam.v1.role.CreateBindingResponse
+ */
+export type CreateBindingResponse = Message<"iam.v1.role.CreateBindingResponse"> & {
+  /**
+   * @generated from field: iam.v1.role.RoleBinding binding = 1;
+   */
+  binding?: RoleBinding;
+};
+
+/**
+ * Describes the message iam.v1.role.CreateBindingResponse.
+ * Use `create(CreateBindingResponseSchema)` to create a new message.
+ */
+export const CreateBindingResponseSchema: GenMessage<CreateBindingResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 17);
+
+/**
+ * @generated from message iam.v1.role.DeleteBindingRequest
+ */
+export type DeleteBindingRequest = Message<"iam.v1.role.DeleteBindingRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message iam.v1.role.DeleteBindingRequest.
+ * Use `create(DeleteBindingRequestSchema)` to create a new message.
+ */
+export const DeleteBindingRequestSchema: GenMessage<DeleteBindingRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 18);
+
+/**
+ * @generated from message iam.v1.role.DeleteBindingResponse
+ */
+export type DeleteBindingResponse = Message<"iam.v1.role.DeleteBindingResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.role.DeleteBindingResponse.
+ * Use `create(DeleteBindingResponseSchema)` to create a new message.
+ */
+export const DeleteBindingResponseSchema: GenMessage<DeleteBindingResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_role_message, 19);
+
+/**
+ * @generated from enum iam.v1.role.Effect
+ */
+export enum Effect {
+  /**
+   * @generated from enum value: ALLOW = 0;
+   */
+  ALLOW = 0,
+
+  /**
+   * @generated from enum value: DENY = 1;
+   */
+  DENY = 1,
+}
+
+/**
+ * Describes the enum iam.v1.role.Effect.
+ */
+export const EffectSchema: GenEnum<Effect> = /*@__PURE__*/
+  enumDesc(file_iam_v1_role_message, 0);
+
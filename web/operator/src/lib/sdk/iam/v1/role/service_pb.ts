@@ -0,0 +1,86 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/role/service.proto (package iam.v1.role, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { CreateBindingRequestSchema, CreateBindingResponseSchema, CreateRequestSchema, CreateResponseSchema, DeleteBindingRequestSchema, DeleteBindingResponseSchema, DeleteRequestSchema, DeleteResponseSchema, GetRequestSchema, GetResponseSchema, ListBindingsRequestSchema, ListBindingsResponseSchema, ListRequestSchema, ListResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
+import { file_iam_v1_role_message } from "./message_pb";
+
+/**
+ * Describes the file iam/v1/role/service.proto.
+ */
+export const file_iam_v1_role_service: GenFile = /*@__PURE__*/
+  fileDesc("ChlpYW0vdjEvcm9sZS9zZXJ2aWNlLnByb3RvEgtpYW0udjEucm9sZTLiBAoLUm9sZVNlcnZpY2USPQoETGlzdBIYLmlhbS52MS5yb2xlLkxpc3RSZXF1ZXN0GhkuaWFtLnYxLnJvbGUuTGlzdFJlc3BvbnNlIgASOgoDR2V0EhcuaWFtLnYxLnJvbGUuR2V0UmVxdWVzdBoYLmlhbS52MS5yb2xlLkdldFJlc3BvbnNlIgASQwoGQ3JlYXRlEhouaWFtLnYxLnJvbGUuQ3JlYXRlUmVxdWVzdBobLmlhbS52MS5yb2xlLkNyZWF0ZVJlc3BvbnNlIgASQwoGVXBkYXRlEhouaWFtLnYxLnJvbGUuVXBkYXRlUmVxdWVzdBobLmlhbS52MS5yb2xlLlVwZGF0ZVJlc3BvbnNlIgASQwoGRGVsZXRlEhouaWFtLnYxLnJvbGUuRGVsZXRlUmVxdWVzdBobLmlhbS52MS5yb2xlLkRlbGV0ZVJlc3BvbnNlIgASVQoMTGlzdEJpbmRpbmdzEiAuaWFtLnYxLnJvbGUuTGlzdEJpbmRpbmdzUmVxdWVzdBohLmlhbS52MS5yb2xlLkxpc3RCaW5kaW5nc1Jlc3BvbnNlIgASWAoNQ3JlYXRlQmluZGluZxIhLmlhbS52MS5yb2xlLkNyZWF0ZUJpbmRpbmdSZXF1ZXN0GiIuaWFtLnYxLnJvbGUuQ3JlYXRlQmluZGluZ1Jlc3BvbnNlIgASWAoNRGVsZXRlQmluZGluZxIhLmlhbS52MS5yb2xlLkRlbGV0ZUJpbmRpbmdSZXF1ZXN0GiIuaWFtLnYxLnJvbGUuRGVsZXRlQmluZGluZ1Jlc3BvbnNlIgBCLlosZ2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvaWFtL3YxL3JvbGViBnByb3RvMw", [file_iam_v1_role_message]);
+
+/**
+ * @generated from service iam.v1.role.RoleService
+ */
+export const RoleService: GenService<{
+  /**
+   * @generated from rpc iam.v1.role.RoleService.List
+   */
+  list: {
+    methodKind: "unary";
+    input: typeof ListRequestSchema;
+    output: typeof ListResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.role.RoleService.Get
+   */
+  get: {
+    methodKind: "unary";
+    input: typeof GetRequestSchema;
+    output: typeof GetResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.role.RoleService.Create
+   */
+  create: {
+    methodKind: "unary";
+    input: typeof CreateRequestSchema;
+    output: typeof CreateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.role.RoleService.Update
+   */
+  update: {
+    methodKind: "unary";
+    input: typeof UpdateRequestSchema;
+    output: typeof UpdateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.role.RoleService.Delete
+   */
+  delete: {
+    methodKind: "unary";
+    input: typeof DeleteRequestSchema;
+    output: typeof DeleteResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.role.RoleService.ListBindings
+   */
+  listBindings: {
+    methodKind: "unary";
+    input: typeof ListBindingsRequestSchema;
+    output: typeof ListBindingsResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.role.RoleService.CreateBinding
+   */
+  createBinding: {
+    methodKind: "unary";
+    input: typeof CreateBindingRequestSchema;
+    output: typeof CreateBindingResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.role.RoleService.DeleteBinding
+   */
+  deleteBinding: {
+    methodKind: "unary";
+    input: typeof DeleteBindingRequestSchema;
+    output: typeof DeleteBindingResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_iam_v1_role_service, 0);
+
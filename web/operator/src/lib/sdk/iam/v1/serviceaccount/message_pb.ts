@@ -0,0 +1,408 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/serviceaccount/message.proto (package iam.v1.serviceaccount, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file iam/v1/serviceaccount/message.proto.
+ */
+export const file_iam_v1_serviceaccount_message: GenFile = /*@__PURE__*/
+  fileDesc("CiNpYW0vdjEvc2VydmljZWFjY291bnQvbWVzc2FnZS5wcm90bxIVaWFtLnYxLnNlcnZpY2VhY2NvdW50It8BCg5TZXJ2aWNlQWNjb3VudBI8CgRuYW1lGAEgASgJQi66SCvIAQFyJjIkXlthLXowLTldKFstYS16MC05XXswLDYxfVthLXowLTldKT8kEh0KC2Rlc2NyaXB0aW9uGAIgASgJQgi6SAVyAxiACBIQCghkaXNhYmxlZBgDIAEoCBIuCgpjcmVhdGVkX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCLyAgoGQXBpS2V5EgoKAmlkGAEgASgJEkcKD3NlcnZpY2VfYWNjb3VudBgCIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JBIdCgtkZXNjcmlwdGlvbhgDIAEoCUIIukgFcgMYgAgSOwoKcHJvY2VkdXJlcxgEIAMoCUInukgkkgEhIh9yHTIbXihcKnxbYS16Kj9dKzpbQS1aYS16Kj9dKykkEk0KCGNsdXN0ZXJzGAUgAygJQju6SDiSATUiM3IxMi9eKFstYS16MC05Kj9dfFxbXF4/KFthLXowLTldKC1bYS16MC05XSk/KStcXSkrJBI4CgpleHBpcmVzX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEIIukgFsgECQAESLgoKY3JlYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiQAoLTGlzdFJlcXVlc3QSHQoJcGFnZV9zaXplGAEgASgFQgq6SAcaBRjoBygAEhIKCnBhZ2VfdG9rZW4YAiABKAkiaAoMTGlzdFJlc3BvbnNlEj8KEHNlcnZpY2VfYWNjb3VudHMYASADKAsyJS5pYW0udjEuc2VydmljZWFjY291bnQuU2VydmljZUFjY291bnQSFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJIkoKCkdldFJlcXVlc3QSPAoEbmFtZRgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JCJNCgtHZXRSZXNwb25zZRI+Cg9zZXJ2aWNlX2FjY291bnQYASABKAsyJS5pYW0udjEuc2VydmljZWFjY291bnQuU2VydmljZUFjY291bnQiVwoNQ3JlYXRlUmVxdWVzdBJGCg9zZXJ2aWNlX2FjY291bnQYASABKAsyJS5pYW0udjEuc2VydmljZWFjY291bnQuU2VydmljZUFjY291bnRCBrpIA8gBASJQCg5DcmVhdGVSZXNwb25zZRI+Cg9zZXJ2aWNlX2FjY291bnQYASABKAsyJS5pYW0udjEuc2VydmljZWFjY291bnQuU2VydmljZUFjY291bnQiVwoNVXBkYXRlUmVxdWVzdBJGCg9zZXJ2aWNlX2FjY291bnQYASABKAsyJS5pYW0udjEuc2VydmljZWFjY291bnQuU2VydmljZUFjY291bnRCBrpIA8gBASJQCg5VcGRhdGVSZXNwb25zZRI+Cg9zZXJ2aWNlX2FjY291bnQYASABKAsyJS5pYW0udjEuc2VydmljZWFjY291bnQuU2VydmljZUFjY291bnQiTQoNRGVsZXRlUmVxdWVzdBI8CgRuYW1lGAEgASgJQi66SCvIAQFyJjIkXlthLXowLTldKFstYS16MC05XXswLDYxfVthLXowLTldKT8kIhAKDkRlbGV0ZVJlc3BvbnNlIkYKEENyZWF0ZUtleVJlcXVlc3QSMgoDa2V5GAEgASgLMh0uaWFtLnYxLnNlcnZpY2VhY2NvdW50LkFwaUtleUIGukgDyAEBIk8KEUNyZWF0ZUtleVJlc3BvbnNlEioKA2tleRgBIAEoCzIdLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5BcGlLZXkSDgoGc2VjcmV0GAIgASgJIloKD0xpc3RLZXlzUmVxdWVzdBJHCg9zZXJ2aWNlX2FjY291bnQYASABKAlCLrpIK8gBAXImMiReW2EtejAtOV0oWy1hLXowLTldezAsNjF9W2EtejAtOV0pPyQiPwoQTGlzdEtleXNSZXNwb25zZRIrCgRrZXlzGAEgAygLMh0uaWFtLnYxLnNlcnZpY2VhY2NvdW50LkFwaUtleSImChBSZXZva2VLZXlSZXF1ZXN0EhIKAmlkGAEgASgJQga6SAPIAQEiEwoRUmV2b2tlS2V5UmVzcG9uc2VCOFo2Z2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvaWFtL3YxL3NlcnZpY2VhY2NvdW50YgZwcm90bzM", [file_buf_validate_validate, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message iam.v1.serviceaccount.ServiceAccount
+ */
+export type ServiceAccount = Message<"iam.v1.serviceaccount.ServiceAccount"> & {
+  /**
+   * authenticates as subject "serviceaccount:<name>", which is used in role bindings
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * keys of disabled service accounts are rejected
+   *
+   * @generated from field: bool disabled = 3;
+   */
+  disabled: boolean;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 5;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.ServiceAccount.
+ * Use `create(ServiceAccountSchema)` to create a new message.
+ */
+export const ServiceAccountSchema: GenMessage<ServiceAccount> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 0);
+
+/**
+ * @generated from message iam.v1.serviceaccount.ApiKey
+ */
+export type ApiKey = Message<"iam.v1.serviceaccount.ApiKey"> & {
+  /**
+   * public part of the key ("miam_<id>_<secret>"), the secret is only stored as hash
+   *
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string service_account = 2;
+   */
+  serviceAccount: string;
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description: string;
+
+  /**
+   * restricts the key to actions matching these patterns (e.g. "cluster:Update"), empty allows all
+   *
+   * @generated from field: repeated string procedures = 4;
+   */
+  procedures: string[];
+
+  /**
+   * restricts the key to clusters matching these name patterns, empty allows all
+   *
+   * @generated from field: repeated string clusters = 5;
+   */
+  clusters: string[];
+
+  /**
+   * the key never expires if unset
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 6;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.ApiKey.
+ * Use `create(ApiKeySchema)` to create a new message.
+ */
+export const ApiKeySchema: GenMessage<ApiKey> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 1);
+
+/**
+ * @generated from message iam.v1.serviceaccount.ListRequest
+ */
+export type ListRequest = Message<"iam.v1.serviceaccount.ListRequest"> & {
+  /**
+   * maximum number of service accounts returned (defaults to 100)
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token of the previous page
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.ListRequest.
+ * Use `create(ListRequestSchema)` to create a new message.
+ */
+export const ListRequestSchema: GenMessage<ListRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 2);
+
+/**
+ * @generated from message iam.v1.serviceaccount.ListResponse
+ */
+export type ListResponse = Message<"iam.v1.serviceaccount.ListResponse"> & {
+  /**
+   * returns service accounts ordered by name
+   *
+   * @generated from field: repeated iam.v1.serviceaccount.ServiceAccount service_accounts = 1;
+   */
+  serviceAccounts: ServiceAccount[];
+
+  /**
+   * empty if there are no more pages
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.ListResponse.
+ * Use `create(ListResponseSchema)` to create a new message.
+ */
+export const ListResponseSchema: GenMessage<ListResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 3);
+
+/**
+ * @generated from message iam.v1.serviceaccount.GetRequest
+ */
+export type GetRequest = Message<"iam.v1.serviceaccount.GetRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.GetRequest.
+ * Use `create(GetRequestSchema)` to create a new message.
+ */
+export const GetRequestSchema: GenMessage<GetRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 4);
+
+/**
+ * @generated from message iam.v1.serviceaccount.GetResponse
+ */
+export type GetResponse = Message<"iam.v1.serviceaccount.GetResponse"> & {
+  /**
+   * @generated from field: iam.v1.serviceaccount.ServiceAccount service_account = 1;
+   */
+  serviceAccount?: ServiceAccount;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.GetResponse.
+ * Use `create(GetResponseSchema)` to create a new message.
+ */
+export const GetResponseSchema: GenMessage<GetResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 5);
+
+/**
+ * @generated from message iam.v1.serviceaccount.CreateRequest
+ */
+export type CreateRequest = Message<"iam.v1.serviceaccount.CreateRequest"> & {
+  /**
+   * @generated from field: iam.v1.serviceaccount.ServiceAccount service_account = 1;
+   */
+  serviceAccount?: ServiceAccount;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.CreateRequest.
+ * Use `create(CreateRequestSchema)` to create a new message.
+ */
+export const CreateRequestSchema: GenMessage<CreateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 6);
+
+/**
+ * @generated from message iam.v1.serviceaccount.CreateResponse
+ */
+export type CreateResponse = Message<"iam.v1.serviceaccount.CreateResponse"> & {
+  /**
+   * @generated from field: iam.v1.serviceaccount.ServiceAccount service_account = 1;
+   */
+  serviceAccount?: ServiceAccount;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.CreateResponse.
+ * Use `create(CreateResponseSchema)` to create a new message.
+ */
+export const CreateResponseSchema: GenMessage<CreateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 7);
+
+/**
+ * @generated from message iam.v1.serviceaccount.UpdateRequest
+ */
+export type UpdateRequest = Message<"iam.v1.serviceaccount.UpdateRequest"> & {
+  /**
+   * @generated from field: iam.v1.serviceaccount.ServiceAccount service_account = 1;
+   */
+  serviceAccount?: ServiceAccount;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.UpdateRequest.
+ * Use `create(UpdateRequestSchema)` to create a new message.
+ */
+export const UpdateRequestSchema: GenMessage<UpdateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 8);
+
+/**
+ * @generated from message iam.v1.serviceaccount.UpdateResponse
+ */
+export type UpdateResponse = Message<"iam.v1.serviceaccount.UpdateResponse"> & {
+  /**
+   * @generated from field: iam.v1.serviceaccount.ServiceAccount service_account = 1;
+   */
+  serviceAccount?: ServiceAccount;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.UpdateResponse.
+ * Use `create(UpdateResponseSchema)` to create a new message.
+ */
+export const UpdateResponseSchema: GenMessage<UpdateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 9);
+
+/**
+ * @generated from message iam.v1.serviceaccount.DeleteRequest
+ */
+export type DeleteRequest = Message<"iam.v1.serviceaccount.DeleteRequest"> & {
+  /**
+   * also revokes all keys of the service account
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.DeleteRequest.
+ * Use `create(DeleteRequestSchema)` to create a new message.
+ */
+export const DeleteRequestSchema: GenMessage<DeleteRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 10);
+
+/**
+ * @generated from message iam.v1.serviceaccount.DeleteResponse
+ */
+export type DeleteResponse = Message<"iam.v1.serviceaccount.DeleteResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.DeleteResponse.
+ * Use `create(DeleteResponseSchema)` to create a new message.
+ */
+export const DeleteResponseSchema: GenMessage<DeleteResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 11);
+
+/**
+ * @generated from message iam.v1.serviceaccount.CreateKeyRequest
+ */
+export type CreateKeyRequest = Message<"iam.v1.serviceaccount.CreateKeyRequest"> & {
+  /**
+   * id and created_at are generated
+   *
+   * @generated from field: iam.v1.serviceaccount.ApiKey key = 1;
+   */
+  key?: ApiKey;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.CreateKeyRequest.
+ * Use `create(CreateKeyRequestSchema)` to create a new message.
+ */
+export const CreateKeyRequestSchema: GenMessage<CreateKeyRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 12);
+
+/**
+ * @generated from message iam.v1.serviceaccount.CreateKeyResponse
+ */
+export type CreateKeyResponse = Message<"iam.v1.serviceaccount.CreateKeyResponse"> & {
+  /**
+   * @generated from field: iam.v1.serviceaccount.ApiKey key = 1;
+   */
+  key?: ApiKey;
+
+  /**
+   * full api key, it is only returned once and cannot be recovered
+   *
+   * @generated from field: string secret = 2;
+   */
+  secret: string;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.CreateKeyResponse.
+ * Use `create(CreateKeyResponseSchema)` to create a new message.
+ */
+export const CreateKeyResponseSchema: GenMessage<CreateKeyResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 13);
+
+/**
+ * @generated from message iam.v1.serviceaccount.ListKeysRequest
+ */
+export type ListKeysRequest = Message<"iam.v1.serviceaccount.ListKeysRequest"> & {
+  /**
+   * @generated from field: string service_account = 1;
+   */
+  serviceAccount: string;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.ListKeysRequest.
+ * Use `create(ListKeysRequestSchema)` to create a new message.
+ */
+export const ListKeysRequestSchema: GenMessage<ListKeysRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 14);
+
+/**
+ * @generated from message iam.v1.serviceaccount.ListKeysResponse
+ */
+export type ListKeysResponse = Message<"iam.v1.serviceaccount.ListKeysResponse"> & {
+  /**
+   * returns the keys of the service account ordered by id
+   *
+   * @generated from field: repeated iam.v1.serviceaccount.ApiKey keys = 1;
+   */
+  keys: ApiKey[];
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.ListKeysResponse.
+ * Use `create(ListKeysResponseSchema)` to create a new message.
+ */
+export const ListKeysResponseSchema: GenMessage<ListKeysResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 15);
+
+/**
+ * @generated from message iam.v1.serviceaccount.RevokeKeyRequest
+ */
+export type RevokeKeyRequest = Message<"iam.v1.serviceaccount.RevokeKeyRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.RevokeKeyRequest.
+ * Use `create(RevokeKeyRequestSchema)` to create a new message.
+ */
+export const RevokeKeyRequestSchema: GenMessage<RevokeKeyRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 16);
+
+/**
+ * @generated from message iam.v1.serviceaccount.RevokeKeyResponse
+ */
+export type RevokeKeyResponse = Message<"iam.v1.serviceaccount.RevokeKeyResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.serviceaccount.RevokeKeyResponse.
+ * Use `create(RevokeKeyResponseSchema)` to create a new message.
+ */
+export const RevokeKeyResponseSchema: GenMessage<RevokeKeyResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_serviceaccount_message, 17);
+
@@ -0,0 +1,86 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/serviceaccount/service.proto (package iam.v1.serviceaccount, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { CreateKeyRequestSchema, CreateKeyResponseSchema, CreateRequestSchema, CreateResponseSchema, DeleteRequestSchema, DeleteResponseSchema, GetRequestSchema, GetResponseSchema, ListKeysRequestSchema, ListKeysResponseSchema, ListRequestSchema, ListResponseSchema, RevokeKeyRequestSchema, RevokeKeyResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
+import { file_iam_v1_serviceaccount_message } from "./message_pb";
+
+/**
+ * Describes the file iam/v1/serviceaccount/service.proto.
+ */
+export const file_iam_v1_serviceaccount_service: GenFile = /*@__PURE__*/
+  fileDesc("CiNpYW0vdjEvc2VydmljZWFjY291bnQvc2VydmljZS5wcm90bxIVaWFtLnYxLnNlcnZpY2VhY2NvdW50MugFChVTZXJ2aWNlQWNjb3VudFNlcnZpY2USUQoETGlzdBIiLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5MaXN0UmVxdWVzdBojLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5MaXN0UmVzcG9uc2UiABJOCgNHZXQSIS5pYW0udjEuc2VydmljZWFjY291bnQuR2V0UmVxdWVzdBoiLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5HZXRSZXNwb25zZSIAElcKBkNyZWF0ZRIkLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5DcmVhdGVSZXF1ZXN0GiUuaWFtLnYxLnNlcnZpY2VhY2NvdW50LkNyZWF0ZVJlc3BvbnNlIgASVwoGVXBkYXRlEiQuaWFtLnYxLnNlcnZpY2VhY2NvdW50LlVwZGF0ZVJlcXVlc3QaJS5pYW0udjEuc2VydmljZWFjY291bnQuVXBkYXRlUmVzcG9uc2UiABJXCgZEZWxldGUSJC5pYW0udjEuc2VydmljZWFjY291bnQuRGVsZXRlUmVxdWVzdBolLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5EZWxldGVSZXNwb25zZSIAEmAKCUNyZWF0ZUtleRInLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5DcmVhdGVLZXlSZXF1ZXN0GiguaWFtLnYxLnNlcnZpY2VhY2NvdW50LkNyZWF0ZUtleVJlc3BvbnNlIgASXQoITGlzdEtleXMSJi5pYW0udjEuc2VydmljZWFjY291bnQuTGlzdEtleXNSZXF1ZXN0GicuaWFtLnYxLnNlcnZpY2VhY2NvdW50Lkxpc3RLZXlzUmVzcG9uc2UiABJgCglSZXZva2VLZXkSJy5pYW0udjEuc2VydmljZWFjY291bnQuUmV2b2tlS2V5UmVxdWVzdBooLmlhbS52MS5zZXJ2aWNlYWNjb3VudC5SZXZva2VLZXlSZXNwb25zZSIAQjhaNmdpdGh1Yi5jb20vbWVnYWt1dWwvbWlhbS9wa2cvYXBpL2lhbS92MS9zZXJ2aWNlYWNjb3VudGIGcHJvdG8z", [file_iam_v1_serviceaccount_message]);
+
+/**
+ * @generated from service iam.v1.serviceaccount.ServiceAccountService
+ */
+export const ServiceAccountService: GenService<{
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.List
+   */
+  list: {
+    methodKind: "unary";
+    input: typeof ListRequestSchema;
+    output: typeof ListResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.Get
+   */
+  get: {
+    methodKind: "unary";
+    input: typeof GetRequestSchema;
+    output: typeof GetResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.Create
+   */
+  create: {
+    methodKind: "unary";
+    input: typeof CreateRequestSchema;
+    output: typeof CreateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.Update
+   */
+  update: {
+    methodKind: "unary";
+    input: typeof UpdateRequestSchema;
+    output: typeof UpdateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.Delete
+   */
+  delete: {
+    methodKind: "unary";
+    input: typeof DeleteRequestSchema;
+    output: typeof DeleteResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.CreateKey
+   */
+  createKey: {
+    methodKind: "unary";
+    input: typeof CreateKeyRequestSchema;
+    output: typeof CreateKeyResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.ListKeys
+   */
+  listKeys: {
+    methodKind: "unary";
+    input: typeof ListKeysRequestSchema;
+    output: typeof ListKeysResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.serviceaccount.ServiceAccountService.RevokeKey
+   */
+  revokeKey: {
+    methodKind: "unary";
+    input: typeof RevokeKeyRequestSchema;
+    output: typeof RevokeKeyResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_iam_v1_serviceaccount_service, 0);
+
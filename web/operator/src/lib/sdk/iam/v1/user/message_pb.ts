@@ -0,0 +1,262 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/user/message.proto (package iam.v1.user, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file iam/v1/user/message.proto.
+ */
+export const file_iam_v1_user_message: GenFile = /*@__PURE__*/
+  fileDesc("ChlpYW0vdjEvdXNlci9tZXNzYWdlLnByb3RvEgtpYW0udjEudXNlciKYAgoEVXNlchI1CgRuYW1lGAEgASgJQie6SCTIAQFyHzIdXltBLVphLXowLTkuX0ArPSw6Ly1dezEsMjU2fSQSHgoMZGlzcGxheV9uYW1lGAIgASgJQgi6SAVyAxiAAhIZCgVlbWFpbBgDIAEoCUIKukgH2AEBcgJgARIQCghkaXNhYmxlZBgEIAEoCBIsCgl1cHN0cmVhbXMYByADKAlCGbpIFpIBEyIRcg8yDV5bXi86XXsxLDYzfSQSLgoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKdXBkYXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiQAoLTGlzdFJlcXVlc3QSHQoJcGFnZV9zaXplGAEgASgFQgq6SAcaBRjoBygAEhIKCnBhZ2VfdG9rZW4YAiABKAkiSQoMTGlzdFJlc3BvbnNlEiAKBXVzZXJzGAEgAygLMhEuaWFtLnYxLnVzZXIuVXNlchIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiQwoKR2V0UmVxdWVzdBI1CgRuYW1lGAEgASgJQie6SCTIAQFyHzIdXltBLVphLXowLTkuX0ArPSw6Ly1dezEsMjU2fSQiPgoLR2V0UmVzcG9uc2USHwoEdXNlchgBIAEoCzIRLmlhbS52MS51c2VyLlVzZXISDgoGZ3JvdXBzGAIgAygJIjgKDUNyZWF0ZVJlcXVlc3QSJwoEdXNlchgBIAEoCzIRLmlhbS52MS51c2VyLlVzZXJCBrpIA8gBASIxCg5DcmVhdGVSZXNwb25zZRIfCgR1c2VyGAEgASgLMhEuaWFtLnYxLnVzZXIuVXNlciI4Cg1VcGRhdGVSZXF1ZXN0EicKBHVzZXIYASABKAsyES5pYW0udjEudXNlci5Vc2VyQga6SAPIAQEiMQoOVXBkYXRlUmVzcG9uc2USHwoEdXNlchgBIAEoCzIRLmlhbS52MS51c2VyLlVzZXIiRgoNRGVsZXRlUmVxdWVzdBI1CgRuYW1lGAEgASgJQie6SCTIAQFyHzIdXltBLVphLXowLTkuX0ArPSw6Ly1dezEsMjU2fSQiEAoORGVsZXRlUmVzcG9uc2VCLlosZ2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvaWFtL3YxL3VzZXJiBnByb3RvMw", [file_buf_validate_validate, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message iam.v1.user.User
+ */
+export type User = Message<"iam.v1.user.User"> & {
+  /**
+   * unique user name, matched against the name of authenticated identities
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string display_name = 2;
+   */
+  displayName: string;
+
+  /**
+   * @generated from field: string email = 3;
+   */
+  email: string;
+
+  /**
+   * disabled users are denied all procedures
+   *
+   * @generated from field: bool disabled = 4;
+   */
+  disabled: boolean;
+
+  /**
+   * upstreams the user signs in through, set by jit provisioning. Logins through other upstreams are refused
+   * until an admin links the upstream to the user.
+   *
+   * @generated from field: repeated string upstreams = 7;
+   */
+  upstreams: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 5;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 6;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message iam.v1.user.User.
+ * Use `create(UserSchema)` to create a new message.
+ */
+export const UserSchema: GenMessage<User> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 0);
+
+/**
+ * @generated from message iam.v1.user.ListRequest
+ */
+export type ListRequest = Message<"iam.v1.user.ListRequest"> & {
+  /**
+   * maximum number of users returned (defaults to 100)
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token of the previous page
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message iam.v1.user.ListRequest.
+ * Use `create(ListRequestSchema)` to create a new message.
+ */
+export const ListRequestSchema: GenMessage<ListRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 1);
+
+/**
+ * @generated from message iam.v1.user.ListResponse
+ */
+export type ListResponse = Message<"iam.v1.user.ListResponse"> & {
+  /**
+   * returns users ordered by name
+   *
+   * @generated from field: repeated iam.v1.user.User users = 1;
+   */
+  users: User[];
+
+  /**
+   * empty if there are no more pages
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message iam.v1.user.ListResponse.
+ * Use `create(ListResponseSchema)` to create a new message.
+ */
+export const ListResponseSchema: GenMessage<ListResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 2);
+
+/**
+ * @generated from message iam.v1.user.GetRequest
+ */
+export type GetRequest = Message<"iam.v1.user.GetRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.user.GetRequest.
+ * Use `create(GetRequestSchema)` to create a new message.
+ */
+export const GetRequestSchema: GenMessage<GetRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 3);
+
+/**
+ * @generated from message iam.v1.user.GetResponse
+ */
+export type GetResponse = Message<"iam.v1.user.GetResponse"> & {
+  /**
+   * @generated from field: iam.v1.user.User user = 1;
+   */
+  user?: User;
+
+  /**
+   * returns the groups the user is a member of
+   *
+   * @generated from field: repeated string groups = 2;
+   */
+  groups: string[];
+};
+
+/**
+ * Describes the message iam.v1.user.GetResponse.
+ * Use `create(GetResponseSchema)` to create a new message.
+ */
+export const GetResponseSchema: GenMessage<GetResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 4);
+
+/**
+ * @generated from message iam.v1.user.CreateRequest
+ */
+export type CreateRequest = Message<"iam.v1.user.CreateRequest"> & {
+  /**
+   * @generated from field: iam.v1.user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message iam.v1.user.CreateRequest.
+ * Use `create(CreateRequestSchema)` to create a new message.
+ */
+export const CreateRequestSchema: GenMessage<CreateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 5);
+
+/**
+ * @generated from message iam.v1.user.CreateResponse
+ */
+export type CreateResponse = Message<"iam.v1.user.CreateResponse"> & {
+  /**
+   * @generated from field: iam.v1.user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message iam.v1.user.CreateResponse.
+ * Use `create(CreateResponseSchema)` to create a new message.
+ */
+export const CreateResponseSchema: GenMessage<CreateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 6);
+
+/**
+ * @generated from message iam.v1.user.UpdateRequest
+ */
+export type UpdateRequest = Message<"iam.v1.user.UpdateRequest"> & {
+  /**
+   * @generated from field: iam.v1.user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message iam.v1.user.UpdateRequest.
+ * Use `create(UpdateRequestSchema)` to create a new message.
+ */
+export const UpdateRequestSchema: GenMessage<UpdateRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 7);
+
+/**
+ * @generated from message iam.v1.user.UpdateResponse
+ */
+export type UpdateResponse = Message<"iam.v1.user.UpdateResponse"> & {
+  /**
+   * @generated from field: iam.v1.user.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message iam.v1.user.UpdateResponse.
+ * Use `create(UpdateResponseSchema)` to create a new message.
+ */
+export const UpdateResponseSchema: GenMessage<UpdateResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 8);
+
+/**
+ * @generated from message iam.v1.user.DeleteRequest
+ */
+export type DeleteRequest = Message<"iam.v1.user.DeleteRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message iam.v1.user.DeleteRequest.
+ * Use `create(DeleteRequestSchema)` to create a new message.
+ */
+export const DeleteRequestSchema: GenMessage<DeleteRequest> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 9);
+
+/**
+ * @generated from message iam.v1.user.DeleteResponse
+ */
+export type DeleteResponse = Message<"iam.v1.user.DeleteResponse"> & {
+};
+
+/**
+ * Describes the message iam.v1.user.DeleteResponse.
+ * Use `create(DeleteResponseSchema)` to create a new message.
+ */
+export const DeleteResponseSchema: GenMessage<DeleteResponse> = /*@__PURE__*/
+  messageDesc(file_iam_v1_user_message, 10);
+
@@ -0,0 +1,62 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file iam/v1/user/service.proto (package iam.v1.user, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { CreateRequestSchema, CreateResponseSchema, DeleteRequestSchema, DeleteResponseSchema, GetRequestSchema, GetResponseSchema, ListRequestSchema, ListResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
+import { file_iam_v1_user_message } from "./message_pb";
+
+/**
+ * Describes the file iam/v1/user/service.proto.
+ */
+export const file_iam_v1_user_service: GenFile = /*@__PURE__*/
+  fileDesc("ChlpYW0vdjEvdXNlci9zZXJ2aWNlLnByb3RvEgtpYW0udjEudXNlcjLXAgoLVXNlclNlcnZpY2USPQoETGlzdBIYLmlhbS52MS51c2VyLkxpc3RSZXF1ZXN0GhkuaWFtLnYxLnVzZXIuTGlzdFJlc3BvbnNlIgASOgoDR2V0EhcuaWFtLnYxLnVzZXIuR2V0UmVxdWVzdBoYLmlhbS52MS51c2VyLkdldFJlc3BvbnNlIgASQwoGQ3JlYXRlEhouaWFtLnYxLnVzZXIuQ3JlYXRlUmVxdWVzdBobLmlhbS52MS51c2VyLkNyZWF0ZVJlc3BvbnNlIgASQwoGVXBkYXRlEhouaWFtLnYxLnVzZXIuVXBkYXRlUmVxdWVzdBobLmlhbS52MS51c2VyLlVwZGF0ZVJlc3BvbnNlIgASQwoGRGVsZXRlEhouaWFtLnYxLnVzZXIuRGVsZXRlUmVxdWVzdBobLmlhbS52MS51c2VyLkRlbGV0ZVJlc3BvbnNlIgBCLlosZ2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvaWFtL3YxL3VzZXJiBnByb3RvMw", [file_iam_v1_user_message]);
+
+/**
+ * @generated from service iam.v1.user.UserService
+ */
+export const UserService: GenService<{
+  /**
+   * @generated from rpc iam.v1.user.UserService.List
+   */
+  list: {
+    methodKind: "unary";
+    input: typeof ListRequestSchema;
+    output: typeof ListResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.user.UserService.Get
+   */
+  get: {
+    methodKind: "unary";
+    input: typeof GetRequestSchema;
+    output: typeof GetResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.user.UserService.Create
+   */
+  create: {
+    methodKind: "unary";
+    input: typeof CreateRequestSchema;
+    output: typeof CreateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.user.UserService.Update
+   */
+  update: {
+    methodKind: "unary";
+    input: typeof UpdateRequestSchema;
+    output: typeof UpdateResponseSchema;
+  },
+  /**
+   * @generated from rpc iam.v1.user.UserService.Delete
+   */
+  delete: {
+    methodKind: "unary";
+    input: typeof DeleteRequestSchema;
+    output: typeof DeleteResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_iam_v1_user_service, 0);
+
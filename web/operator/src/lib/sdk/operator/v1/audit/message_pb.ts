@@ -0,0 +1,145 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file operator/v1/audit/message.proto (package operator.v1.audit, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file operator/v1/audit/message.proto.
+ */
+export const file_operator_v1_audit_message: GenFile = /*@__PURE__*/
+  fileDesc("Ch9vcGVyYXRvci92MS9hdWRpdC9tZXNzYWdlLnByb3RvEhFvcGVyYXRvci52MS5hdWRpdCKcAQoKQXVkaXRFbnRyeRIKCgJpZBgBIAEoCRIoCgR0aW1lGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBINCgVhY3RvchgDIAEoCRIRCglwcm9jZWR1cmUYBCABKAkSFgoOcmVxdWVzdF9kaWdlc3QYBSABKAkSDAoEY29kZRgGIAEoCRIQCghyZXZpc2lvbhgHIAEoCSLGAgoLTGlzdFJlcXVlc3QSDQoFYWN0b3IYASABKAkSLgoKc3RhcnRfdGltZRgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLAoIZW5kX3RpbWUYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEh0KCXBhZ2Vfc2l6ZRgEIAEoBUIKukgHGgUY6AcoABISCgpwYWdlX3Rva2VuGAUgASgJOpYBukiSARqPAQoZZW5kX3RpbWVfYWZ0ZXJfc3RhcnRfdGltZRIhZW5kX3RpbWUgbXVzdCBiZSBhZnRlciBzdGFydF90aW1lGk8haGFzKHRoaXMuc3RhcnRfdGltZSkgfHwgIWhhcyh0aGlzLmVuZF90aW1lKSB8fCB0aGlzLnN0YXJ0X3RpbWUgPCB0aGlzLmVuZF90aW1lIlcKDExpc3RSZXNwb25zZRIuCgdlbnRyaWVzGAEgAygLMh0ub3BlcmF0b3IudjEuYXVkaXQuQXVkaXRFbnRyeRIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAlCNFoyZ2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvb3BlcmF0b3IvdjEvYXVkaXRiBnByb3RvMw", [file_buf_validate_validate, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message operator.v1.audit.AuditEntry
+ */
+export type AuditEntry = Message<"operator.v1.audit.AuditEntry"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp time = 2;
+   */
+  time?: Timestamp;
+
+  /**
+   * identity that issued the call
+   *
+   * @generated from field: string actor = 3;
+   */
+  actor: string;
+
+  /**
+   * @generated from field: string procedure = 4;
+   */
+  procedure: string;
+
+  /**
+   * hex encoded sha256 of the serialized request
+   *
+   * @generated from field: string request_digest = 5;
+   */
+  requestDigest: string;
+
+  /**
+   * result code of the call ("ok" on success)
+   *
+   * @generated from field: string code = 6;
+   */
+  code: string;
+
+  /**
+   * revision produced by the call (if any)
+   *
+   * @generated from field: string revision = 7;
+   */
+  revision: string;
+};
+
+/**
+ * Describes the message operator.v1.audit.AuditEntry.
+ * Use `create(AuditEntrySchema)` to create a new message.
+ */
+export const AuditEntrySchema: GenMessage<AuditEntry> = /*@__PURE__*/
+  messageDesc(file_operator_v1_audit_message, 0);
+
+/**
+ * @generated from message operator.v1.audit.ListRequest
+ */
+export type ListRequest = Message<"operator.v1.audit.ListRequest"> & {
+  /**
+   * only returns entries of this actor
+   *
+   * @generated from field: string actor = 1;
+   */
+  actor: string;
+
+  /**
+   * only returns entries recorded at or after this time
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 2;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * only returns entries recorded before this time
+   *
+   * @generated from field: google.protobuf.Timestamp end_time = 3;
+   */
+  endTime?: Timestamp;
+
+  /**
+   * maximum number of entries returned (defaults to 100)
+   *
+   * @generated from field: int32 page_size = 4;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token of the previous page
+   *
+   * @generated from field: string page_token = 5;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message operator.v1.audit.ListRequest.
+ * Use `create(ListRequestSchema)` to create a new message.
+ */
+export const ListRequestSchema: GenMessage<ListRequest> = /*@__PURE__*/
+  messageDesc(file_operator_v1_audit_message, 1);
+
+/**
+ * @generated from message operator.v1.audit.ListResponse
+ */
+export type ListResponse = Message<"operator.v1.audit.ListResponse"> & {
+  /**
+   * returns matching entries ordered from newest to oldest
+   *
+   * @generated from field: repeated operator.v1.audit.AuditEntry entries = 1;
+   */
+  entries: AuditEntry[];
+
+  /**
+   * empty if there are no more pages
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message operator.v1.audit.ListResponse.
+ * Use `create(ListResponseSchema)` to create a new message.
+ */
+export const ListResponseSchema: GenMessage<ListResponse> = /*@__PURE__*/
+  messageDesc(file_operator_v1_audit_message, 2);
+
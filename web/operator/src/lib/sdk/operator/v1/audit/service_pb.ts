@@ -0,0 +1,30 @@
+// @generated by protoc-gen-es v2.7.0 with parameter "target=ts"
+// @generated from file operator/v1/audit/service.proto (package operator.v1.audit, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { ListRequestSchema, ListResponseSchema } from "./message_pb";
+import { file_operator_v1_audit_message } from "./message_pb";
+
+/**
+ * Describes the file operator/v1/audit/service.proto.
+ */
+export const file_operator_v1_audit_service: GenFile = /*@__PURE__*/
+  fileDesc("Ch9vcGVyYXRvci92MS9hdWRpdC9zZXJ2aWNlLnByb3RvEhFvcGVyYXRvci52MS5hdWRpdDJZCgxBdWRpdFNlcnZpY2USSQoETGlzdBIeLm9wZXJhdG9yLnYxLmF1ZGl0Lkxpc3RSZXF1ZXN0Gh8ub3BlcmF0b3IudjEuYXVkaXQuTGlzdFJlc3BvbnNlIgBCNFoyZ2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvb3BlcmF0b3IvdjEvYXVkaXRiBnByb3RvMw", [file_operator_v1_audit_message]);
+
+/**
+ * @generated from service operator.v1.audit.AuditService
+ */
+export const AuditService: GenService<{
+  /**
+   * @generated from rpc operator.v1.audit.AuditService.List
+   */
+  list: {
+    methodKind: "unary";
+    input: typeof ListRequestSchema;
+    output: typeof ListResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_operator_v1_audit_service, 0);
+
@@ -4,13 +4,16 @@
 
 import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file operator/v1/cluster/message.proto.
  */
 export const file_operator_v1_cluster_message: GenFile = /*@__PURE__*/
-  fileDesc("CiFvcGVyYXRvci92MS9jbHVzdGVyL21lc3NhZ2UucHJvdG8SE29wZXJhdG9yLnYxLmNsdXN0ZXIidwoNQ2x1c3RlclN0YXR1cxIMCgRuYW1lGAEgASgJEhAKCHJldmlzaW9uGAIgASgJEgwKBHRhZ3MYAyADKAkSKQoFc3RhdGUYBCABKA4yGi5vcGVyYXRvci52MS5jbHVzdGVyLlN0YXRlEg0KBWVycm9yGAUgASgJIqwBCg1DbHVzdGVyQ29uZmlnEhAKCHJlcG9fdXJsGAIgASgJEhAKCHJlcG9fcmVmGAMgASgJEjsKDmNvbnRyb2xfY29uZmlnGAQgASgLMiMub3BlcmF0b3IudjEuY2x1c3Rlci5JbnN0YW5jZUNvbmZpZxI6Cg13b3JrZXJfY29uZmlnGAUgASgLMiMub3BlcmF0b3IudjEuY2x1c3Rlci5JbnN0YW5jZUNvbmZpZyJECg5JbnN0YW5jZUNvbmZpZxIMCgR0eXBlGAEgASgJEhEKCW1pbl9zY2FsZRgCIAEoAxIRCgltYXhfc2NhbGUYAyABKAMiDQoLTGlzdFJlcXVlc3QiRAoMTGlzdFJlc3BvbnNlEjQKCGNsdXN0ZXJzGAEgAygLMiIub3BlcmF0b3IudjEuY2x1c3Rlci5DbHVzdGVyU3RhdHVzIhoKCkdldFJlcXVlc3QSDAoEbmFtZRgBIAEoCSJECgtHZXRSZXNwb25zZRI1CglyZXZpc2lvbnMYASADKAsyIi5vcGVyYXRvci52MS5jbHVzdGVyLkNsdXN0ZXJTdGF0dXMiMQoPRGVzY3JpYmVSZXF1ZXN0EgwKBG5hbWUYASABKAkSEAoIcmV2aXNpb24YAiABKAkiRgoQRGVzY3JpYmVSZXNwb25zZRIyCgZjb25maWcYASABKAsyIi5vcGVyYXRvci52MS5jbHVzdGVyLkNsdXN0ZXJDb25maWciQwoNVXBkYXRlUmVxdWVzdBIyCgZjb25maWcYASABKAsyIi5vcGVyYXRvci52MS5jbHVzdGVyLkNsdXN0ZXJDb25maWciIgoOVXBkYXRlUmVzcG9uc2USEAoIcmV2aXNpb24YASABKAkiHgoORGVzdHJveVJlcXVlc3QSDAoEbmFtZRgBIAEoCSIRCg9EZXN0cm95UmVzcG9uc2UqPAoFU3RhdGUSCgoGQUNUSVZFEAASDAoISU5BQ1RJVkUQARINCglERVBMT1lJTkcQAhIKCgZGQUlMRUQQA0I2WjRnaXRodWIuY29tL21lZ2FrdXVsL21pYW0vcGtnL2FwaS9vcGVyYXRvci92MS9jbHVzdGVyYgZwcm90bzM");
+  fileDesc("CiFvcGVyYXRvci92MS9jbHVzdGVyL21lc3NhZ2UucHJvdG8SE29wZXJhdG9yLnYxLmNsdXN0ZXIi/AEKDUNsdXN0ZXJTdGF0dXMSDAoEbmFtZRgBIAEoCRIQCghyZXZpc2lvbhgCIAEoCRIMCgR0YWdzGAMgAygJEikKBXN0YXRlGAQgASgOMhoub3BlcmF0b3IudjEuY2x1c3Rlci5TdGF0ZRINCgVlcnJvchgFIAEoCRIuCgpjcmVhdGVkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBISCgpjcmVhdGVkX2J5GAggASgJEg8KB21lc3NhZ2UYCSABKAkikAMKDUNsdXN0ZXJDb25maWcSPAoEbmFtZRgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JBJuCghyZXBvX3VybBgCIAEoCUJcukhZyAEBclQyUl4oKGh0dHBzP3xzc2h8Z2l0KTovL1teXHMvPyNdKyhbLz8jXVxTKik/fFtBLVphLXowLTkuXy1dK0BbQS1aYS16MC05Li1dKzpbXi9dXFMqKSQSEAoIcmVwb19yZWYYAyABKAkSQwoOY29udHJvbF9jb25maWcYBCABKAsyIy5vcGVyYXRvci52MS5jbHVzdGVyLkluc3RhbmNlQ29uZmlnQga6SAPIAQESQgoNd29ya2VyX2NvbmZpZxgFIAEoCzIjLm9wZXJhdG9yLnYxLmNsdXN0ZXIuSW5zdGFuY2VDb25maWdCBrpIA8gBARI2CgR0YWdzGAYgAygJQii6SCWSASIiIHIeMhxeW0EtWmEtejAtOV8uOi89K0AtXXsxLDEyOH0kIsUBCg5JbnN0YW5jZUNvbmZpZxIUCgR0eXBlGAEgASgJQga6SAPIAQESGgoJbWluX3NjYWxlGAIgASgDQge6SAQiAigAEhoKCW1heF9zY2FsZRgDIAEoA0IHukgEIgIoATplukhiGmAKF21pbl9zY2FsZV9sdGVfbWF4X3NjYWxlEiNtaW5fc2NhbGUgbXVzdCBub3QgZXhjZWVkIG1heF9zY2FsZRogdGhpcy5taW5fc2NhbGUgPD0gdGhpcy5tYXhfc2NhbGUi6gEKC0xpc3RSZXF1ZXN0EjYKBHRhZ3MYASADKAlCKLpIJZIBIiIgch4yHF5bQS1aYS16MC05Xy46Lz0rQC1dezEsMTI4fSQSKgoGc3RhdGVzGAIgAygOMhoub3BlcmF0b3IudjEuY2x1c3Rlci5TdGF0ZRIwCgtuYW1lX3ByZWZpeBgDIAEoCUIbukgY2AEBchMyEV5bLWEtejAtOV17MSw2M30kEhIKCmRlc2NlbmRpbmcYBCABKAgSHQoJcGFnZV9zaXplGAUgASgFQgq6SAcaBRjoBygAEhIKCnBhZ2VfdG9rZW4YBiABKAkiXQoMTGlzdFJlc3BvbnNlEjQKCGNsdXN0ZXJzGAEgAygLMiIub3BlcmF0b3IudjEuY2x1c3Rlci5DbHVzdGVyU3RhdHVzEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCSJKCgpHZXRSZXF1ZXN0EjwKBG5hbWUYASABKAlCLrpIK8gBAXImMiReW2EtejAtOV0oWy1hLXowLTldezAsNjF9W2EtejAtOV0pPyQiRAoLR2V0UmVzcG9uc2USNQoJcmV2aXNpb25zGAEgAygLMiIub3BlcmF0b3IudjEuY2x1c3Rlci5DbHVzdGVyU3RhdHVzImEKD0Rlc2NyaWJlUmVxdWVzdBI8CgRuYW1lGAEgASgJQi66SCvIAQFyJjIkXlthLXowLTldKFstYS16MC05XXswLDYxfVthLXowLTldKT8kEhAKCHJldmlzaW9uGAIgASgJIkYKEERlc2NyaWJlUmVzcG9uc2USMgoGY29uZmlnGAEgASgLMiIub3BlcmF0b3IudjEuY2x1c3Rlci5DbHVzdGVyQ29uZmlnImYKDVVwZGF0ZVJlcXVlc3QSOgoGY29uZmlnGAEgASgLMiIub3BlcmF0b3IudjEuY2x1c3Rlci5DbHVzdGVyQ29uZmlnQga6SAPIAQESGQoHbWVzc2FnZRgCIAEoCUIIukgFcgMYgAgiIgoOVXBkYXRlUmVzcG9uc2USEAoIcmV2aXNpb24YASABKAkiaQoORGVzdHJveVJlcXVlc3QSPAoEbmFtZRgBIAEoCUIuukgryAEBciYyJF5bYS16MC05XShbLWEtejAtOV17MCw2MX1bYS16MC05XSk/JBIZCgdtZXNzYWdlGAIgASgJQgi6SAVyAxiACCIjCg9EZXN0cm95UmVzcG9uc2USEAoIcmV2aXNpb24YASABKAkidgoNQ2FuY2VsUmVxdWVzdBI8CgRuYW1lGAEgASgJQi66SCvIAQFyJjIkXlthLXowLTldKFstYS16MC05XXswLDYxfVthLXowLTldKT8kEhgKCHJldmlzaW9uGAIgASgJQga6SAPIAQESDQoFZm9yY2UYAyABKAgiEAoOQ2FuY2VsUmVzcG9uc2UiTAoMUHJ1bmVSZXF1ZXN0EjwKBG5hbWUYASABKAlCLrpIK9gBAXImMiReW2EtejAtOV0oWy1hLXowLTldezAsNjF9W2EtejAtOV0pPyQiRgoNUHJ1bmVSZXNwb25zZRI1CglyZXZpc2lvbnMYASADKAsyIi5vcGVyYXRvci52MS5jbHVzdGVyLkNsdXN0ZXJTdGF0dXMqPAoFU3RhdGUSCgoGQUNUSVZFEAASDAoISU5BQ1RJVkUQARINCglERVBMT1lJTkcQAhIKCgZGQUlMRUQQA0I2WjRnaXRodWIuY29tL21lZ2FrdXVsL21pYW0vcGtnL2FwaS9vcGVyYXRvci92MS9jbHVzdGVyYgZwcm90bzM", [file_buf_validate_validate, file_google_protobuf_timestamp]);
 
 /**
  * @generated from message operator.v1.cluster.ClusterStatus
@@ -40,6 +43,32 @@ export type ClusterStatus = Message<"operator.v1.cluster.ClusterStatus"> & {
    * @generated from field: string error = 5;
    */
   error: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * time of the last state change
+   *
+   * @generated from field: google.protobuf.Timestamp updated_at = 7;
+   */
+  updatedAt?: Timestamp;
+
+  /**
+   * identity that requested the revision
+   *
+   * @generated from field: string created_by = 8;
+   */
+  createdBy: string;
+
+  /**
+   * change message provided by the author
+   *
+   * @generated from field: string message = 9;
+   */
+  message: string;
 };
 
 /**
@@ -53,6 +82,13 @@ export const ClusterStatusSchema: GenMessage<ClusterStatus> = /*@__PURE__*/
  * @generated from message operator.v1.cluster.ClusterConfig
  */
 export type ClusterConfig = Message<"operator.v1.cluster.ClusterConfig"> & {
+  /**
+   * unique cluster identity, used as stack name
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
   /**
    * @generated from field: string repo_url = 2;
    */
@@ -72,6 +108,11 @@ export type ClusterConfig = Message<"operator.v1.cluster.ClusterConfig"> & {
    * @generated from field: operator.v1.cluster.InstanceConfig worker_config = 5;
    */
   workerConfig?: InstanceConfig;
+
+  /**
+   * @generated from field: repeated string tags = 6;
+   */
+  tags: string[];
 };
 
 /**
@@ -112,6 +153,47 @@ export const InstanceConfigSchema: GenMessage<InstanceConfig> = /*@__PURE__*/
  * @generated from message operator.v1.cluster.ListRequest
  */
 export type ListRequest = Message<"operator.v1.cluster.ListRequest"> & {
+  /**
+   * only returns clusters carrying all of these tags
+   *
+   * @generated from field: repeated string tags = 1;
+   */
+  tags: string[];
+
+  /**
+   * only returns clusters in one of these states
+   *
+   * @generated from field: repeated operator.v1.cluster.State states = 2;
+   */
+  states: State[];
+
+  /**
+   * only returns clusters whose name starts with this prefix
+   *
+   * @generated from field: string name_prefix = 3;
+   */
+  namePrefix: string;
+
+  /**
+   * sorts clusters by name in descending instead of ascending order
+   *
+   * @generated from field: bool descending = 4;
+   */
+  descending: boolean;
+
+  /**
+   * maximum number of clusters returned (defaults to 100)
+   *
+   * @generated from field: int32 page_size = 5;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token of the previous page
+   *
+   * @generated from field: string page_token = 6;
+   */
+  pageToken: string;
 };
 
 /**
@@ -126,11 +208,18 @@ export const ListRequestSchema: GenMessage<ListRequest> = /*@__PURE__*/
  */
 export type ListResponse = Message<"operator.v1.cluster.ListResponse"> & {
   /**
-   * returns latest revision of all clusters
+   * returns latest revision of all matching clusters
    *
    * @generated from field: repeated operator.v1.cluster.ClusterStatus clusters = 1;
    */
   clusters: ClusterStatus[];
+
+  /**
+   * empty if there are no more pages
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
 };
 
 /**
@@ -162,7 +251,7 @@ export const GetRequestSchema: GenMessage<GetRequest> = /*@__PURE__*/
  */
 export type GetResponse = Message<"operator.v1.cluster.GetResponse"> & {
   /**
-   * returns all revisions of one cluster 
+   * returns all revisions of one cluster (newest first)
    *
    * @generated from field: repeated operator.v1.cluster.ClusterStatus revisions = 1;
    */
@@ -223,6 +312,13 @@ export type UpdateRequest = Message<"operator.v1.cluster.UpdateRequest"> & {
    * @generated from field: operator.v1.cluster.ClusterConfig config = 1;
    */
   config?: ClusterConfig;
+
+  /**
+   * describes the change, recorded on the revision
+   *
+   * @generated from field: string message = 2;
+   */
+  message: string;
 };
 
 /**
@@ -257,6 +353,13 @@ export type DestroyRequest = Message<"operator.v1.cluster.DestroyRequest"> & {
    * @generated from field: string name = 1;
    */
   name: string;
+
+  /**
+   * describes the change, recorded on the revision
+   *
+   * @generated from field: string message = 2;
+   */
+  message: string;
 };
 
 /**
@@ -270,6 +373,10 @@ export const DestroyRequestSchema: GenMessage<DestroyRequest> = /*@__PURE__*/
  * @generated from message operator.v1.cluster.DestroyResponse
  */
 export type DestroyResponse = Message<"operator.v1.cluster.DestroyResponse"> & {
+  /**
+   * @generated from field: string revision = 1;
+   */
+  revision: string;
 };
 
 /**
@@ -279,6 +386,88 @@ export type DestroyResponse = Message<"operator.v1.cluster.DestroyResponse"> & {
 export const DestroyResponseSchema: GenMessage<DestroyResponse> = /*@__PURE__*/
   messageDesc(file_operator_v1_cluster_message, 12);
 
+/**
+ * @generated from message operator.v1.cluster.CancelRequest
+ */
+export type CancelRequest = Message<"operator.v1.cluster.CancelRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * revision that is currently deploying
+   *
+   * @generated from field: string revision = 2;
+   */
+  revision: string;
+
+  /**
+   * releases the revision even if another operator instance still holds its lease
+   *
+   * @generated from field: bool force = 3;
+   */
+  force: boolean;
+};
+
+/**
+ * Describes the message operator.v1.cluster.CancelRequest.
+ * Use `create(CancelRequestSchema)` to create a new message.
+ */
+export const CancelRequestSchema: GenMessage<CancelRequest> = /*@__PURE__*/
+  messageDesc(file_operator_v1_cluster_message, 13);
+
+/**
+ * @generated from message operator.v1.cluster.CancelResponse
+ */
+export type CancelResponse = Message<"operator.v1.cluster.CancelResponse"> & {
+};
+
+/**
+ * Describes the message operator.v1.cluster.CancelResponse.
+ * Use `create(CancelResponseSchema)` to create a new message.
+ */
+export const CancelResponseSchema: GenMessage<CancelResponse> = /*@__PURE__*/
+  messageDesc(file_operator_v1_cluster_message, 14);
+
+/**
+ * @generated from message operator.v1.cluster.PruneRequest
+ */
+export type PruneRequest = Message<"operator.v1.cluster.PruneRequest"> & {
+  /**
+   * prunes all clusters if empty
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message operator.v1.cluster.PruneRequest.
+ * Use `create(PruneRequestSchema)` to create a new message.
+ */
+export const PruneRequestSchema: GenMessage<PruneRequest> = /*@__PURE__*/
+  messageDesc(file_operator_v1_cluster_message, 15);
+
+/**
+ * @generated from message operator.v1.cluster.PruneResponse
+ */
+export type PruneResponse = Message<"operator.v1.cluster.PruneResponse"> & {
+  /**
+   * returns the pruned revisions
+   *
+   * @generated from field: repeated operator.v1.cluster.ClusterStatus revisions = 1;
+   */
+  revisions: ClusterStatus[];
+};
+
+/**
+ * Describes the message operator.v1.cluster.PruneResponse.
+ * Use `create(PruneResponseSchema)` to create a new message.
+ */
+export const PruneResponseSchema: GenMessage<PruneResponse> = /*@__PURE__*/
+  messageDesc(file_operator_v1_cluster_message, 16);
+
 /**
  * @generated from enum operator.v1.cluster.State
  */
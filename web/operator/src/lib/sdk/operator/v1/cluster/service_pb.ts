@@ -4,14 +4,14 @@
 
 import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
 import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { DescribeRequestSchema, DescribeResponseSchema, DestroyRequestSchema, DestroyResponseSchema, GetRequestSchema, GetResponseSchema, ListRequestSchema, ListResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
+import type { CancelRequestSchema, CancelResponseSchema, DescribeRequestSchema, DescribeResponseSchema, DestroyRequestSchema, DestroyResponseSchema, GetRequestSchema, GetResponseSchema, ListRequestSchema, ListResponseSchema, PruneRequestSchema, PruneResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
 import { file_operator_v1_cluster_message } from "./message_pb";
 
 /**
  * Describes the file operator/v1/cluster/service.proto.
  */
 export const file_operator_v1_cluster_service: GenFile = /*@__PURE__*/
-  fileDesc("CiFvcGVyYXRvci92MS9jbHVzdGVyL3NlcnZpY2UucHJvdG8SE29wZXJhdG9yLnYxLmNsdXN0ZXIyswMKDkNsdXN0ZXJTZXJ2aWNlEk0KBExpc3QSIC5vcGVyYXRvci52MS5jbHVzdGVyLkxpc3RSZXF1ZXN0GiEub3BlcmF0b3IudjEuY2x1c3Rlci5MaXN0UmVzcG9uc2UiABJKCgNHZXQSHy5vcGVyYXRvci52MS5jbHVzdGVyLkdldFJlcXVlc3QaIC5vcGVyYXRvci52MS5jbHVzdGVyLkdldFJlc3BvbnNlIgASWQoIRGVzY3JpYmUSJC5vcGVyYXRvci52MS5jbHVzdGVyLkRlc2NyaWJlUmVxdWVzdBolLm9wZXJhdG9yLnYxLmNsdXN0ZXIuRGVzY3JpYmVSZXNwb25zZSIAElMKBlVwZGF0ZRIiLm9wZXJhdG9yLnYxLmNsdXN0ZXIuVXBkYXRlUmVxdWVzdBojLm9wZXJhdG9yLnYxLmNsdXN0ZXIuVXBkYXRlUmVzcG9uc2UiABJWCgdEZXN0cm95EiMub3BlcmF0b3IudjEuY2x1c3Rlci5EZXN0cm95UmVxdWVzdBokLm9wZXJhdG9yLnYxLmNsdXN0ZXIuRGVzdHJveVJlc3BvbnNlIgBCNlo0Z2l0aHViLmNvbS9tZWdha3V1bC9taWFtL3BrZy9hcGkvb3BlcmF0b3IvdjEvY2x1c3RlcmIGcHJvdG8z", [file_operator_v1_cluster_message]);
+  fileDesc("CiFvcGVyYXRvci92MS9jbHVzdGVyL3NlcnZpY2UucHJvdG8SE29wZXJhdG9yLnYxLmNsdXN0ZXIy2gQKDkNsdXN0ZXJTZXJ2aWNlEk0KBExpc3QSIC5vcGVyYXRvci52MS5jbHVzdGVyLkxpc3RSZXF1ZXN0GiEub3BlcmF0b3IudjEuY2x1c3Rlci5MaXN0UmVzcG9uc2UiABJKCgNHZXQSHy5vcGVyYXRvci52MS5jbHVzdGVyLkdldFJlcXVlc3QaIC5vcGVyYXRvci52MS5jbHVzdGVyLkdldFJlc3BvbnNlIgASWQoIRGVzY3JpYmUSJC5vcGVyYXRvci52MS5jbHVzdGVyLkRlc2NyaWJlUmVxdWVzdBolLm9wZXJhdG9yLnYxLmNsdXN0ZXIuRGVzY3JpYmVSZXNwb25zZSIAElMKBlVwZGF0ZRIiLm9wZXJhdG9yLnYxLmNsdXN0ZXIuVXBkYXRlUmVxdWVzdBojLm9wZXJhdG9yLnYxLmNsdXN0ZXIuVXBkYXRlUmVzcG9uc2UiABJWCgdEZXN0cm95EiMub3BlcmF0b3IudjEuY2x1c3Rlci5EZXN0cm95UmVxdWVzdBokLm9wZXJhdG9yLnYxLmNsdXN0ZXIuRGVzdHJveVJlc3BvbnNlIgASUwoGQ2FuY2VsEiIub3BlcmF0b3IudjEuY2x1c3Rlci5DYW5jZWxSZXF1ZXN0GiMub3BlcmF0b3IudjEuY2x1c3Rlci5DYW5jZWxSZXNwb25zZSIAElAKBVBydW5lEiEub3BlcmF0b3IudjEuY2x1c3Rlci5QcnVuZVJlcXVlc3QaIi5vcGVyYXRvci52MS5jbHVzdGVyLlBydW5lUmVzcG9uc2UiAEI2WjRnaXRodWIuY29tL21lZ2FrdXVsL21pYW0vcGtnL2FwaS9vcGVyYXRvci92MS9jbHVzdGVyYgZwcm90bzM", [file_operator_v1_cluster_message]);
 
 /**
  * @generated from service operator.v1.cluster.ClusterService
@@ -57,6 +57,22 @@ export const ClusterService: GenService<{
     input: typeof DestroyRequestSchema;
     output: typeof DestroyResponseSchema;
   },
+  /**
+   * @generated from rpc operator.v1.cluster.ClusterService.Cancel
+   */
+  cancel: {
+    methodKind: "unary";
+    input: typeof CancelRequestSchema;
+    output: typeof CancelResponseSchema;
+  },
+  /**
+   * @generated from rpc operator.v1.cluster.ClusterService.Prune
+   */
+  prune: {
+    methodKind: "unary";
+    input: typeof PruneRequestSchema;
+    output: typeof PruneResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_operator_v1_cluster_service, 0);
 
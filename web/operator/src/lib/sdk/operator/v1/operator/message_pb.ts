@@ -4,13 +4,16 @@
 
 import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file operator/v1/operator/message.proto.
  */
 export const file_operator_v1_operator_message: GenFile = /*@__PURE__*/
-  fileDesc("CiJvcGVyYXRvci92MS9vcGVyYXRvci9tZXNzYWdlLnByb3RvEhRvcGVyYXRvci52MS5vcGVyYXRvciJdCg5PcGVyYXRvclN0YXR1cxIQCghyZXZpc2lvbhgCIAEoCRIqCgVzdGF0ZRgEIAEoDjIbLm9wZXJhdG9yLnYxLm9wZXJhdG9yLlN0YXRlEg0KBWVycm9yGAUgASgJIjQKDk9wZXJhdG9yQ29uZmlnEhAKCHJlcG9fdXJsGAIgASgJEhAKCHJlcG9fcmVmGAMgASgJIgwKCkdldFJlcXVlc3QiRgoLR2V0UmVzcG9uc2USNwoJcmV2aXNpb25zGAEgAygLMiQub3BlcmF0b3IudjEub3BlcmF0b3IuT3BlcmF0b3JTdGF0dXMiIwoPRGVzY3JpYmVSZXF1ZXN0EhAKCHJldmlzaW9uGAEgASgJIkgKEERlc2NyaWJlUmVzcG9uc2USNAoGY29uZmlnGAEgASgLMiQub3BlcmF0b3IudjEub3BlcmF0b3IuT3BlcmF0b3JDb25maWciRQoNVXBkYXRlUmVxdWVzdBI0CgZjb25maWcYASABKAsyJC5vcGVyYXRvci52MS5vcGVyYXRvci5PcGVyYXRvckNvbmZpZyIiCg5VcGRhdGVSZXNwb25zZRIQCghyZXZpc2lvbhgBIAEoCSIQCg5EZXN0cm95UmVxdWVzdCIRCg9EZXN0cm95UmVzcG9uc2UqPAoFU3RhdGUSCgoGQUNUSVZFEAASDAoISU5BQ1RJVkUQARINCglERVBMT1lJTkcQAhIKCgZGQUlMRUQQA0I3WjVnaXRodWIuY29tL21lZ2FrdXVsL21pYW0vcGtnL2FwaS9vcGVyYXRvci92MS9vcGVyYXRvcmIGcHJvdG8z");
+  fileDesc("CiJvcGVyYXRvci92MS9vcGVyYXRvci9tZXNzYWdlLnByb3RvEhRvcGVyYXRvci52MS5vcGVyYXRvciLiAQoOT3BlcmF0b3JTdGF0dXMSEAoIcmV2aXNpb24YAiABKAkSKgoFc3RhdGUYBCABKA4yGy5vcGVyYXRvci52MS5vcGVyYXRvci5TdGF0ZRINCgVlcnJvchgFIAEoCRIuCgpjcmVhdGVkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBISCgpjcmVhdGVkX2J5GAggASgJEg8KB21lc3NhZ2UYCSABKAkikgEKDk9wZXJhdG9yQ29uZmlnEm4KCHJlcG9fdXJsGAIgASgJQly6SFnYAQFyVDJSXigoaHR0cHM/fHNzaHxnaXQpOi8vW15ccy8/I10rKFsvPyNdXFMqKT98W0EtWmEtejAtOS5fLV0rQFtBLVphLXowLTkuLV0rOlteL11cUyopJBIQCghyZXBvX3JlZhgDIAEoCSIMCgpHZXRSZXF1ZXN0IkYKC0dldFJlc3BvbnNlEjcKCXJldmlzaW9ucxgBIAMoCzIkLm9wZXJhdG9yLnYxLm9wZXJhdG9yLk9wZXJhdG9yU3RhdHVzIisKD0Rlc2NyaWJlUmVxdWVzdBIYCghyZXZpc2lvbhgBIAEoCUIGukgDyAEBIkgKEERlc2NyaWJlUmVzcG9uc2USNAoGY29uZmlnGAEgASgLMiQub3BlcmF0b3IudjEub3BlcmF0b3IuT3BlcmF0b3JDb25maWciaAoNVXBkYXRlUmVxdWVzdBI8CgZjb25maWcYASABKAsyJC5vcGVyYXRvci52MS5vcGVyYXRvci5PcGVyYXRvckNvbmZpZ0IGukgDyAEBEhkKB21lc3NhZ2UYAiABKAlCCLpIBXIDGIAIIiIKDlVwZGF0ZVJlc3BvbnNlEhAKCHJldmlzaW9uGAEgASgJIisKDkRlc3Ryb3lSZXF1ZXN0EhkKB21lc3NhZ2UYASABKAlCCLpIBXIDGIAIIiMKD0Rlc3Ryb3lSZXNwb25zZRIQCghyZXZpc2lvbhgBIAEoCSI4Cg1DYW5jZWxSZXF1ZXN0EhgKCHJldmlzaW9uGAEgASgJQga6SAPIAQESDQoFZm9yY2UYAiABKAgiEAoOQ2FuY2VsUmVzcG9uc2UiDgoMUHJ1bmVSZXF1ZXN0IkgKDVBydW5lUmVzcG9uc2USNwoJcmV2aXNpb25zGAEgAygLMiQub3BlcmF0b3IudjEub3BlcmF0b3IuT3BlcmF0b3JTdGF0dXMqPAoFU3RhdGUSCgoGQUNUSVZFEAASDAoISU5BQ1RJVkUQARINCglERVBMT1lJTkcQAhIKCgZGQUlMRUQQA0I3WjVnaXRodWIuY29tL21lZ2FrdXVsL21pYW0vcGtnL2FwaS9vcGVyYXRvci92MS9vcGVyYXRvcmIGcHJvdG8z", [file_buf_validate_validate, file_google_protobuf_timestamp]);
 
 /**
  * @generated from message operator.v1.operator.OperatorStatus
@@ -30,6 +33,32 @@ export type OperatorStatus = Message<"operator.v1.operator.OperatorStatus"> & {
    * @generated from field: string error = 5;
    */
   error: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * time of the last state change
+   *
+   * @generated from field: google.protobuf.Timestamp updated_at = 7;
+   */
+  updatedAt?: Timestamp;
+
+  /**
+   * identity that requested the revision
+   *
+   * @generated from field: string created_by = 8;
+   */
+  createdBy: string;
+
+  /**
+   * change message provided by the author
+   *
+   * @generated from field: string message = 9;
+   */
+  message: string;
 };
 
 /**
@@ -79,7 +108,7 @@ export const GetRequestSchema: GenMessage<GetRequest> = /*@__PURE__*/
  */
 export type GetResponse = Message<"operator.v1.operator.GetResponse"> & {
   /**
-   * returns all revisions of the operator 
+   * returns all revisions of the operator (newest first)
    *
    * @generated from field: repeated operator.v1.operator.OperatorStatus revisions = 1;
    */
@@ -135,6 +164,13 @@ export type UpdateRequest = Message<"operator.v1.operator.UpdateRequest"> & {
    * @generated from field: operator.v1.operator.OperatorConfig config = 1;
    */
   config?: OperatorConfig;
+
+  /**
+   * describes the change, recorded on the revision
+   *
+   * @generated from field: string message = 2;
+   */
+  message: string;
 };
 
 /**
@@ -165,6 +201,12 @@ export const UpdateResponseSchema: GenMessage<UpdateResponse> = /*@__PURE__*/
  * @generated from message operator.v1.operator.DestroyRequest
  */
 export type DestroyRequest = Message<"operator.v1.operator.DestroyRequest"> & {
+  /**
+   * describes the change, recorded on the revision
+   *
+   * @generated from field: string message = 1;
+   */
+  message: string;
 };
 
 /**
@@ -178,6 +220,10 @@ export const DestroyRequestSchema: GenMessage<DestroyRequest> = /*@__PURE__*/
  * @generated from message operator.v1.operator.DestroyResponse
  */
 export type DestroyResponse = Message<"operator.v1.operator.DestroyResponse"> & {
+  /**
+   * @generated from field: string revision = 1;
+   */
+  revision: string;
 };
 
 /**
@@ -187,6 +233,77 @@ export type DestroyResponse = Message<"operator.v1.operator.DestroyResponse"> &
 export const DestroyResponseSchema: GenMessage<DestroyResponse> = /*@__PURE__*/
   messageDesc(file_operator_v1_operator_message, 9);
 
+/**
+ * @generated from message operator.v1.operator.CancelRequest
+ */
+export type CancelRequest = Message<"operator.v1.operator.CancelRequest"> & {
+  /**
+   * revision that is currently deploying
+   *
+   * @generated from field: string revision = 1;
+   */
+  revision: string;
+
+  /**
+   * releases the revision even if another operator instance still holds its lease
+   *
+   * @generated from field: bool force = 2;
+   */
+  force: boolean;
+};
+
+/**
+ * Describes the message operator.v1.operator.CancelRequest.
+ * Use `create(CancelRequestSchema)` to create a new message.
+ */
+export const CancelRequestSchema: GenMessage<CancelRequest> = /*@__PURE__*/
+  messageDesc(file_operator_v1_operator_message, 10);
+
+/**
+ * @generated from message operator.v1.operator.CancelResponse
+ */
+export type CancelResponse = Message<"operator.v1.operator.CancelResponse"> & {
+};
+
+/**
+ * Describes the message operator.v1.operator.CancelResponse.
+ * Use `create(CancelResponseSchema)` to create a new message.
+ */
+export const CancelResponseSchema: GenMessage<CancelResponse> = /*@__PURE__*/
+  messageDesc(file_operator_v1_operator_message, 11);
+
+/**
+ * @generated from message operator.v1.operator.PruneRequest
+ */
+export type PruneRequest = Message<"operator.v1.operator.PruneRequest"> & {
+};
+
+/**
+ * Describes the message operator.v1.operator.PruneRequest.
+ * Use `create(PruneRequestSchema)` to create a new message.
+ */
+export const PruneRequestSchema: GenMessage<PruneRequest> = /*@__PURE__*/
+  messageDesc(file_operator_v1_operator_message, 12);
+
+/**
+ * @generated from message operator.v1.operator.PruneResponse
+ */
+export type PruneResponse = Message<"operator.v1.operator.PruneResponse"> & {
+  /**
+   * returns the pruned revisions
+   *
+   * @generated from field: repeated operator.v1.operator.OperatorStatus revisions = 1;
+   */
+  revisions: OperatorStatus[];
+};
+
+/**
+ * Describes the message operator.v1.operator.PruneResponse.
+ * Use `create(PruneResponseSchema)` to create a new message.
+ */
+export const PruneResponseSchema: GenMessage<PruneResponse> = /*@__PURE__*/
+  messageDesc(file_operator_v1_operator_message, 13);
+
 /**
  * @generated from enum operator.v1.operator.State
  */
@@ -4,14 +4,14 @@
 
 import type { GenFile, GenService } from "@bufbuild/protobuf/codegenv2";
 import { fileDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { DescribeRequestSchema, DescribeResponseSchema, DestroyRequestSchema, DestroyResponseSchema, GetRequestSchema, GetResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
+import type { CancelRequestSchema, CancelResponseSchema, DescribeRequestSchema, DescribeResponseSchema, DestroyRequestSchema, DestroyResponseSchema, GetRequestSchema, GetResponseSchema, PruneRequestSchema, PruneResponseSchema, UpdateRequestSchema, UpdateResponseSchema } from "./message_pb";
 import { file_operator_v1_operator_message } from "./message_pb";
 
 /**
  * Describes the file operator/v1/operator/service.proto.
  */
 export const file_operator_v1_operator_service: GenFile = /*@__PURE__*/
-  fileDesc("CiJvcGVyYXRvci92MS9vcGVyYXRvci9zZXJ2aWNlLnByb3RvEhRvcGVyYXRvci52MS5vcGVyYXRvcjLwAgoSTWFpbnRlbmFuY2VTZXJ2aWNlEkwKA0dldBIgLm9wZXJhdG9yLnYxLm9wZXJhdG9yLkdldFJlcXVlc3QaIS5vcGVyYXRvci52MS5vcGVyYXRvci5HZXRSZXNwb25zZSIAElsKCERlc2NyaWJlEiUub3BlcmF0b3IudjEub3BlcmF0b3IuRGVzY3JpYmVSZXF1ZXN0GiYub3BlcmF0b3IudjEub3BlcmF0b3IuRGVzY3JpYmVSZXNwb25zZSIAElUKBlVwZGF0ZRIjLm9wZXJhdG9yLnYxLm9wZXJhdG9yLlVwZGF0ZVJlcXVlc3QaJC5vcGVyYXRvci52MS5vcGVyYXRvci5VcGRhdGVSZXNwb25zZSIAElgKB0Rlc3Ryb3kSJC5vcGVyYXRvci52MS5vcGVyYXRvci5EZXN0cm95UmVxdWVzdBolLm9wZXJhdG9yLnYxLm9wZXJhdG9yLkRlc3Ryb3lSZXNwb25zZSIAQjdaNWdpdGh1Yi5jb20vbWVnYWt1dWwvbWlhbS9wa2cvYXBpL29wZXJhdG9yL3YxL29wZXJhdG9yYgZwcm90bzM", [file_operator_v1_operator_message]);
+  fileDesc("CiJvcGVyYXRvci92MS9vcGVyYXRvci9zZXJ2aWNlLnByb3RvEhRvcGVyYXRvci52MS5vcGVyYXRvcjKbBAoSTWFpbnRlbmFuY2VTZXJ2aWNlEkwKA0dldBIgLm9wZXJhdG9yLnYxLm9wZXJhdG9yLkdldFJlcXVlc3QaIS5vcGVyYXRvci52MS5vcGVyYXRvci5HZXRSZXNwb25zZSIAElsKCERlc2NyaWJlEiUub3BlcmF0b3IudjEub3BlcmF0b3IuRGVzY3JpYmVSZXF1ZXN0GiYub3BlcmF0b3IudjEub3BlcmF0b3IuRGVzY3JpYmVSZXNwb25zZSIAElUKBlVwZGF0ZRIjLm9wZXJhdG9yLnYxLm9wZXJhdG9yLlVwZGF0ZVJlcXVlc3QaJC5vcGVyYXRvci52MS5vcGVyYXRvci5VcGRhdGVSZXNwb25zZSIAElgKB0Rlc3Ryb3kSJC5vcGVyYXRvci52MS5vcGVyYXRvci5EZXN0cm95UmVxdWVzdBolLm9wZXJhdG9yLnYxLm9wZXJhdG9yLkRlc3Ryb3lSZXNwb25zZSIAElUKBkNhbmNlbBIjLm9wZXJhdG9yLnYxLm9wZXJhdG9yLkNhbmNlbFJlcXVlc3QaJC5vcGVyYXRvci52MS5vcGVyYXRvci5DYW5jZWxSZXNwb25zZSIAElIKBVBydW5lEiIub3BlcmF0b3IudjEub3BlcmF0b3IuUHJ1bmVSZXF1ZXN0GiMub3BlcmF0b3IudjEub3BlcmF0b3IuUHJ1bmVSZXNwb25zZSIAQjdaNWdpdGh1Yi5jb20vbWVnYWt1dWwvbWlhbS9wa2cvYXBpL29wZXJhdG9yL3YxL29wZXJhdG9yYgZwcm90bzM", [file_operator_v1_operator_message]);
 
 /**
  * @generated from service operator.v1.operator.MaintenanceService
@@ -49,6 +49,22 @@ export const MaintenanceService: GenService<{
     input: typeof DestroyRequestSchema;
     output: typeof DestroyResponseSchema;
   },
+  /**
+   * @generated from rpc operator.v1.operator.MaintenanceService.Cancel
+   */
+  cancel: {
+    methodKind: "unary";
+    input: typeof CancelRequestSchema;
+    output: typeof CancelResponseSchema;
+  },
+  /**
+   * @generated from rpc operator.v1.operator.MaintenanceService.Prune
+   */
+  prune: {
+    methodKind: "unary";
+    input: typeof PruneRequestSchema;
+    output: typeof PruneResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_operator_v1_operator_service, 0);
 
@@ -0,0 +1,20 @@
+//go:build webui
+
+package operator
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:build
+var build embed.FS
+
+// Files returns the static build of the web ui.
+func Files() fs.FS {
+	files, err := fs.Sub(build, "build")
+	if err != nil {
+		panic(err)
+	}
+	return files
+}
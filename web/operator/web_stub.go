@@ -0,0 +1,10 @@
+//go:build !webui
+
+package operator
+
+import "io/fs"
+
+// Files returns nil, the operator was built without the web ui.
+func Files() fs.FS {
+	return nil
+}